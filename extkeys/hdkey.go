@@ -8,6 +8,8 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"strconv"
+	"strings"
 
 	"github.com/btcsuite/btcd/btcec"
 	"github.com/btcsuite/btcd/chaincfg"
@@ -82,6 +84,7 @@ var (
 	ErrInvalidKeyLen              = errors.New("serialized extended key length is invalid")
 	ErrDerivingChild              = errors.New("error deriving child key")
 	ErrInvalidMasterKey           = errors.New("invalid master key supplied")
+	ErrInvalidPath                = errors.New("invalid derivation path")
 )
 
 var (
@@ -249,6 +252,33 @@ func (k *ExtendedKey) Derive(path []uint32) (*ExtendedKey, error) {
 	return extKey, nil
 }
 
+// ParsePath converts derivation path in a conventional textual form (e.g. m/44'/60'/0'/0/0)
+// into a list of child indexes, suitable for Derive. Hardened indexes are marked with ' or h.
+func ParsePath(path string) ([]uint32, error) {
+	components := strings.Split(strings.TrimSpace(path), "/")
+	if len(components) < 2 || components[0] != "m" {
+		return nil, ErrInvalidPath
+	}
+
+	indexes := make([]uint32, 0, len(components)-1)
+	for _, component := range components[1:] {
+		offset := uint32(0)
+		if strings.HasSuffix(component, "'") || strings.HasSuffix(component, "h") {
+			offset = HardenedKeyStart
+			component = component[:len(component)-1]
+		}
+
+		index, err := strconv.ParseUint(component, 10, 32)
+		if err != nil || uint32(index) >= HardenedKeyStart {
+			return nil, ErrInvalidPath
+		}
+
+		indexes = append(indexes, offset+uint32(index))
+	}
+
+	return indexes, nil
+}
+
 // Neuter returns a new extended public key from a give extended private key.
 // If the input extended key is already public, it will be returned unaltered.
 func (k *ExtendedKey) Neuter() (*ExtendedKey, error) {
@@ -510,6 +510,33 @@ func TestBIP44ChildDerivation(t *testing.T) {
 	t.Logf("Account 1 key: %s", accounKey2.String())
 }
 
+func TestParsePath(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected []uint32
+		err      error
+	}{
+		{"m/44'/60'/0'/0/0", []uint32{extkeys.HardenedKeyStart + 44, extkeys.HardenedKeyStart + 60, extkeys.HardenedKeyStart, 0, 0}, nil},
+		{"m/44h/60h/0h/0/1", []uint32{extkeys.HardenedKeyStart + 44, extkeys.HardenedKeyStart + 60, extkeys.HardenedKeyStart, 0, 1}, nil},
+		{"m/0", []uint32{0}, nil},
+		{"m", nil, extkeys.ErrInvalidPath},
+		{"44'/60'/0'/0/0", nil, extkeys.ErrInvalidPath},
+		{"m/44'/x/0", nil, extkeys.ErrInvalidPath},
+		{"m/2147483648", nil, extkeys.ErrInvalidPath},
+	}
+
+	for _, test := range tests {
+		path, err := extkeys.ParsePath(test.path)
+		if err != test.err {
+			t.Errorf("ParsePath(%s): unexpected error -- got: %v, want: %v", test.path, err, test.err)
+			continue
+		}
+		if !reflect.DeepEqual(path, test.expected) {
+			t.Errorf("ParsePath(%s): path mismatch -- got: %v, want: %v", test.path, path, test.expected)
+		}
+	}
+}
+
 //func TestNewKey(t *testing.T) {
 //	mnemonic := NewMnemonic()
 //
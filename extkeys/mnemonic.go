@@ -7,6 +7,7 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"io"
 	"math/big"
 	"strings"
 
@@ -119,6 +120,12 @@ func (m *Mnemonic) MnemonicSeed(mnemonic string, password string) []byte {
 
 // MnemonicPhrase returns a human readable seed for BIP32 Hierarchical Deterministic Wallets
 func (m *Mnemonic) MnemonicPhrase(strength, language Language) (string, error) {
+	return m.MnemonicPhraseFromReader(rand.Reader, strength, language)
+}
+
+// MnemonicPhraseFromReader works like MnemonicPhrase, but reads initial entropy from r.
+// Unless r is a cryptographically secure source, it must only be used in tests.
+func (m *Mnemonic) MnemonicPhraseFromReader(r io.Reader, strength, language Language) (string, error) {
 	wordList, err := m.WordList(language)
 	if err != nil {
 		return "", err
@@ -134,7 +141,7 @@ func (m *Mnemonic) MnemonicPhrase(strength, language Language) (string, error) {
 
 	// First, an initial entropy of ENT bits is generated
 	entropy := make([]byte, strength/8)
-	_, err = rand.Read(entropy)
+	_, err = io.ReadFull(r, entropy)
 
 	if err != nil {
 		return "", err
@@ -218,6 +225,44 @@ func (m *Mnemonic) ValidMnemonic(mnemonic string, language Language) bool {
 	return true
 }
 
+// ValidMnemonicChecksum validates checksum encoded in mnemonic string (see BIP39).
+// Words are expected to be validated beforehand (see ValidMnemonic).
+func (m *Mnemonic) ValidMnemonicChecksum(mnemonic string, language Language) bool {
+	wordList, err := m.WordList(language)
+	if err != nil {
+		return false
+	}
+
+	words := strings.Fields(mnemonic)
+	numOfWords := len(words)
+	if numOfWords%3 != 0 || numOfWords < 12 || numOfWords > 24 {
+		return false
+	}
+
+	// Concatenate 11-bit word indexes back into entropy followed by checksum bits
+	entropyBigInt := big.NewInt(0)
+	for _, word := range words {
+		index := indexOf(wordList, word)
+		if index < 0 {
+			return false
+		}
+
+		entropyBigInt.Mul(entropyBigInt, rightShift11BitsDivider)
+		entropyBigInt.Or(entropyBigInt, big.NewInt(int64(index)))
+	}
+
+	checksumBitLength := uint(numOfWords * 11 / 33)
+	checksumMask := big.NewInt(int64(1<<checksumBitLength - 1))
+	checksum := new(big.Int).And(entropyBigInt, checksumMask)
+	entropyBigInt.Rsh(entropyBigInt, checksumBitLength)
+
+	entropy := padByteSlice(entropyBigInt.Bytes(), int(checksumBitLength)*4)
+	hash := sha256.Sum256(entropy)
+	expectedChecksum := int64(hash[0] >> (8 - checksumBitLength))
+
+	return checksum.Int64() == expectedChecksum
+}
+
 // WordList returns list of words for a given language
 func (m *Mnemonic) WordList(language Language) (*WordList, error) {
 	if m.wordLists[language] == nil {
@@ -253,6 +298,15 @@ func contains(wordList *WordList, e string) bool {
 	return false
 }
 
+func indexOf(wordList *WordList, e string) int {
+	for i, a := range wordList {
+		if a == e {
+			return i
+		}
+	}
+	return -1
+}
+
 func padByteSlice(slice []byte, length int) []byte { //nolint: unparam
 	newSlice := make([]byte, length-len(slice))
 	return append(newSlice, slice...)
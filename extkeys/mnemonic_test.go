@@ -1,6 +1,7 @@
 package extkeys_test
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -36,6 +37,10 @@ func TestMnemonicPhrase(t *testing.T) {
 		if !mnemonic.ValidMnemonic(phrase, language) {
 			t.Error("Seed is not valid Mnenomic")
 		}
+
+		if !mnemonic.ValidMnemonicChecksum(phrase, language) {
+			t.Error("Seed has invalid checksum")
+		}
 	}
 
 	// run against test vectors
@@ -61,6 +66,28 @@ func TestMnemonicPhrase(t *testing.T) {
 	}
 }
 
+func TestMnemonicPhraseFromReader(t *testing.T) {
+	mnemonic := extkeys.NewMnemonic(extkeys.Salt)
+
+	// BIP39 test vector for 0x7f7f...7f entropy
+	entropy := bytes.Repeat([]byte{0x7f}, 16)
+	phrase, err := mnemonic.MnemonicPhraseFromReader(bytes.NewReader(entropy), 128, extkeys.EnglishLanguage)
+	if err != nil {
+		t.Fatalf("could not create mnemonic: %s", err)
+	}
+
+	expected := "legal winner thank year wave sausage worth useful legal winner thank yellow"
+	if phrase != expected {
+		t.Errorf("unexpected mnemonic: %s (expected: %s)", phrase, expected)
+	}
+
+	// not enough entropy
+	_, err = mnemonic.MnemonicPhraseFromReader(bytes.NewReader(entropy[:8]), 128, extkeys.EnglishLanguage)
+	if err == nil {
+		t.Error("expected error for short entropy source")
+	}
+}
+
 func LoadVectorsFile(path string) (*VectorsFile, error) {
 	fp, err := os.Open(path)
 	if err != nil {
@@ -1,62 +1,191 @@
 package account
 
 import (
+	"bytes"
 	"context"
-	"encoding/json"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"math/big"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
 
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/accounts/keystore"
 	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	whisper "github.com/ethereum/go-ethereum/whisper/whisperv6"
 	"github.com/status-im/status-go/extkeys"
 	"github.com/status-im/status-go/geth/common"
 	"github.com/status-im/status-go/geth/rpc"
 )
 
+// whisperWorkTime is the maximum time (in seconds) spent on proof of work for a sent Whisper message.
+const whisperWorkTime = 5
+
+// DefaultDerivationPath is a BIP44 path of account key generated by CreateAccount (CKD#1).
+const DefaultDerivationPath = "m/44'/60'/0'/0/0"
+
 // errors
 var (
 	ErrAddressToAccountMappingFailure  = errors.New("cannot retrieve a valid account for a given address")
 	ErrAccountToKeyMappingFailure      = errors.New("cannot retrieve a valid key for a given account")
 	ErrWhisperIdentityInjectionFailure = errors.New("failed to inject identity into Whisper")
 	ErrWhisperClearIdentitiesFailure   = errors.New("failed to clear whisper identities")
+	ErrWhisperIdentityNotFound         = errors.New("whisper identity has not been added")
+	ErrWhisperSymKeyNotFound           = errors.New("whisper symmetric key not found")
 	ErrNoAccountSelected               = errors.New("no account has been selected, please login")
 	ErrInvalidMasterKeyCreated         = errors.New("can not create master extended key")
+	ErrSelectedAccountDeletion         = errors.New("cannot delete selected account, please logout first")
+	ErrInvalidMnemonic                 = errors.New("mnemonic phrase is invalid: unknown words or bad checksum")
+	ErrInvalidPrivateKeyEncoding       = errors.New("private key must be a hex encoded 32-byte string")
+	ErrInvalidPrivateKey               = errors.New("private key is outside of the curve order range")
+	ErrInvalidExtendedKey              = errors.New("extended key must be a base58 encoded BIP32 private key (xprv)")
+	ErrKeyFileMalformed                = errors.New("key file is not a valid JSON key")
+	ErrKeyFileNameMismatch             = errors.New("key file name does not match the address it contains")
+	ErrNotSelectedAccount              = errors.New("only the selected account can be used for signing")
+	ErrInvalidSignParams               = errors.New("invalid signing parameters, address and hex encoded data are expected")
+	ErrInvalidAccountsCount            = errors.New("number of accounts to recover must be positive")
+	ErrNoRPCClient                     = errors.New("RPC client is not available")
+	ErrInvalidSignature                = errors.New("signature must be 65 bytes long, with V value of 27 or 28")
 )
 
+// SelectedAccountChangedHandler defines a handler invoked whenever selected account changes.
+// Address of newly selected account is passed, or empty string if selection is cleared.
+type SelectedAccountChangedHandler func(address string)
+
+// WhisperIdentityRotatedHandler defines a handler invoked once Whisper identity of the selected
+// account is rotated. IDs of the replaced and of the new key pair are passed.
+type WhisperIdentityRotatedHandler func(oldKeyID, newKeyID string)
+
+// MigrationProgressHandler defines a handler invoked while keys are migrated, see
+// UpgradeKeystoreSecurityWithProgress. Number of processed keys and the total one are passed.
+type MigrationProgressHandler func(done, total int)
+
+// Logger receives outcomes of account operations. Context is passed as key/value pairs,
+// the same way go-ethereum's log.Logger expects it (so the latter can be used directly).
+type Logger interface {
+	Debug(msg string, ctx ...interface{})
+	Info(msg string, ctx ...interface{})
+	Warn(msg string, ctx ...interface{})
+}
+
+// noopLogger discards all log records, it is used unless another logger is set.
+type noopLogger struct{}
+
+func (noopLogger) Debug(msg string, ctx ...interface{}) {}
+func (noopLogger) Info(msg string, ctx ...interface{})  {}
+func (noopLogger) Warn(msg string, ctx ...interface{})  {}
+
 // Manager represents account manager interface
 type Manager struct {
-	nodeManager     common.NodeManager
-	selectedAccount *common.SelectedExtKey // account that was processed during the last call to SelectAccount()
+	nodeManager common.NodeManager
+
+	keyFiles keyFileIndex // address to key file index used by VerifyAccountPassword
+	labels   labelStore   // account labels, see SetAccountLabel
+	keyStore KeyStore     // storage of account keys set by SetKeyStore, keystore of the node is used if nil
+
+	keyStoreMu sync.Mutex // serializes mutations of keystore, so that key files are never written concurrently
+
+	mu                            sync.RWMutex
+	selectedAccount               *common.SelectedExtKey // account that was processed during the last call to SelectAccount()
+	selectedAccountChangedHandler SelectedAccountChangedHandler
+	whisperIdentities             map[gethcommon.Address]*keystore.Key // identities injected in addition to selected account
+	rotatedIdentity               *ecdsa.PrivateKey                    // identity replacing the one of selected account, see RotateWhisperIdentity
+	whisperIdentityRotatedHandler WhisperIdentityRotatedHandler
+	entropy                       io.Reader // source of randomness for mnemonic generation
+	passwordPolicy                PasswordPolicy
+	nonceSource                   NonceSource // nonces of transactions sent locally, see PendingNonce
+
+	logMu sync.RWMutex // guards log separately, so that logging is never blocked by a stalled select
+	log   Logger
 }
 
 // NewManager returns new node account manager
 func NewManager(nodeManager common.NodeManager) *Manager {
 	return &Manager{
 		nodeManager: nodeManager,
+		log:         noopLogger{},
+		entropy:     rand.Reader,
+	}
+}
+
+// SetEntropySource sets the source of randomness used to generate mnemonic phrases of new accounts.
+// It allows tests to create accounts deterministically, and must not be used otherwise.
+// Passing nil restores the default, crypto/rand.Reader.
+func (m *Manager) SetEntropySource(r io.Reader) {
+	if r == nil {
+		r = rand.Reader
+	}
+
+	m.mu.Lock()
+	m.entropy = r
+	m.mu.Unlock()
+}
+
+// entropySource returns currently set source of randomness.
+func (m *Manager) entropySource() io.Reader {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.entropy == nil {
+		return rand.Reader
+	}
+	return m.entropy
+}
+
+// SetLogger sets logger receiving outcomes of account operations. Passwords and keys are never logged.
+// Passing nil disables logging.
+func (m *Manager) SetLogger(logger Logger) {
+	if logger == nil {
+		logger = noopLogger{}
+	}
+
+	m.logMu.Lock()
+	m.log = logger
+	m.logMu.Unlock()
+}
+
+// logger returns currently set logger.
+func (m *Manager) logger() Logger {
+	m.logMu.RLock()
+	defer m.logMu.RUnlock()
+
+	if m.log == nil {
+		return noopLogger{}
+	}
+	return m.log
+}
+
+// logResult logs outcome of an operation: message is logged at info level if operation succeeded,
+// and at warning level (along with error) otherwise.
+func (m *Manager) logResult(err error, msg string, ctx ...interface{}) {
+	if err != nil {
+		m.logger().Warn(msg+" failed", append(ctx, "err", err)...)
+		return
 	}
+	m.logger().Info(msg, ctx...)
 }
 
 // CreateAccount creates an internal geth account
 // BIP44-compatible keys are generated: CKD#1 is stored as account key, CKD#2 stored as sub-account root
 // Public key of CKD#1 is returned, with CKD#2 securely encoded into account key file (to be used for
-// sub-account derivations)
+// sub-account derivations). Like everywhere else, address is returned in EIP-55 checksummed form.
 func (m *Manager) CreateAccount(password string) (address, pubKey, mnemonic string, err error) {
-	// generate mnemonic phrase
-	mn := extkeys.NewMnemonic(extkeys.Salt)
-	mnemonic, err = mn.MnemonicPhrase(128, extkeys.EnglishLanguage)
-	if err != nil {
-		return "", "", "", fmt.Errorf("can not create mnemonic seed: %v", err)
-	}
+	defer func() { m.logResult(err, "create account", "address", address) }()
 
-	// generate extended master key (see BIP32)
-	extKey, err := extkeys.NewMaster(mn.MnemonicSeed(mnemonic, password), []byte(extkeys.Salt))
+	mnemonic, extKey, err := m.newMasterKey(password)
 	if err != nil {
-		return "", "", "", fmt.Errorf("can not create master extended key: %v", err)
+		return "", "", "", err
 	}
 
 	// import created key into account keystore
@@ -68,18 +197,227 @@ func (m *Manager) CreateAccount(password string) (address, pubKey, mnemonic stri
 	return address, pubKey, mnemonic, nil
 }
 
+// CreateAccountInfo describes an account created by CreateAccountV2.
+type CreateAccountInfo struct {
+	Address        string // hex encoded address of the account key
+	PubKey         string // hex encoded public key of the account key
+	Mnemonic       string // mnemonic phrase the account can be recovered from
+	DerivationPath string // BIP44 path of the account key, DefaultDerivationPath
+}
+
+// accountDerivationPath returns BIP44 path of the i-th account key, m/44'/60'/0'/0/i.
+func accountDerivationPath(i int) string {
+	return fmt.Sprintf("m/44'/60'/0'/0/%d", i)
+}
+
+// CreateAccountV2 creates an account just like CreateAccount does,
+// but returns its details as a struct.
+func (m *Manager) CreateAccountV2(password string) (CreateAccountInfo, error) {
+	address, pubKey, mnemonic, err := m.CreateAccount(password)
+	if err != nil {
+		return CreateAccountInfo{}, err
+	}
+
+	return CreateAccountInfo{
+		Address:        address,
+		PubKey:         pubKey,
+		Mnemonic:       mnemonic,
+		DerivationPath: DefaultDerivationPath,
+	}, nil
+}
+
+// CreateAccountContext creates an account just like CreateAccount does, but aborts the derivation
+// once ctx is done, returning ctx.Err() without waiting for it. Key encryption itself can't be
+// interrupted: if ctx is done while the key is being stored, the stored key is deleted afterwards,
+// so that no account is left behind whose mnemonic the caller never received.
+func (m *Manager) CreateAccountContext(ctx context.Context, password string) (address, pubKey, mnemonic string, err error) {
+	result, err := runAccountTask(ctx, func() (r accountTaskResult) {
+		var extKey *extkeys.ExtendedKey
+		r.mnemonic, extKey, r.err = m.newMasterKey(password)
+		if r.err != nil {
+			return
+		}
+		if r.err = ctx.Err(); r.err != nil {
+			return
+		}
+
+		r.address, r.pubKey, r.err = m.importExtendedKeyContext(ctx, extKey, password)
+		return
+	})
+	if err != nil {
+		return "", "", "", err
+	}
+
+	return result.address, result.pubKey, result.mnemonic, nil
+}
+
+// CreateAccountAt creates an internal geth account, with account key derived at a given
+// BIP44 path (e.g. m/44'/60'/0'/0/0). Knowing both mnemonic and path allows to reconstruct
+// the same address with other clients and hardware wallets.
+func (m *Manager) CreateAccountAt(password, path string) (address, pubKey, mnemonic string, err error) {
+	indexes, err := extkeys.ParsePath(path)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	mnemonic, extKey, err := m.newMasterKey(password)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	address, pubKey, err = m.importExtendedKeyAt(extKey, indexes, password)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	return address, pubKey, mnemonic, nil
+}
+
+// CreateAccountWithParams creates an internal geth account, just like CreateAccount does,
+// but key is stored encrypted using provided scrypt parameters (instead of the ones keystore is configured with).
+// Use keystore.StandardScryptN/P for stronger protection, or keystore.LightScryptN/P for low-end devices.
+func (m *Manager) CreateAccountWithParams(password string, scryptN, scryptP int) (address, pubKey, mnemonic string, err error) {
+	keyStore, err := m.accountKeyStore()
+	if err != nil {
+		return "", "", "", err
+	}
+
+	mnemonic, extKey, err := m.newMasterKey(password)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	key, err := newKeyFromMasterKey(extKey)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	keyJSON, err := keystore.EncryptKey(key, password, scryptN, scryptP)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	m.keyStoreMu.Lock()
+	err = keyStore.ImportKey(keyJSON, password)
+	m.keyStoreMu.Unlock()
+	if err != nil {
+		return "", "", "", err
+	}
+	m.keyFiles.invalidate()
+
+	address = key.Address.Hex()
+	pubKey = gethcommon.ToHex(crypto.FromECDSAPub(&key.PrivateKey.PublicKey))
+
+	return address, pubKey, mnemonic, nil
+}
+
+// CreateRandomAccount creates an account out of a random key, skipping BIP39 and BIP32 entirely.
+// It's faster than CreateAccount, but there's no mnemonic to recover the account from, nor
+// sub-accounts can be derived. Intended for ephemeral accounts, ExportAccount can be used for backups.
+func (m *Manager) CreateRandomAccount(password string) (address, pubKey string, err error) {
+	defer func() { m.logResult(err, "create random account", "address", address) }()
+
+	if err := m.checkPasswordPolicy(password); err != nil {
+		return "", "", err
+	}
+
+	keyStore, err := m.accountKeyStore()
+	if err != nil {
+		return "", "", err
+	}
+
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		return "", "", err
+	}
+
+	key := newKeyFromECDSA(privateKey)
+	if err := m.storeKey(keyStore, key, password); err != nil {
+		return "", "", err
+	}
+
+	address = key.Address.Hex()
+	pubKey = gethcommon.ToHex(crypto.FromECDSAPub(&privateKey.PublicKey))
+
+	return address, pubKey, nil
+}
+
+// accountTaskResult holds outcome of account creation or recovery run by runAccountTask.
+type accountTaskResult struct {
+	address, pubKey, mnemonic string
+	err                       error
+}
+
+// runAccountTask runs task in a separate goroutine, and waits for it to finish or for ctx to be done,
+// whichever happens first.
+func runAccountTask(ctx context.Context, task func() accountTaskResult) (accountTaskResult, error) {
+	if err := ctx.Err(); err != nil {
+		return accountTaskResult{}, err
+	}
+
+	done := make(chan accountTaskResult, 1)
+	go func() {
+		done <- task()
+	}()
+
+	select {
+	case r := <-done:
+		return r, r.err
+	case <-ctx.Done():
+		return accountTaskResult{}, ctx.Err()
+	}
+}
+
+// masterKeyFromMnemonic validates mnemonic phrase, and re-creates extended master key (see BIP32) out of it.
+func masterKeyFromMnemonic(mnemonic, passphrase string) (*extkeys.ExtendedKey, error) {
+	if err := ValidateMnemonic(mnemonic); err != nil {
+		return nil, err
+	}
+
+	mn := extkeys.NewMnemonic(extkeys.Salt)
+	extKey, err := extkeys.NewMaster(mn.MnemonicSeed(mnemonic, passphrase), []byte(extkeys.Salt))
+	if err != nil {
+		return nil, ErrInvalidMasterKeyCreated
+	}
+
+	return extKey, nil
+}
+
+// newMasterKey generates mnemonic phrase and extended master key (see BIP32) out of it.
+// All the ways of creating a new account go through it, so the password policy is checked here.
+func (m *Manager) newMasterKey(password string) (mnemonic string, extKey *extkeys.ExtendedKey, err error) {
+	if err := m.checkPasswordPolicy(password); err != nil {
+		return "", nil, err
+	}
+
+	mn := extkeys.NewMnemonic(extkeys.Salt)
+	mnemonic, err = mn.MnemonicPhraseFromReader(m.entropySource(), 128, extkeys.EnglishLanguage)
+	if err != nil {
+		return "", nil, fmt.Errorf("can not create mnemonic seed: %v", err)
+	}
+
+	extKey, err = extkeys.NewMaster(mn.MnemonicSeed(mnemonic, password), []byte(extkeys.Salt))
+	if err != nil {
+		return "", nil, fmt.Errorf("can not create master extended key: %v", err)
+	}
+
+	return mnemonic, extKey, nil
+}
+
 // CreateChildAccount creates sub-account for an account identified by parent address.
 // CKD#2 is used as root for master accounts (when parentAddress is "").
 // Otherwise (when parentAddress != ""), child is derived directly from parent.
 func (m *Manager) CreateChildAccount(parentAddress, password string) (address, pubKey string, err error) {
-	keyStore, err := m.nodeManager.AccountKeyStore()
+	keyStore, err := m.accountKeyStore()
 	if err != nil {
 		return "", "", err
 	}
 
+	m.mu.RLock()
 	if parentAddress == "" && m.selectedAccount != nil { // derive from selected account by default
 		parentAddress = m.selectedAccount.Address.Hex()
 	}
+	m.mu.RUnlock()
 
 	if parentAddress == "" {
 		return "", "", ErrNoAccountSelected
@@ -91,227 +429,1127 @@ func (m *Manager) CreateChildAccount(parentAddress, password string) (address, p
 	}
 
 	// make sure that given password can decrypt key associated with a given parent address
-	account, accountKey, err := keyStore.AccountDecryptedKey(account, password)
+	accountKey, err := keyStore.GetKey(account.Address, password)
 	if err != nil {
 		return "", "", fmt.Errorf("%s: %v", ErrAccountToKeyMappingFailure.Error(), err)
 	}
+	defer zeroKey(accountKey)
 
 	parentKey, err := extkeys.NewKeyFromString(accountKey.ExtendedKey.String())
 	if err != nil {
 		return "", "", err
 	}
 
-	// derive child key
-	childKey, err := parentKey.Child(accountKey.SubAccountIndex)
+	// derive child key
+	childKey, err := parentKey.Child(accountKey.SubAccountIndex)
+	if err != nil {
+		return "", "", err
+	}
+	if err = keyStore.IncSubAccountIndex(account.Address, password); err != nil {
+		return "", "", err
+	}
+	accountKey.SubAccountIndex++
+
+	// import derived key into account keystore
+	address, pubKey, err = m.importExtendedKey(childKey, password)
+	if err != nil {
+		return
+	}
+
+	// update in-memory selected account, if it's the parent; its key is kept, as it's
+	// injected into Whisper, and only sub-account index is updated
+	m.mu.Lock()
+	if m.selectedAccount != nil && m.selectedAccount.Address == account.Address {
+		m.selectedAccount.AccountKey.SubAccountIndex = accountKey.SubAccountIndex
+	}
+	m.mu.Unlock()
+
+	return address, pubKey, nil
+}
+
+// RecoverAccount re-creates master key using given details.
+// Once master key is re-generated, it is inserted into keystore (if not already there).
+// Password is used as BIP39 passphrase, just like it's done when account is created.
+func (m *Manager) RecoverAccount(password, mnemonic string) (address, pubKey string, err error) {
+	return m.RecoverAccountWithPassphrase(password, mnemonic, password)
+}
+
+// RecoverAccountContext re-creates master key just like RecoverAccount does, but aborts the derivation
+// once ctx is done, returning ctx.Err(). See CreateAccountContext for cancellation details.
+func (m *Manager) RecoverAccountContext(ctx context.Context, password, mnemonic string) (address, pubKey string, err error) {
+	result, err := runAccountTask(ctx, func() (r accountTaskResult) {
+		var extKey *extkeys.ExtendedKey
+		extKey, r.err = masterKeyFromMnemonic(mnemonic, password)
+		if r.err != nil {
+			return
+		}
+		if r.err = ctx.Err(); r.err != nil {
+			return
+		}
+
+		r.address, r.pubKey, r.err = m.importExtendedKeyContext(ctx, extKey, password)
+		return
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	return result.address, result.pubKey, nil
+}
+
+// RecoverAccountWithPassphrase re-creates master key using given details and a BIP39 passphrase
+// (so called 25th word), which is fed into seed derivation instead of password. The password only
+// encrypts the key, so passing it as passphrase recovers the same account RecoverAccount does.
+// Once master key is re-generated, it is inserted into keystore (if not already there).
+func (m *Manager) RecoverAccountWithPassphrase(password, mnemonic, passphrase string) (address, pubKey string, err error) {
+	defer func() { m.logResult(err, "recover account", "address", address) }()
+
+	// re-create extended key (see BIP32)
+	extKey, err := masterKeyFromMnemonic(mnemonic, passphrase)
+	if err != nil {
+		return "", "", err
+	}
+
+	// import re-created key into account keystore
+	address, pubKey, err = m.importExtendedKey(extKey, password)
+	if err != nil {
+		return
+	}
+
+	return address, pubKey, nil
+}
+
+// RecoverAccounts re-creates master key using given details, and derives count sequential accounts
+// out of it, at m/44'/60'/0'/0/i paths. All of them are inserted into keystore (if not already there).
+// The first account, including its sub-accounts, is the one RecoverAccount returns, while the others
+// share its sub-account root. Mnemonic is not included into returned details.
+func (m *Manager) RecoverAccounts(password, mnemonic string, count int) ([]CreateAccountInfo, error) {
+	if count <= 0 {
+		return nil, ErrInvalidAccountsCount
+	}
+
+	extKey, err := masterKeyFromMnemonic(mnemonic, password)
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]CreateAccountInfo, 0, count)
+	for i := 0; i < count; i++ {
+		path := accountDerivationPath(i)
+		indexes, err := extkeys.ParsePath(path)
+		if err != nil {
+			return nil, err
+		}
+
+		var address, pubKey string
+		if i == 0 {
+			// the same account RecoverAccount imports
+			address, pubKey, err = m.importExtendedKey(extKey, password)
+		} else {
+			address, pubKey, err = m.importExtendedKeyAt(extKey, indexes, password)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		infos = append(infos, CreateAccountInfo{
+			Address:        address,
+			PubKey:         pubKey,
+			DerivationPath: path,
+		})
+	}
+
+	return infos, nil
+}
+
+// ExportAccount decrypts key of an account identified by a given address, and returns it serialized
+// as standard web3 secret storage (v3 keystore) JSON, encrypted with the same password.
+// Exported JSON can be used as a backup, or imported into another client.
+func (m *Manager) ExportAccount(address, password string) ([]byte, error) {
+	keyStore, err := m.accountKeyStore()
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := common.ParseAccountString(address)
+	if err != nil {
+		return nil, ErrAddressToAccountMappingFailure
+	}
+
+	return keyStore.ExportKey(account.Address, password)
+}
+
+// ImportAccount imports web3 secret storage (v3 keystore) JSON into keystore.
+// Key is stored encrypted with the same password it was encrypted with originally.
+func (m *Manager) ImportAccount(keyJSON []byte, password string) (address string, err error) {
+	keyStore, err := m.accountKeyStore()
+	if err != nil {
+		return "", err
+	}
+
+	key, err := keystore.DecryptKey(keyJSON, password)
+	if err != nil {
+		return "", err
+	}
+
+	if err := m.storeKey(keyStore, key, password); err != nil {
+		return "", err
+	}
+
+	return key.Address.Hex(), nil
+}
+
+// ImportPrivateKey imports raw hex encoded ECDSA private key into keystore, encrypting it with a given password.
+// Imported key is a plain key, with no sub-account derivation support.
+func (m *Manager) ImportPrivateKey(privateKeyHex, password string) (address, pubKey string, err error) {
+	keyBytes, err := hex.DecodeString(strings.TrimPrefix(privateKeyHex, "0x"))
+	if err != nil || len(keyBytes) != 32 {
+		return "", "", ErrInvalidPrivateKeyEncoding
+	}
+
+	privateKey, err := crypto.ToECDSA(keyBytes)
+	if err != nil {
+		return "", "", ErrInvalidPrivateKey
+	}
+
+	keyStore, err := m.accountKeyStore()
+	if err != nil {
+		return "", "", err
+	}
+
+	key := newKeyFromECDSA(privateKey)
+	if err := m.storeKey(keyStore, key, password); err != nil {
+		return "", "", err
+	}
+
+	address = key.Address.Hex()
+	pubKey = gethcommon.ToHex(crypto.FromECDSAPub(&privateKey.PublicKey))
+
+	return address, pubKey, nil
+}
+
+// ImportExtendedKey imports an account exported by another HD wallet as BIP32 extended private key (xprv).
+// Child key at a given index (hardened if index >= extkeys.HardenedKeyStart) is derived and imported
+// into keystore, it's used both as account key and sub-account root.
+func (m *Manager) ImportExtendedKey(xprv, password string, index uint32) (address, pubKey string, err error) {
+	defer func() { m.logResult(err, "import extended key", "address", address) }()
+
+	extKey, err := extkeys.NewKeyFromString(xprv)
+	if err != nil || !extKey.IsPrivate {
+		return "", "", ErrInvalidExtendedKey
+	}
+
+	childKey, err := extKey.Child(index)
+	if err != nil {
+		return "", "", err
+	}
+
+	return m.importExtendedKey(childKey, password)
+}
+
+// CreateAccountIn creates an account just like CreateAccount does, but stores its key file in
+// a given key store directory instead of the keystore of the running node, the same way
+// VerifyAccountPassword takes the directory to look for key file in. It allows transient
+// operations (e.g. restoring from backup) without reconfiguring the node.
+func (m *Manager) CreateAccountIn(keyStoreDir, password string) (address, pubKey, mnemonic string, err error) {
+	defer func() { m.logResult(err, "create account", "address", address, "dir", keyStoreDir) }()
+
+	mnemonic, extKey, err := m.newMasterKey(password)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	key, err := newKeyFromMasterKey(extKey)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	if err := m.storeKeyIn(keyStoreDir, key, password); err != nil {
+		return "", "", "", err
+	}
+
+	address = key.Address.Hex()
+	pubKey = gethcommon.ToHex(crypto.FromECDSAPub(&key.PrivateKey.PublicKey))
+
+	return address, pubKey, mnemonic, nil
+}
+
+// ImportAccountIn imports web3 secret storage (v3 keystore) JSON just like ImportAccount does,
+// but into a given key store directory instead of the keystore of the running node.
+func (m *Manager) ImportAccountIn(keyStoreDir string, keyJSON []byte, password string) (address string, err error) {
+	key, err := keystore.DecryptKey(keyJSON, password)
+	if err != nil {
+		return "", err
+	}
+	defer zeroKey(key)
+
+	if err := m.storeKeyIn(keyStoreDir, key, password); err != nil {
+		return "", err
+	}
+
+	return key.Address.Hex(), nil
+}
+
+// ValidateMnemonic makes sure that a given mnemonic phrase is a valid BIP39 phrase:
+// it consists of known words and encodes a correct checksum. It allows to detect typos
+// before recovery, which would otherwise silently produce a different account.
+func ValidateMnemonic(mnemonic string) error {
+	mn := extkeys.NewMnemonic(extkeys.Salt)
+	if !mn.ValidMnemonic(mnemonic, extkeys.EnglishLanguage) {
+		return ErrInvalidMnemonic
+	}
+
+	if !mn.ValidMnemonicChecksum(mnemonic, extkeys.EnglishLanguage) {
+		return ErrInvalidMnemonic
+	}
+
+	return nil
+}
+
+// DeriveAccountFromMnemonic returns address and public key of the account a given mnemonic maps to,
+// without touching the keystore. Since account password is used as BIP39 passphrase, passing
+// the same value as RecoverAccount's password yields the same account.
+func DeriveAccountFromMnemonic(mnemonic, passphrase string) (address, pubKey string, err error) {
+	extKey, err := masterKeyFromMnemonic(mnemonic, passphrase)
+	if err != nil {
+		return "", "", err
+	}
+
+	key, err := newKeyFromMasterKey(extKey)
+	if err != nil {
+		return "", "", err
+	}
+	defer zeroKey(key)
+
+	address = key.Address.Hex()
+	pubKey = gethcommon.ToHex(crypto.FromECDSAPub(&key.PrivateKey.PublicKey))
+
+	return address, pubKey, nil
+}
+
+// ReEncryptAccount changes password of an account identified by a given address.
+// Key is decrypted with the old password first, and then stored back encrypted with the new one.
+// Key file is left intact if old password is wrong.
+func (m *Manager) ReEncryptAccount(address, oldPassword, newPassword string) error {
+	keyStore, err := m.accountKeyStore()
+	if err != nil {
+		return err
+	}
+
+	account, err := common.ParseAccountString(address)
+	if err != nil {
+		return ErrAddressToAccountMappingFailure
+	}
+
+	m.keyStoreMu.Lock()
+	defer m.keyStoreMu.Unlock()
+
+	// key is left intact if old password can't decrypt key associated with a given address
+	err = keyStore.UpdateKey(account.Address, oldPassword, newPassword, 0, 0)
+	if err == keystore.ErrDecrypt || err == keystore.ErrNoMatch {
+		return fmt.Errorf("%s: %v", ErrAccountToKeyMappingFailure.Error(), err)
+	}
+
+	return err
+}
+
+// UpgradeKeystoreSecurity re-encrypts every key in the keystore which can be decrypted with
+// a given password, using provided scrypt parameters. Keys that can't be decrypted are skipped.
+// Number of migrated keys is returned.
+func (m *Manager) UpgradeKeystoreSecurity(password string, scryptN, scryptP int) (migrated int, err error) {
+	return m.UpgradeKeystoreSecurityWithProgress(password, scryptN, scryptP, nil)
+}
+
+// UpgradeKeystoreSecurityWithProgress re-encrypts keys just like UpgradeKeystoreSecurity does,
+// reporting progress to a given handler (if not nil) after every key is processed, skipped
+// ones included. The last call reports done == total, even if there are no keys at all.
+func (m *Manager) UpgradeKeystoreSecurityWithProgress(password string, scryptN, scryptP int,
+	progress MigrationProgressHandler) (migrated int, err error) {
+	keyStore, err := m.accountKeyStore()
+	if err != nil {
+		return 0, err
+	}
+
+	m.keyStoreMu.Lock()
+	defer m.keyStoreMu.Unlock()
+
+	addresses, err := keyStore.Accounts()
+	if err != nil {
+		return 0, err
+	}
+
+	for i, address := range addresses {
+		if err := keyStore.UpdateKey(address, password, password, scryptN, scryptP); err == nil {
+			migrated++
+		} else if err != keystore.ErrDecrypt {
+			return migrated, err
+		}
+
+		if progress != nil {
+			progress(i+1, len(addresses))
+		}
+	}
+
+	if len(addresses) == 0 && progress != nil {
+		progress(0, 0)
+	}
+
+	return migrated, nil
+}
+
+// VerifyAccountPassword tries to decrypt a given account key file, with a provided password.
+// If no error is returned, then account is considered verified.
+//
+// Unknown address is reported with keystore.ErrDecrypt (ErrWrongPassword), just like a wrong
+// password is, and a decoy key, as costly to decrypt as the key files, is decrypted instead.
+// Key files are looked up in the index the same way for known and unknown addresses (apart from
+// an unknown address re-indexing the directory once it's modified), so neither the error nor the
+// time it takes to verify the password reveals whether an account exists in the key store. Files
+// which aren't keys are skipped, they affect neither of them. Errors of reading the key store
+// directory match ErrKeyStoreUnavailable.
+func (m *Manager) VerifyAccountPassword(keyStoreDir, address, password string) (key *keystore.Key, err error) {
+	defer func() {
+		if err != nil {
+			m.logger().Warn("verify account password failed", "address", address, "err", err)
+			return
+		}
+		m.logger().Debug("verify account password", "address", address)
+	}()
+
+	addressObj := gethcommon.BytesToAddress(gethcommon.FromHex(address))
+
+	// locate key within key store directory (address should be within the file)
+	keyFilePath, err := m.keyFiles.lookup(keyStoreDir, addressObj)
+	if err != nil {
+		return nil, withKind(ErrKeyStoreUnavailable, fmt.Errorf("cannot traverse key store folder: %v", err))
+	}
+
+	if keyFilePath == "" {
+		m.decryptDecoyKeyFile(keyStoreDir, password)
+		return nil, keystore.ErrDecrypt
+	}
+
+	foundKeyFile, err := ioutil.ReadFile(keyFilePath)
+	if err != nil {
+		m.keyFiles.invalidate()
+		return nil, withKind(ErrKeyStoreUnavailable, fmt.Errorf("invalid account key file: %v", err))
+	}
+
+	return m.VerifyKeyJSON(foundKeyFile, address, password)
+}
+
+// decryptDecoyKeyFile decrypts a decoy key, encrypted with the highest scrypt params of key files
+// within key store directory, and discards the result. It makes verifying a password of unknown
+// account at least as slow as verifying a password of existing one.
+func (m *Manager) decryptDecoyKeyFile(keyStoreDir, password string) {
+	keyJSON := decoyKeyJSON(m.keyFiles.decoyParams(keyStoreDir))
+	if keyJSON == nil {
+		return
+	}
+
+	if key, err := decryptKey(keyJSON, password); err == nil {
+		zeroKey(key)
+	}
+}
+
+// VerifyKeystoreIntegrity walks a given key store directory, and reports key files which
+// can not be parsed, or whose name doesn't follow UTC--<created_at>--<address> convention
+// for the address stored within.
+func (m *Manager) VerifyKeystoreIntegrity(keyStoreDir string) ([]IntegrityIssue, error) {
+	var issues []IntegrityIssue
+	err := walkKeyFiles(keyStoreDir, func(path string, fileInfo os.FileInfo) error {
+		if issue := checkKeyFile(path); issue != nil {
+			issues = append(issues, *issue)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot traverse key store folder: %v", err)
+	}
+
+	return issues, nil
+}
+
+// VerifyKeyJSON tries to decrypt a given account key JSON (as stored in key file), with a provided password.
+// It allows to verify keys kept outside of keystore directory (e.g. in platform-specific secure storage).
+// If no error is returned, then account is considered verified.
+func (m *Manager) VerifyKeyJSON(keyJSON []byte, address, password string) (*keystore.Key, error) {
+	addressObj := gethcommon.BytesToAddress(gethcommon.FromHex(address))
+
+	key, err := decryptKey(keyJSON, password)
+	if err != nil {
+		return nil, err
+	}
+
+	// avoid swap attack
+	if key.Address != addressObj {
+		return nil, fmt.Errorf("account mismatch: have %s, want %s", key.Address.Hex(), addressObj.Hex())
+	}
+
+	return key, nil
+}
+
+// DeleteAccount removes key file of an account identified by a given address, along with its label.
+// Password is verified (see VerifyAccountPassword) before the key file is removed. Currently selected
+// account can not be deleted, selection must be cleared (see Logout) beforehand. Unknown account is
+// reported with an error matching ErrAccountNotFound, see IsKind.
+func (m *Manager) DeleteAccount(address, password string) error {
+	keyStore, err := m.accountKeyStore()
+	if err != nil {
+		return err
+	}
+
+	account, err := common.ParseAccountString(address)
+	if err != nil {
+		return ErrAddressToAccountMappingFailure
+	}
+
+	ok, err := hasKey(keyStore, account.Address)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return withKind(ErrAccountNotFound, fmt.Errorf("cannot locate account for address: %s", account.Address.Hex()))
+	}
+
+	labelsDir, err := m.labelsDir(keyStore, account.Address)
+	if err != nil {
+		return err
+	}
+
+	// key files are verified the same way everywhere
+	var key *keystore.Key
+	if _, ok := keyStore.(*FileKeyStore); ok {
+		key, err = m.VerifyAccountPassword(labelsDir, address, password)
+	} else {
+		key, err = keyStore.GetKey(account.Address, password)
+	}
+	if err != nil {
+		return err
+	}
+	zeroKey(key)
+
+	defer m.keyFiles.invalidate()
+
+	m.keyStoreMu.Lock()
+	defer m.keyStoreMu.Unlock()
+
+	// account can't get selected until it's deleted
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.selectedAccount != nil && m.selectedAccount.Address == account.Address {
+		return ErrSelectedAccountDeletion
+	}
+
+	if err := keyStore.DeleteKey(account.Address, password); err != nil {
+		return err
+	}
+
+	// account is gone already, a stale label is harmless
+	if err := m.labels.set(labelsDir, account.Address.Hex(), ""); err != nil {
+		m.logger().Warn("cannot clear label of deleted account", "address", address, "err", err)
+	}
+
+	return nil
+}
+
+// SelectAccount selects current account, by verifying that address has corresponding account which can be decrypted
+// using provided password. Once verification is done, decrypted key is injected into Whisper (as a single identity,
+// all previous identities are removed). If Whisper is not running, common.ErrWhisperServiceUnavailable is reported.
+// Errors match ErrKeyStoreUnavailable, ErrWhisperUnavailable, ErrAccountNotFound or ErrWrongPassword, see IsKind.
+func (m *Manager) SelectAccount(address, password string) error {
+	return m.SelectAccountContext(context.Background(), address, password)
+}
+
+// SelectAccountContext selects current account just like SelectAccount does, but gives up waiting for
+// the key to be injected into Whisper once ctx is done, returning ctx.Err(). Injection itself can't be
+// interrupted, so if ctx is cancelled while it's blocked (e.g. by a busy node), it completes in background,
+// but the account is not selected then: Whisper identities are rolled back to the ones set before.
+func (m *Manager) SelectAccountContext(ctx context.Context, address, password string) (err error) {
+	defer func() { m.logResult(err, "select account", "address", address) }()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	keyStore, err := m.accountKeyStore()
+	if err != nil {
+		return withKind(ErrKeyStoreUnavailable, err)
+	}
+
+	// whisper is checked before the key is decrypted, as decryption is slow
+	whisperService, err := m.nodeManager.WhisperService()
+	if err != nil {
+		return withKind(ErrWhisperUnavailable, err)
+	}
+
+	account, err := common.ParseAccountString(address)
+	if err != nil {
+		return ErrAddressToAccountMappingFailure
+	}
+
+	accountKey, err := keyStore.GetKey(account.Address, password)
+	if err != nil {
+		// matched both as the mapping failure and as the error of the keystore
+		keyErr := withKind(err, fmt.Errorf("%s: %v", ErrAccountToKeyMappingFailure, err))
+		return withKind(ErrAccountToKeyMappingFailure, keyErr)
+	}
+
+	if ctx.Done() == nil {
+		return m.selectKey(ctx, whisperService, account.Address, accountKey)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- m.selectKey(ctx, whisperService, account.Address, accountKey)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// selectKey injects decrypted key of an account into Whisper, and makes the account selected.
+// If ctx is done before the account is selected, identities injected before are restored.
+func (m *Manager) selectKey(ctx context.Context, whisperService *whisper.Whisper, address gethcommon.Address, accountKey *keystore.Key) error {
+	// identity injection and selected account update must not interleave with concurrent selects
+	m.mu.Lock()
+	if err := ctx.Err(); err != nil {
+		m.mu.Unlock()
+		return err
+	}
+
+	if err := m.injectIdentities(whisperService, accountKey.PrivateKey); err != nil {
+		m.mu.Unlock()
+		return err
+	}
+
+	// persist account key for easier recovery of currently selected key
+	subAccounts, err := m.findSubAccounts(accountKey.ExtendedKey, accountKey.SubAccountIndex)
+	if err == nil {
+		err = ctx.Err()
+	}
+	if err != nil {
+		if restoreErr := m.restoreIdentities(whisperService); restoreErr != nil {
+			m.logger().Warn("restore whisper identities failed", "err", restoreErr)
+		}
+		m.mu.Unlock()
+		return err
+	}
+	// key of previously selected account is no longer injected, so it's wiped
+	if m.selectedAccount != nil && m.selectedAccount.AccountKey != accountKey {
+		zeroKey(m.selectedAccount.AccountKey)
+	}
+	m.selectedAccount = &common.SelectedExtKey{
+		Address:     address,
+		AccountKey:  accountKey,
+		SubAccounts: subAccounts,
+	}
+	m.rotatedIdentity = nil
+	m.mu.Unlock()
+
+	m.notifySelectedAccountChanged(address.Hex())
+
+	return nil
+}
+
+// injectIdentities makes a given key pair the Whisper identity of the selected account, and
+// re-injects identities added with AddWhisperIdentity, as selecting key pair drops all other ones.
+// m.mu must be held.
+func (m *Manager) injectIdentities(whisperService *whisper.Whisper, identity *ecdsa.PrivateKey) error {
+	if err := whisperService.SelectKeyPair(identity); err != nil {
+		return ErrWhisperIdentityInjectionFailure
+	}
+
+	for _, key := range m.whisperIdentities {
+		if _, err := whisperService.AddKeyPair(key.PrivateKey); err != nil {
+			return ErrWhisperIdentityInjectionFailure
+		}
+	}
+
+	return nil
+}
+
+// restoreIdentities injects Whisper identities of the selected account (the rotated one, if it's been
+// rotated) and the ones added with AddWhisperIdentity, dropping any other ones. m.mu must be held.
+func (m *Manager) restoreIdentities(whisperService *whisper.Whisper) error {
+	if m.selectedAccount != nil {
+		identity := m.rotatedIdentity
+		if identity == nil {
+			identity = m.selectedAccount.AccountKey.PrivateKey
+		}
+		return m.injectIdentities(whisperService, identity)
+	}
+
+	if err := whisperService.DeleteKeyPairs(); err != nil {
+		return fmt.Errorf("%s: %v", ErrWhisperClearIdentitiesFailure, err)
+	}
+	for _, key := range m.whisperIdentities {
+		if _, err := whisperService.AddKeyPair(key.PrivateKey); err != nil {
+			return ErrWhisperIdentityInjectionFailure
+		}
+	}
+
+	return nil
+}
+
+// SelectedAccount returns currently selected account. Its key is a copy owned by the caller,
+// so that it's not wiped by Logout (or another select) while it's being used.
+func (m *Manager) SelectedAccount() (*common.SelectedExtKey, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.selectedAccount == nil {
+		return nil, ErrNoAccountSelected
+	}
+	return &common.SelectedExtKey{
+		Address:     m.selectedAccount.Address,
+		AccountKey:  copyKey(m.selectedAccount.AccountKey),
+		SubAccounts: m.selectedAccount.SubAccounts,
+	}, nil
+}
+
+// SelectedAccountInfo returns address and public key of currently selected account,
+// in the form CreateAccount returns them. ErrNoAccountSelected is returned if none is selected.
+func (m *Manager) SelectedAccountInfo() (address, pubKey string, err error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.selectedAccount == nil {
+		return "", "", ErrNoAccountSelected
+	}
+
+	address = m.selectedAccount.Address.Hex()
+	pubKey = gethcommon.ToHex(crypto.FromECDSAPub(&m.selectedAccount.AccountKey.PrivateKey.PublicKey))
+
+	return address, pubKey, nil
+}
+
+// ReSelectAccount selects previously selected account, often, after node restart.
+// Whisper identities are injected the same way SelectAccount does it: the rotated identity
+// (see RotateWhisperIdentity) replaces the one of the account key, and identities added
+// with AddWhisperIdentity are injected as well.
+func (m *Manager) ReSelectAccount() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.selectedAccount == nil {
+		return nil
+	}
+
+	whisperService, err := m.nodeManager.WhisperService()
+	if err != nil {
+		return err
+	}
+
+	return m.restoreIdentities(whisperService)
+}
+
+// Logout clears whisper identities and wipes decrypted key material of the selected account from memory
+func (m *Manager) Logout() error {
+	whisperService, err := m.nodeManager.WhisperService()
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	err = whisperService.DeleteKeyPairs()
+	if err != nil {
+		m.mu.Unlock()
+		return fmt.Errorf("%s: %v", ErrWhisperClearIdentitiesFailure, err)
+	}
+
+	if m.selectedAccount != nil {
+		zeroKey(m.selectedAccount.AccountKey)
+	}
+	m.selectedAccount = nil
+	m.rotatedIdentity = nil
+	for _, key := range m.whisperIdentities {
+		zeroKey(key)
+	}
+	m.whisperIdentities = nil
+	m.mu.Unlock()
+
+	m.notifySelectedAccountChanged("")
+
+	return nil
+}
+
+// AddWhisperIdentity injects key of a given account into Whisper, in addition to the selected account,
+// so that messages addressed to several accounts can be received at once.
+func (m *Manager) AddWhisperIdentity(address, password string) error {
+	_, accountKey, err := m.AddressToDecryptedAccount(address, password)
+	if err != nil {
+		return fmt.Errorf("%s: %v", ErrAccountToKeyMappingFailure.Error(), err)
+	}
+
+	whisperService, err := m.nodeManager.WhisperService()
+	if err != nil {
+		zeroKey(accountKey)
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, err := whisperService.AddKeyPair(accountKey.PrivateKey); err != nil {
+		zeroKey(accountKey)
+		return ErrWhisperIdentityInjectionFailure
+	}
+
+	if m.whisperIdentities == nil {
+		m.whisperIdentities = make(map[gethcommon.Address]*keystore.Key)
+	}
+	if key, ok := m.whisperIdentities[accountKey.Address]; ok {
+		zeroKey(key)
+	}
+	m.whisperIdentities[accountKey.Address] = accountKey
+
+	return nil
+}
+
+// RemoveWhisperIdentity removes identity previously added with AddWhisperIdentity from Whisper.
+// Identity of the selected account is kept intact.
+func (m *Manager) RemoveWhisperIdentity(address string) error {
+	account, err := common.ParseAccountString(address)
+	if err != nil {
+		return ErrAddressToAccountMappingFailure
+	}
+
+	whisperService, err := m.nodeManager.WhisperService()
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key, ok := m.whisperIdentities[account.Address]
+	if !ok {
+		return ErrWhisperIdentityNotFound
+	}
+	delete(m.whisperIdentities, account.Address)
+
+	// identity of the selected account is kept, unless it's been rotated
+	if m.selectedAccount == nil || m.selectedAccount.Address != account.Address || m.rotatedIdentity != nil {
+		whisperService.DeleteKeyPair(gethcommon.ToHex(crypto.FromECDSAPub(&key.PrivateKey.PublicKey)))
+	}
+	zeroKey(key)
+
+	return nil
+}
+
+// RotateWhisperIdentity replaces Whisper identity of the selected account with a freshly generated
+// key pair, for forward secrecy, and returns ID of the new key pair. Messages encrypted with the replaced
+// key can't be decrypted anymore, so subscriptions (filters) using it have to be re-created by their
+// owners: handler set with OnWhisperIdentityRotated is invoked with IDs of both key pairs for that.
+// Identity of the account key itself is restored once the account is selected again.
+func (m *Manager) RotateWhisperIdentity() (newKeyID string, err error) {
+	whisperService, err := m.nodeManager.WhisperService()
+	if err != nil {
+		return "", err
+	}
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		return "", err
+	}
+
+	m.mu.Lock()
+	if m.selectedAccount == nil {
+		m.mu.Unlock()
+		return "", ErrNoAccountSelected
+	}
+
+	oldKey := m.rotatedIdentity
+	if oldKey == nil {
+		oldKey = m.selectedAccount.AccountKey.PrivateKey
+	}
+
+	newKeyID, err = whisperService.AddKeyPair(key)
+	if err != nil {
+		m.mu.Unlock()
+		return "", ErrWhisperIdentityInjectionFailure
+	}
+
+	// old key pair is known already, so it's not re-injected, only its ID is returned
+	oldKeyID, err := whisperService.AddKeyPair(oldKey)
 	if err != nil {
-		return "", "", err
-	}
-	if err = keyStore.IncSubAccountIndex(account, password); err != nil {
-		return "", "", err
+		m.mu.Unlock()
+		return "", ErrWhisperIdentityInjectionFailure
 	}
-	accountKey.SubAccountIndex++
 
-	// import derived key into account keystore
-	address, pubKey, err = m.importExtendedKey(childKey, password)
-	if err != nil {
-		return
+	// account key is kept if it's also added as an additional identity
+	if _, ok := m.whisperIdentities[crypto.PubkeyToAddress(oldKey.PublicKey)]; !ok {
+		whisperService.DeleteKeyPair(oldKeyID)
 	}
+	m.rotatedIdentity = key
+	handler := m.whisperIdentityRotatedHandler
+	m.mu.Unlock()
 
-	// update in-memory selected account
-	if m.selectedAccount != nil {
-		m.selectedAccount.AccountKey = accountKey
+	if handler != nil {
+		handler(oldKeyID, newKeyID)
 	}
 
-	return address, pubKey, nil
+	return newKeyID, nil
 }
 
-// RecoverAccount re-creates master key using given details.
-// Once master key is re-generated, it is inserted into keystore (if not already there).
-func (m *Manager) RecoverAccount(password, mnemonic string) (address, pubKey string, err error) {
-	// re-create extended key (see BIP32)
-	mn := extkeys.NewMnemonic(extkeys.Salt)
-	extKey, err := extkeys.NewMaster(mn.MnemonicSeed(mnemonic, password), []byte(extkeys.Salt))
+// OnWhisperIdentityRotated sets handler to invoke whenever Whisper identity of the selected
+// account is rotated by RotateWhisperIdentity. Passing nil removes previously set handler.
+func (m *Manager) OnWhisperIdentityRotated(handler WhisperIdentityRotatedHandler) {
+	m.mu.Lock()
+	m.whisperIdentityRotatedHandler = handler
+	m.mu.Unlock()
+}
+
+// AddSymKeyFromPassword derives Whisper symmetric key from a given password, and registers it
+// with Whisper service. Id of registered key is returned. Everybody knowing the password
+// (e.g. name of a public chat) derives the same key.
+func (m *Manager) AddSymKeyFromPassword(password string) (keyID string, err error) {
+	whisperService, err := m.nodeManager.WhisperService()
 	if err != nil {
-		return "", "", ErrInvalidMasterKeyCreated
+		return "", err
 	}
 
-	// import re-created key into account keystore
-	address, pubKey, err = m.importExtendedKey(extKey, password)
+	return whisperService.AddSymKeyFromPassword(password)
+}
+
+// DeleteSymKey removes Whisper symmetric key with a given id.
+func (m *Manager) DeleteSymKey(keyID string) error {
+	whisperService, err := m.nodeManager.WhisperService()
 	if err != nil {
-		return
+		return err
 	}
 
-	return address, pubKey, nil
+	if !whisperService.DeleteSymKey(keyID) {
+		return ErrWhisperSymKeyNotFound
+	}
+
+	return nil
 }
 
-// VerifyAccountPassword tries to decrypt a given account key file, with a provided password.
-// If no error is returned, then account is considered verified.
-func (m *Manager) VerifyAccountPassword(keyStoreDir, address, password string) (*keystore.Key, error) {
-	var err error
-	var foundKeyFile []byte
+// WhisperMessageParams configures the cost and lifetime of sent Whisper messages, see SendWhisperMessageWithParams.
+type WhisperMessageParams struct {
+	TTL      uint32  // time to live of the message in seconds, whisper.DefaultTTL if zero
+	PoW      float64 // proof of work target, proof of work is done for the whole WorkTime if zero
+	WorkTime uint32  // maximum time (in seconds) spent on proof of work, 5 seconds if zero
+}
 
-	addressObj := gethcommon.BytesToAddress(gethcommon.FromHex(address))
-	checkAccountKey := func(path string, fileInfo os.FileInfo) error {
-		if len(foundKeyFile) > 0 || fileInfo.IsDir() {
-			return nil
-		}
+// SendWhisperMessage sends Whisper message with a given topic and payload, signed with the key
+// of the selected account, and encrypted with a given symmetric key. Hash of the sent envelope is returned.
+// Message is sent with the default TTL, and proof of work matching the minimum accepted by the node.
+func (m *Manager) SendWhisperMessage(topic whisper.TopicType, payload, symKey []byte) (gethcommon.Hash, error) {
+	return m.sendWhisperMessage(topic, payload, symKey, nil)
+}
 
-		rawKeyFile, e := ioutil.ReadFile(path)
-		if e != nil {
-			return fmt.Errorf("invalid account key file: %v", e)
-		}
+// SendWhisperMessageWithParams sends Whisper message just like SendWhisperMessage does, but with given
+// TTL and proof of work, so that the cost of the message can be tuned: lower for trusted networks, higher
+// for public ones. Note that the node drops messages with proof of work below its minimum, see whisper.MinPow.
+func (m *Manager) SendWhisperMessageWithParams(topic whisper.TopicType, payload, symKey []byte,
+	messageParams WhisperMessageParams) (gethcommon.Hash, error) {
+	return m.sendWhisperMessage(topic, payload, symKey, &messageParams)
+}
 
-		var accountKey struct {
-			Address string `json:"address"`
-		}
-		if e := json.Unmarshal(rawKeyFile, &accountKey); e != nil {
-			return fmt.Errorf("failed to read key file: %s", e)
-		}
+// sendWhisperMessage sends Whisper message with given params,
+// or with the defaults of SendWhisperMessage if params are nil.
+func (m *Manager) sendWhisperMessage(topic whisper.TopicType, payload, symKey []byte,
+	messageParams *WhisperMessageParams) (gethcommon.Hash, error) {
+	m.mu.RLock()
+	var selectedKey *keystore.Key
+	if m.selectedAccount != nil {
+		selectedKey = copyKey(m.selectedAccount.AccountKey)
+	}
+	m.mu.RUnlock()
+	if selectedKey == nil {
+		return gethcommon.Hash{}, ErrNoAccountSelected
+	}
+	defer zeroKey(selectedKey)
 
-		if gethcommon.HexToAddress("0x"+accountKey.Address).Hex() == addressObj.Hex() {
-			foundKeyFile = rawKeyFile
-		}
+	whisperService, err := m.nodeManager.WhisperService()
+	if err != nil {
+		return gethcommon.Hash{}, err
+	}
 
-		return nil
+	params := &whisper.MessageParams{
+		Src:      selectedKey.PrivateKey,
+		KeySym:   symKey,
+		Topic:    topic,
+		Payload:  payload,
+		TTL:      whisper.DefaultTTL,
+		PoW:      whisperService.MinPow(),
+		WorkTime: whisperWorkTime,
 	}
-	// locate key within key store directory (address should be within the file)
-	err = filepath.Walk(keyStoreDir, func(path string, fileInfo os.FileInfo, err error) error {
-		if err != nil {
-			return err
+	if messageParams != nil {
+		params.PoW = messageParams.PoW
+		if messageParams.TTL != 0 {
+			params.TTL = messageParams.TTL
+		}
+		if messageParams.WorkTime != 0 {
+			params.WorkTime = messageParams.WorkTime
 		}
-		return checkAccountKey(path, fileInfo)
-	})
-	if err != nil {
-		return nil, fmt.Errorf("cannot traverse key store folder: %v", err)
 	}
 
-	if len(foundKeyFile) == 0 {
-		return nil, fmt.Errorf("cannot locate account for address: %s", addressObj.Hex())
+	message, err := whisper.NewSentMessage(params)
+	if err != nil {
+		return gethcommon.Hash{}, err
 	}
 
-	key, err := keystore.DecryptKey(foundKeyFile, password)
+	envelope, err := message.Wrap(params)
 	if err != nil {
-		return nil, err
+		return gethcommon.Hash{}, err
 	}
 
-	// avoid swap attack
-	if key.Address != addressObj {
-		return nil, fmt.Errorf("account mismatch: have %s, want %s", key.Address.Hex(), addressObj.Hex())
+	if err := whisperService.Send(envelope); err != nil {
+		return gethcommon.Hash{}, err
 	}
 
-	return key, nil
+	return envelope.Hash(), nil
 }
 
-// SelectAccount selects current account, by verifying that address has corresponding account which can be decrypted
-// using provided password. Once verification is done, decrypted key is injected into Whisper (as a single identity,
-// all previous identities are removed).
-func (m *Manager) SelectAccount(address, password string) error {
-	keyStore, err := m.nodeManager.AccountKeyStore()
-	if err != nil {
-		return err
-	}
+// OnSelectedAccountChanged sets handler to invoke whenever selected account changes,
+// either on SelectAccount or Logout. Passing nil removes previously set handler.
+func (m *Manager) OnSelectedAccountChanged(handler SelectedAccountChangedHandler) {
+	m.mu.Lock()
+	m.selectedAccountChangedHandler = handler
+	m.mu.Unlock()
+}
 
-	account, err := common.ParseAccountString(address)
-	if err != nil {
-		return ErrAddressToAccountMappingFailure
+// notifySelectedAccountChanged invokes selected account change handler (if any).
+// Handler is invoked without holding the lock, so it is safe to call Manager's methods from it.
+func (m *Manager) notifySelectedAccountChanged(address string) {
+	m.mu.RLock()
+	handler := m.selectedAccountChangedHandler
+	m.mu.RUnlock()
+
+	if handler != nil {
+		handler(address)
 	}
+}
+
+// importExtendedKey processes incoming extended key, extracts required info and creates corresponding account key.
+// Once account key is formed, that key is put (if not already) into keystore i.e. key is *encoded* into key file.
+func (m *Manager) importExtendedKey(extKey *extkeys.ExtendedKey, password string) (address, pubKey string, err error) {
+	return m.importExtendedKeyContext(context.Background(), extKey, password)
+}
 
-	account, accountKey, err := keyStore.AccountDecryptedKey(account, password)
+// importExtendedKeyContext is importExtendedKey, which doesn't store the key once ctx is done, see storeKeyContext.
+func (m *Manager) importExtendedKeyContext(ctx context.Context, extKey *extkeys.ExtendedKey, password string) (address, pubKey string, err error) {
+	key, err := newKeyFromExtendedKey(extKey)
 	if err != nil {
-		return fmt.Errorf("%s: %v", ErrAccountToKeyMappingFailure.Error(), err)
+		return "", "", err
 	}
 
-	whisperService, err := m.nodeManager.WhisperService()
+	return m.importKey(ctx, key, password)
+}
+
+// importExtendedKeyAt derives child of a given master key at a given path, and imports it into keystore.
+// The child is used as account key, while sub-account root is CKD#2 of the master key, just like
+// for keys imported by importExtendedKey, so sub-accounts don't depend on the path.
+func (m *Manager) importExtendedKeyAt(extKey *extkeys.ExtendedKey, path []uint32, password string) (address, pubKey string, err error) {
+	childKey, err := extKey.Derive(path)
 	if err != nil {
-		return err
+		return "", "", err
 	}
 
-	err = whisperService.SelectKeyPair(accountKey.PrivateKey)
+	// CKD#2 - sub-accounts root
+	subAccountsRoot, err := extKey.BIP44Child(extkeys.CoinTypeETH, 1)
 	if err != nil {
-		return ErrWhisperIdentityInjectionFailure
+		return "", "", err
 	}
 
-	// persist account key for easier recovery of currently selected key
-	subAccounts, err := m.findSubAccounts(accountKey.ExtendedKey, accountKey.SubAccountIndex)
+	return m.importKey(context.Background(), newKeyFromChildKeys(childKey, subAccountsRoot), password)
+}
+
+// importKey puts (if not already) account key into keystore, and returns its address and public key.
+// Key is not stored once ctx is done, see storeKeyContext. Key is wiped once it's imported.
+func (m *Manager) importKey(ctx context.Context, key *keystore.Key, password string) (address, pubKey string, err error) {
+	defer zeroKey(key)
+
+	keyStore, err := m.accountKeyStore()
 	if err != nil {
-		return err
+		return "", "", err
 	}
-	m.selectedAccount = &common.SelectedExtKey{
-		Address:     account.Address,
-		AccountKey:  accountKey,
-		SubAccounts: subAccounts,
+
+	// store the key (if not already)
+	err = m.storeKeyContext(ctx, keyStore, key, password)
+	address = key.Address.Hex()
+	if err == ErrAccountExists {
+		// key is stored already, it's decrypted as the password must match it
+		storedKey, err := keyStore.GetKey(key.Address, password)
+		if err != nil {
+			return address, "", err
+		}
+		zeroKey(storedKey)
+	} else if err != nil {
+		return "", "", err
 	}
+	pubKey = gethcommon.ToHex(crypto.FromECDSAPub(&key.PrivateKey.PublicKey))
 
-	return nil
+	return address, pubKey, nil
 }
 
-// SelectedAccount returns currently selected account
-func (m *Manager) SelectedAccount() (*common.SelectedExtKey, error) {
-	if m.selectedAccount == nil {
-		return nil, ErrNoAccountSelected
-	}
-	return m.selectedAccount, nil
+// storeKey stores a key, serializing it with other keystore mutations, so that
+// e.g. the same account recovered concurrently is never stored twice.
+func (m *Manager) storeKey(keyStore KeyStore, key *keystore.Key, password string) error {
+	return m.storeKeyContext(context.Background(), keyStore, key, password)
 }
 
-// ReSelectAccount selects previously selected account, often, after node restart.
-func (m *Manager) ReSelectAccount() error {
-	selectedAccount := m.selectedAccount
-	if selectedAccount == nil {
-		return nil
+// storeKeyContext stores a key just like storeKey does, unless ctx is done before it's stored.
+// If ctx is done while the key is being stored, the key is deleted again and ctx.Err() is returned,
+// so that no account is left behind which the caller gave up on.
+func (m *Manager) storeKeyContext(ctx context.Context, keyStore KeyStore, key *keystore.Key, password string) error {
+	m.keyStoreMu.Lock()
+	defer m.keyStoreMu.Unlock()
+	defer m.keyFiles.invalidate()
+
+	if err := ctx.Err(); err != nil {
+		return err
 	}
 
-	whisperService, err := m.nodeManager.WhisperService()
-	if err != nil {
+	if err := keyStore.StoreKey(key, password); err != nil {
 		return err
 	}
 
-	if err := whisperService.SelectKeyPair(selectedAccount.AccountKey.PrivateKey); err != nil {
-		return ErrWhisperIdentityInjectionFailure
+	if err := ctx.Err(); err != nil {
+		if deleteErr := keyStore.DeleteKey(key.Address, password); deleteErr != nil {
+			m.logger().Warn("delete key of cancelled account failed", "address", key.Address.Hex(), "err", deleteErr)
+		}
+		return err
 	}
 
 	return nil
 }
 
-// Logout clears whisper identities
-func (m *Manager) Logout() error {
-	whisperService, err := m.nodeManager.WhisperService()
+// storeKeyIn writes key file of a key into a given key store directory, encrypted with light
+// scrypt params just like the keystore of the node does. ErrAccountExists is returned if
+// there's a key file of the same account in the directory already.
+func (m *Manager) storeKeyIn(keyStoreDir string, key *keystore.Key, password string) error {
+	keyJSON, err := keystore.EncryptKey(key, password, keystore.LightScryptN, keystore.LightScryptP)
 	if err != nil {
 		return err
 	}
 
-	err = whisperService.DeleteKeyPairs()
-	if err != nil {
-		return fmt.Errorf("%s: %v", ErrWhisperClearIdentitiesFailure, err)
-	}
-
-	m.selectedAccount = nil
-
-	return nil
-}
+	m.keyStoreMu.Lock()
+	defer m.keyStoreMu.Unlock()
 
-// importExtendedKey processes incoming extended key, extracts required info and creates corresponding account key.
-// Once account key is formed, that key is put (if not already) into keystore i.e. key is *encoded* into key file.
-func (m *Manager) importExtendedKey(extKey *extkeys.ExtendedKey, password string) (address, pubKey string, err error) {
-	keyStore, err := m.nodeManager.AccountKeyStore()
-	if err != nil {
-		return "", "", err
+	if err := os.MkdirAll(keyStoreDir, 0700); err != nil {
+		return err
 	}
 
-	// imports extended key, create key file (if necessary)
-	account, err := keyStore.ImportExtendedKey(extKey, password)
+	keyFilePath, err := m.keyFiles.lookup(keyStoreDir, key.Address)
 	if err != nil {
-		return "", "", err
+		return fmt.Errorf("cannot traverse key store folder: %v", err)
 	}
-	address = account.Address.Hex()
-
-	// obtain public key to return
-	account, key, err := keyStore.AccountDecryptedKey(account, password)
-	if err != nil {
-		return address, "", err
+	if keyFilePath != "" {
+		return ErrAccountExists
 	}
-	pubKey = gethcommon.ToHex(crypto.FromECDSAPub(&key.PrivateKey.PublicKey))
 
-	return
+	defer m.keyFiles.invalidate()
+	return writeKeyFile(filepath.Join(keyStoreDir, keyFileName(key.Address)), keyJSON)
 }
 
 // Accounts returns list of addresses for selected account, including
@@ -329,6 +1567,9 @@ func (m *Manager) Accounts() ([]gethcommon.Address, error) {
 		}
 	}
 
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	if m.selectedAccount == nil {
 		return []gethcommon.Address{}, nil
 	}
@@ -353,6 +1594,59 @@ func (m *Manager) Accounts() ([]gethcommon.Address, error) {
 	return filtered, nil
 }
 
+// KeyStoreAccounts returns addresses of all accounts present in the keystore,
+// sorted by address. Unlike Accounts(), it is not limited to the selected account.
+func (m *Manager) KeyStoreAccounts() ([]gethcommon.Address, error) {
+	keyStore, err := m.accountKeyStore()
+	if err != nil {
+		return nil, err
+	}
+
+	addresses, err := keyStore.Accounts()
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(addresses, func(i, j int) bool {
+		return bytes.Compare(addresses[i][:], addresses[j][:]) < 0
+	})
+
+	return addresses, nil
+}
+
+// HasAccount checks whether an account with a given address is present in the keystore.
+// Nothing is decrypted, so it's cheap to call before prompting for a password.
+// Address is not required to be checksummed.
+func (m *Manager) HasAccount(address string) (bool, error) {
+	account, err := common.ParseAccountString(address)
+	if err != nil {
+		return false, ErrAddressToAccountMappingFailure
+	}
+
+	keyStore, err := m.accountKeyStore()
+	if err != nil {
+		return false, err
+	}
+
+	return hasKey(keyStore, account.Address)
+}
+
+// hasKey checks whether a given keystore has key of an account with a given address.
+func hasKey(keyStore KeyStore, address gethcommon.Address) (bool, error) {
+	addresses, err := keyStore.Accounts()
+	if err != nil {
+		return false, err
+	}
+
+	for _, stored := range addresses {
+		if stored == address {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
 // AccountsRPCHandler returns RPC Handler for the Accounts() method.
 func (m *Manager) AccountsRPCHandler() rpc.Handler {
 	return func(context.Context, ...interface{}) (interface{}, error) {
@@ -360,7 +1654,62 @@ func (m *Manager) AccountsRPCHandler() rpc.Handler {
 	}
 }
 
-// refreshSelectedAccount re-populates list of sub-accounts of the currently selected account (if any)
+// SignRPCHandler returns RPC Handler for the eth_sign method: eth_sign(address, data).
+// Data is signed with the key of the selected account, which must match the address.
+func (m *Manager) SignRPCHandler() rpc.Handler {
+	return func(ctx context.Context, args ...interface{}) (interface{}, error) {
+		if len(args) < 2 {
+			return nil, ErrInvalidSignParams
+		}
+
+		return m.signWithSelectedAccount(args[0], args[1])
+	}
+}
+
+// PersonalSignRPCHandler returns RPC Handler for the personal_sign method:
+// personal_sign(data, address). It differs from eth_sign only by the order of arguments.
+func (m *Manager) PersonalSignRPCHandler() rpc.Handler {
+	return func(ctx context.Context, args ...interface{}) (interface{}, error) {
+		if len(args) < 2 {
+			return nil, ErrInvalidSignParams
+		}
+
+		return m.signWithSelectedAccount(args[1], args[0])
+	}
+}
+
+// signWithSelectedAccount calculates personal_sign compatible signature of hex encoded data,
+// using the key of the selected account, which is already decrypted on login.
+func (m *Manager) signWithSelectedAccount(addressArg, dataArg interface{}) (hexutil.Bytes, error) {
+	address, ok := addressArg.(string)
+	if !ok || !gethcommon.IsHexAddress(address) {
+		return nil, ErrInvalidSignParams
+	}
+
+	dataHex, ok := dataArg.(string)
+	if !ok {
+		return nil, ErrInvalidSignParams
+	}
+	data, err := hexutil.Decode(dataHex)
+	if err != nil {
+		return nil, ErrInvalidSignParams
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.selectedAccount == nil || m.selectedAccount.AccountKey == nil {
+		return nil, ErrNoAccountSelected
+	}
+	if m.selectedAccount.Address != gethcommon.HexToAddress(address) {
+		return nil, ErrNotSelectedAccount
+	}
+
+	return signMessage(data, m.selectedAccount.AccountKey)
+}
+
+// refreshSelectedAccount re-populates list of sub-accounts of the currently selected account (if any).
+// Caller is expected to hold the lock.
 func (m *Manager) refreshSelectedAccount() {
 	if m.selectedAccount == nil {
 		return
@@ -385,15 +1734,16 @@ func (m *Manager) refreshSelectedAccount() {
 
 // findSubAccounts traverses cached accounts and adds as a sub-accounts any
 // that belong to the currently selected account.
-// The extKey is CKD#2 := root of sub-accounts of the main account
+// The extKey is CKD#2 := root of sub-accounts of the main account.
+// Caller is expected to hold the lock.
 func (m *Manager) findSubAccounts(extKey *extkeys.ExtendedKey, subAccountIndex uint32) ([]accounts.Account, error) {
-	keyStore, err := m.nodeManager.AccountKeyStore()
+	keyStore, err := m.accountKeyStoreLocked()
 	if err != nil {
 		return []accounts.Account{}, err
 	}
 
 	subAccounts := make([]accounts.Account, 0)
-	if extKey.Depth == 5 { // CKD#2 level
+	if extKey != nil && extKey.Depth == 5 { // CKD#2 level
 		// gather possible sub-account addresses
 		subAccountAddresses := make([]gethcommon.Address, 0)
 		for i := uint32(0); i < subAccountIndex; i++ {
@@ -404,11 +1754,15 @@ func (m *Manager) findSubAccounts(extKey *extkeys.ExtendedKey, subAccountIndex u
 			subAccountAddresses = append(subAccountAddresses, crypto.PubkeyToAddress(childKey.ToECDSA().PublicKey))
 		}
 
-		// see if any of the gathered addresses actually exist in cached accounts list
-		for _, cachedAccount := range keyStore.Accounts() {
+		// see if any of the gathered addresses actually exist in the keystore
+		storedAddresses, err := keyStore.Accounts()
+		if err != nil {
+			return []accounts.Account{}, err
+		}
+		for _, storedAddress := range storedAddresses {
 			for _, possibleAddress := range subAccountAddresses {
-				if possibleAddress.Hex() == cachedAccount.Address.Hex() {
-					subAccounts = append(subAccounts, cachedAccount)
+				if possibleAddress == storedAddress {
+					subAccounts = append(subAccounts, accounts.Account{Address: storedAddress})
 				}
 			}
 		}
@@ -421,7 +1775,7 @@ func (m *Manager) findSubAccounts(extKey *extkeys.ExtendedKey, subAccountIndex u
 // The running node, has a keystore directory which is loaded on start. Key file
 // for a given address is expected to be in that directory prior to node start.
 func (m *Manager) AddressToDecryptedAccount(address, password string) (accounts.Account, *keystore.Key, error) {
-	keyStore, err := m.nodeManager.AccountKeyStore()
+	keyStore, err := m.accountKeyStore()
 	if err != nil {
 		return accounts.Account{}, nil, err
 	}
@@ -431,5 +1785,136 @@ func (m *Manager) AddressToDecryptedAccount(address, password string) (accounts.
 		return accounts.Account{}, nil, ErrAddressToAccountMappingFailure
 	}
 
-	return keyStore.AccountDecryptedKey(account, password)
+	key, err := keyStore.GetKey(account.Address, password)
+	if err != nil {
+		return accounts.Account{}, nil, err
+	}
+
+	return accounts.Account{Address: key.Address}, key, nil
+}
+
+// SignTransaction signs transaction with the key of a given account. EIP-155 signer
+// bound to the chain ID of the running node is used.
+func (m *Manager) SignTransaction(tx *types.Transaction, address, password string) (*types.Transaction, error) {
+	_, key, err := m.AddressToDecryptedAccount(address, password)
+	if err != nil {
+		return nil, err
+	}
+	defer zeroKey(key)
+
+	signer, err := m.signer()
+	if err != nil {
+		return nil, err
+	}
+
+	return types.SignTx(tx, signer, key.PrivateKey)
+}
+
+// SignMessage calculates personal_sign compatible signature of given data with the key
+// of a given account. V value of the signature is 27 or 28, as expected by ecrecover.
+func (m *Manager) SignMessage(data []byte, address, password string) (signature []byte, err error) {
+	_, key, err := m.AddressToDecryptedAccount(address, password)
+	if err != nil {
+		return nil, err
+	}
+	defer zeroKey(key)
+
+	return signMessage(data, key)
+}
+
+// signMessage calculates personal_sign compatible signature of given data with a given key.
+func signMessage(data []byte, key *keystore.Key) ([]byte, error) {
+	signature, err := crypto.Sign(signHash(data), key.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	signature[64] += 27 // transform V from 0/1 to 27/28 according to the yellow paper
+
+	return signature, nil
+}
+
+// signHash calculates a hash of the data prefixed with Ethereum signed message header:
+// keccak256("\x19Ethereum Signed Message:\n"${message length}${message}).
+func signHash(data []byte) []byte {
+	msg := fmt.Sprintf("\x19Ethereum Signed Message:\n%d%s", len(data), data)
+	return crypto.Keccak256([]byte(msg))
+}
+
+// VerifySignature checks whether personal_sign compatible signature of given data, as
+// calculated by SignMessage, was made with the key of a given account. Address is compared
+// regardless of its EIP-55 checksum.
+func VerifySignature(data, signature []byte, address string) (bool, error) {
+	if !gethcommon.IsHexAddress(address) {
+		return false, ErrAddressToAccountMappingFailure
+	}
+	if len(signature) != 65 || (signature[64] != 27 && signature[64] != 28) {
+		return false, ErrInvalidSignature
+	}
+
+	sig := make([]byte, len(signature))
+	copy(sig, signature)
+	sig[64] -= 27 // transform V from 27/28 back to 0/1 expected by ecrecover
+
+	pubKey, err := crypto.SigToPub(signHash(data), sig)
+	if err != nil {
+		return false, err
+	}
+
+	return crypto.PubkeyToAddress(*pubKey) == gethcommon.HexToAddress(address), nil
+}
+
+// signer returns EIP-155 transaction signer bound to the chain ID of the running node,
+// so that signed transactions are replay protected.
+func (m *Manager) signer() (types.Signer, error) {
+	chainID, err := m.nodeManager.ChainID()
+	if err != nil {
+		return nil, err
+	}
+
+	return types.NewEIP155Signer(new(big.Int).SetUint64(chainID)), nil
+}
+
+// copyKey returns a deep copy of decrypted key, which can be wiped independently of the original one.
+func copyKey(key *keystore.Key) *keystore.Key {
+	if key == nil {
+		return nil
+	}
+
+	keyCopy := *key
+	if key.PrivateKey != nil {
+		privateKey := *key.PrivateKey
+		privateKey.D = new(big.Int).Set(key.PrivateKey.D)
+		keyCopy.PrivateKey = &privateKey
+	}
+	if key.ExtendedKey != nil {
+		extKey := *key.ExtendedKey
+		extKey.KeyData = append([]byte(nil), key.ExtendedKey.KeyData...)
+		extKey.ChainCode = append([]byte(nil), key.ExtendedKey.ChainCode...)
+		keyCopy.ExtendedKey = &extKey
+	}
+
+	return &keyCopy
+}
+
+// zeroKey wipes decrypted private key (and extended key, if any) from memory.
+func zeroKey(key *keystore.Key) {
+	if key == nil {
+		return
+	}
+
+	if key.PrivateKey != nil {
+		b := key.PrivateKey.D.Bits()
+		for i := range b {
+			b[i] = 0
+		}
+	}
+
+	if key.ExtendedKey != nil {
+		for i := range key.ExtendedKey.KeyData {
+			key.ExtendedKey.KeyData[i] = 0
+		}
+		for i := range key.ExtendedKey.ChainCode {
+			key.ExtendedKey.ChainCode[i] = 0
+		}
+	}
 }
@@ -1,20 +1,35 @@
 package account
 
 import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"math/big"
 	"os"
 	"path/filepath"
-	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/accounts/keystore"
 	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	whisper "github.com/ethereum/go-ethereum/whisper/whisperv6"
 	"github.com/golang/mock/gomock"
+	"github.com/status-im/status-go/extkeys"
 	"github.com/status-im/status-go/geth/common"
+	"github.com/status-im/status-go/geth/params"
+	"github.com/status-im/status-go/geth/rpc"
+	"github.com/status-im/status-go/static"
 	. "github.com/status-im/status-go/t/utils"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
@@ -34,14 +49,13 @@ func TestVerifyAccountPassword(t *testing.T) {
 	require.NoError(t, common.ImportTestAccount(keyStoreDir, GetAccount1PKFile()))
 	require.NoError(t, common.ImportTestAccount(keyStoreDir, GetAccount2PKFile()))
 
-	account1Address := gethcommon.BytesToAddress(gethcommon.FromHex(TestConfig.Account1.Address))
-
 	testCases := []struct {
 		name          string
 		keyPath       string
 		address       string
 		password      string
 		expectedError error
+		expectedKind  error
 	}{
 		{
 			"correct address, correct password (decrypt should succeed)",
@@ -49,6 +63,7 @@ func TestVerifyAccountPassword(t *testing.T) {
 			TestConfig.Account1.Address,
 			TestConfig.Account1.Password,
 			nil,
+			nil,
 		},
 		{
 			"correct address, correct password, non-existent key store",
@@ -56,20 +71,23 @@ func TestVerifyAccountPassword(t *testing.T) {
 			TestConfig.Account1.Address,
 			TestConfig.Account1.Password,
 			fmt.Errorf("cannot traverse key store folder: lstat %s/non-existent-folder: no such file or directory", keyStoreDir),
+			ErrKeyStoreUnavailable,
 		},
 		{
 			"correct address, correct password, empty key store (pk is not there)",
 			emptyKeyStoreDir,
 			TestConfig.Account1.Address,
 			TestConfig.Account1.Password,
-			fmt.Errorf("cannot locate account for address: %s", account1Address.Hex()),
+			keystore.ErrDecrypt,
+			ErrWrongPassword,
 		},
 		{
 			"wrong address, correct password",
 			keyStoreDir,
 			"0x79791d3e8f2daa1f7fec29649d152c0ada3cc535",
 			TestConfig.Account1.Password,
-			fmt.Errorf("cannot locate account for address: %s", "0x79791d3E8F2dAa1F7FeC29649d152c0aDA3cc535"),
+			keystore.ErrDecrypt,
+			ErrWrongPassword,
 		},
 		{
 			"correct address, wrong password",
@@ -77,13 +95,17 @@ func TestVerifyAccountPassword(t *testing.T) {
 			TestConfig.Account1.Address,
 			"wrong password", // wrong password
 			errors.New("could not decrypt key with given passphrase"),
+			ErrWrongPassword,
 		},
 	}
 	for _, testCase := range testCases {
 		accountKey, err := accManager.VerifyAccountPassword(testCase.keyPath, testCase.address, testCase.password)
-		if !reflect.DeepEqual(err, testCase.expectedError) {
+		if fmt.Sprint(err) != fmt.Sprint(testCase.expectedError) {
 			require.FailNow(t, fmt.Sprintf("unexpected error: expected \n'%v', got \n'%v'", testCase.expectedError, err))
 		}
+		if testCase.expectedKind != nil && !IsKind(err, testCase.expectedKind) {
+			require.FailNow(t, fmt.Sprintf("error '%v' is not '%v'", err, testCase.expectedKind))
+		}
 		if err == nil {
 			if accountKey == nil {
 				require.Fail(t, "no error reported, but account key is missing")
@@ -96,6 +118,233 @@ func TestVerifyAccountPassword(t *testing.T) {
 	}
 }
 
+func TestIsKind(t *testing.T) {
+	cause := errors.New("cause")
+	err := withKind(ErrAccountToKeyMappingFailure, withKind(ErrWrongPassword, cause))
+
+	require.True(t, IsKind(err, ErrAccountToKeyMappingFailure))
+	require.True(t, IsKind(err, ErrWrongPassword))
+	require.True(t, IsKind(err, cause))
+	require.False(t, IsKind(err, ErrAccountNotFound))
+	require.True(t, IsKind(ErrAccountNotFound, ErrAccountNotFound))
+	require.False(t, IsKind(cause, ErrAccountNotFound))
+	require.False(t, IsKind(nil, ErrAccountNotFound))
+	require.Equal(t, "cause", err.Error())
+}
+
+func TestVerifyAccountPasswordSymlinkedKeyStore(t *testing.T) {
+	accManager := NewManager(nil)
+	tmpDir, err := ioutil.TempDir(os.TempDir(), "accounts")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir) //nolint: errcheck
+
+	keyStoreDir := filepath.Join(tmpDir, "keystore")
+	require.NoError(t, common.ImportTestAccount(keyStoreDir, GetAccount1PKFile()))
+
+	// symlinked sub-directory pointing back to key store must not cause a loop
+	require.NoError(t, os.Symlink(keyStoreDir, filepath.Join(keyStoreDir, "loop")))
+
+	symlinkedDir := filepath.Join(tmpDir, "symlinked")
+	require.NoError(t, os.Symlink(keyStoreDir, symlinkedDir))
+
+	accountKey, err := accManager.VerifyAccountPassword(symlinkedDir, TestConfig.Account1.Address, TestConfig.Account1.Password)
+	require.NoError(t, err)
+	require.Equal(t, gethcommon.HexToAddress(TestConfig.Account1.Address), accountKey.Address)
+}
+
+func TestVerifyAccountPasswordKeyFileIndex(t *testing.T) {
+	accManager := NewManager(nil)
+	keyStoreDir, err := ioutil.TempDir(os.TempDir(), "accounts")
+	require.NoError(t, err)
+	defer os.RemoveAll(keyStoreDir) //nolint: errcheck
+
+	require.NoError(t, common.ImportTestAccount(keyStoreDir, GetAccount1PKFile()))
+	_, err = accManager.VerifyAccountPassword(keyStoreDir, TestConfig.Account1.Address, TestConfig.Account1.Password)
+	require.NoError(t, err)
+
+	// unknown address doesn't re-index the directory, unless it's been modified
+	dirIndex := accManager.keyFiles.dirs[keyStoreDir]
+	_, err = accManager.VerifyAccountPassword(keyStoreDir, TestConfig.Account2.Address, TestConfig.Account2.Password)
+	require.Equal(t, keystore.ErrDecrypt, err)
+	require.True(t, dirIndex == accManager.keyFiles.dirs[keyStoreDir])
+
+	// key file added behind manager's back is found, as the directory is modified
+	require.NoError(t, common.ImportTestAccount(keyStoreDir, GetAccount2PKFile()))
+	_, err = accManager.VerifyAccountPassword(keyStoreDir, TestConfig.Account2.Address, TestConfig.Account2.Password)
+	require.NoError(t, err)
+
+	// files which aren't keys don't fail lookups: hidden and temporary ones are ignored,
+	// while the ones which can't be parsed are skipped
+	for name, content := range map[string]string{
+		labelsFileName:                      `{"0x79791d3E8F2dAa1F7FeC29649d152c0aDA3cc535":"label"}`,
+		"." + GetAccount1PKFile() + ".tmp1": "partially written",
+		"README":                            "key files",
+		"notes.txt":                         "not a key",
+		"other.json":                        `{"address":"not an address"}`,
+	} {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(keyStoreDir, name), []byte(content), 0600))
+	}
+	accManager.keyFiles.invalidate()
+	_, err = accManager.VerifyAccountPassword(keyStoreDir, TestConfig.Account1.Address, TestConfig.Account1.Password)
+	require.NoError(t, err)
+	require.Len(t, accManager.keyFiles.dirs[keyStoreDir].files, 2)
+
+	// key file written by manager invalidates the index
+	address, _, _, err := accManager.CreateAccountIn(keyStoreDir, "password")
+	require.NoError(t, err)
+	_, err = accManager.VerifyAccountPassword(keyStoreDir, address, "password")
+	require.NoError(t, err)
+
+	// concurrent lookups
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := accManager.VerifyAccountPassword(keyStoreDir, TestConfig.Account1.Address, TestConfig.Account1.Password)
+			require.NoError(t, err)
+		}()
+		accManager.keyFiles.invalidate()
+	}
+	wg.Wait()
+
+	// removed key file
+	require.NoError(t, os.Remove(filepath.Join(keyStoreDir, GetAccount1PKFile())))
+	_, err = accManager.VerifyAccountPassword(keyStoreDir, TestConfig.Account1.Address, TestConfig.Account1.Password)
+	require.Error(t, err)
+	_, err = accManager.VerifyAccountPassword(keyStoreDir, TestConfig.Account1.Address, TestConfig.Account1.Password)
+	require.Equal(t, keystore.ErrDecrypt, err)
+}
+
+// countDecryptions replaces decryptKey with a function counting decryptions, until restore is called.
+func countDecryptions() (count *int32, restore func()) {
+	count = new(int32)
+	decrypt := decryptKey
+	decryptKey = func(keyJSON []byte, password string) (*keystore.Key, error) {
+		atomic.AddInt32(count, 1)
+		return decrypt(keyJSON, password)
+	}
+
+	return count, func() { decryptKey = decrypt }
+}
+
+// TestVerifyAccountPasswordUnknownAddress verifies that unknown address can't be told apart
+// from a wrong password, neither by the error nor by the work done to verify the password.
+func TestVerifyAccountPasswordUnknownAddress(t *testing.T) {
+	accManager := NewManager(nil)
+	keyStoreDir, err := ioutil.TempDir(os.TempDir(), "accounts")
+	require.NoError(t, err)
+	defer os.RemoveAll(keyStoreDir) //nolint: errcheck
+
+	require.NoError(t, common.ImportTestAccount(keyStoreDir, GetAccount1PKFile()))
+
+	decryptions, restore := countDecryptions()
+	defer restore()
+
+	_, wrongPasswordErr := accManager.VerifyAccountPassword(keyStoreDir, TestConfig.Account1.Address, "wrong password")
+	require.Equal(t, keystore.ErrDecrypt, wrongPasswordErr)
+	require.EqualValues(t, 1, atomic.LoadInt32(decryptions))
+
+	_, unknownAddressErr := accManager.VerifyAccountPassword(keyStoreDir, TestConfig.Account2.Address, "wrong password")
+	require.Equal(t, wrongPasswordErr, unknownAddressErr)
+	require.EqualValues(t, 2, atomic.LoadInt32(decryptions))
+}
+
+// TestVerifyAccountPasswordEmptyKeyStore verifies that verifying a password in an empty
+// key store decrypts a key just like in a non-empty one, so that emptiness isn't revealed either.
+func TestVerifyAccountPasswordEmptyKeyStore(t *testing.T) {
+	accManager := NewManager(nil)
+	emptyKeyStoreDir, err := ioutil.TempDir(os.TempDir(), "accounts")
+	require.NoError(t, err)
+	defer os.RemoveAll(emptyKeyStoreDir) //nolint: errcheck
+
+	// decoy key is decrypted instead of a key file, a password never matches it
+	_, err = keystore.DecryptKey(decoyKeyJSON(keystore.LightScryptN, keystore.LightScryptP), "")
+	require.Equal(t, keystore.ErrDecrypt, err)
+
+	decryptions, restore := countDecryptions()
+	defer restore()
+
+	_, err = accManager.VerifyAccountPassword(emptyKeyStoreDir, TestConfig.Account1.Address, "wrong password")
+	require.Equal(t, keystore.ErrDecrypt, err)
+	require.EqualValues(t, 1, atomic.LoadInt32(decryptions))
+}
+
+// TestVerifyAccountPasswordDecoyParams verifies that decoy key is encrypted with the highest
+// scrypt params of key files, so that it's never faster to decrypt than a key file.
+func TestVerifyAccountPasswordDecoyParams(t *testing.T) {
+	accManager := NewManager(nil)
+	keyStoreDir, err := ioutil.TempDir(os.TempDir(), "accounts")
+	require.NoError(t, err)
+	defer os.RemoveAll(keyStoreDir) //nolint: errcheck
+
+	// empty key store uses light params of the keystore
+	_, err = accManager.VerifyAccountPassword(keyStoreDir, TestConfig.Account1.Address, "wrong password")
+	require.Equal(t, keystore.ErrDecrypt, err)
+	scryptN, scryptP := accManager.keyFiles.decoyParams(keyStoreDir)
+	require.Equal(t, keystore.LightScryptN, scryptN)
+	require.Equal(t, keystore.LightScryptP, scryptP)
+
+	// key file of stronger params, than the ones of the test account
+	require.NoError(t, common.ImportTestAccount(keyStoreDir, GetAccount1PKFile()))
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	keyJSON, err := keystore.EncryptKey(newKeyFromECDSA(privateKey), "password", 2*keystore.LightScryptN, keystore.LightScryptP)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(keyStoreDir, keyFileName(crypto.PubkeyToAddress(privateKey.PublicKey))), keyJSON, 0600))
+
+	_, err = accManager.VerifyAccountPassword(keyStoreDir, TestConfig.Account2.Address, "wrong password")
+	require.Equal(t, keystore.ErrDecrypt, err)
+	scryptN, scryptP = accManager.keyFiles.decoyParams(keyStoreDir)
+	require.Equal(t, 2*keystore.LightScryptN, scryptN)
+	require.Equal(t, keystore.LightScryptP, scryptP)
+
+	// stray files neither fail verification of unknown address, nor affect params
+	strayFiles := map[string]string{
+		"stray.json": `{"crypto":{"kdfparams":{"n":1073741824,"p":1}}}`,
+		"notes.txt":  "not a key",
+	}
+	for name, content := range strayFiles {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(keyStoreDir, name), []byte(content), 0600))
+	}
+	accManager.keyFiles.invalidate()
+	_, err = accManager.VerifyAccountPassword(keyStoreDir, TestConfig.Account2.Address, "wrong password")
+	require.Equal(t, keystore.ErrDecrypt, err)
+	scryptN, scryptP = accManager.keyFiles.decoyParams(keyStoreDir)
+	require.Equal(t, 2*keystore.LightScryptN, scryptN)
+	require.Equal(t, keystore.LightScryptP, scryptP)
+}
+
+func BenchmarkVerifyAccountPassword(b *testing.B) {
+	keyStoreDir, err := ioutil.TempDir(os.TempDir(), "accounts")
+	require.NoError(b, err)
+	defer os.RemoveAll(keyStoreDir) //nolint: errcheck
+
+	require.NoError(b, common.ImportTestAccount(keyStoreDir, GetAccount1PKFile()))
+	for i := 0; i < 1000; i++ {
+		address := gethcommon.BigToAddress(big.NewInt(int64(i + 1)))
+		keyJSON := fmt.Sprintf(`{"address":"%x"}`, address)
+		require.NoError(b, ioutil.WriteFile(filepath.Join(keyStoreDir, keyFileName(address)), []byte(keyJSON), 0600))
+	}
+
+	b.Run("Scan", func(b *testing.B) {
+		accManager := NewManager(nil)
+		for i := 0; i < b.N; i++ {
+			accManager.keyFiles.invalidate()
+			_, err := accManager.VerifyAccountPassword(keyStoreDir, TestConfig.Account1.Address, TestConfig.Account1.Password)
+			require.NoError(b, err)
+		}
+	})
+
+	b.Run("Indexed", func(b *testing.B) {
+		accManager := NewManager(nil)
+		for i := 0; i < b.N; i++ {
+			_, err := accManager.VerifyAccountPassword(keyStoreDir, TestConfig.Account1.Address, TestConfig.Account1.Password)
+			require.NoError(b, err)
+		}
+	})
+}
+
 // TestVerifyAccountPasswordWithAccountBeforeEIP55 verifies if VerifyAccountPassword
 // can handle accounts before introduction of EIP55.
 func TestVerifyAccountPasswordWithAccountBeforeEIP55(t *testing.T) {
@@ -114,10 +363,201 @@ func TestVerifyAccountPasswordWithAccountBeforeEIP55(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestVerifyKeystoreIntegrity(t *testing.T) {
+	accManager := NewManager(nil)
+	keyStoreDir, err := ioutil.TempDir(os.TempDir(), "accounts")
+	require.NoError(t, err)
+	defer os.RemoveAll(keyStoreDir) //nolint: errcheck
+
+	// properly named key file
+	account1Address := gethcommon.HexToAddress(TestConfig.Account1.Address)
+	account1KeyFile := static.MustAsset("keys/" + GetAccount1PKFile())
+	require.NoError(t, ioutil.WriteFile(filepath.Join(keyStoreDir, keyFileName(account1Address)), account1KeyFile, 0600))
+
+	issues, err := accManager.VerifyKeystoreIntegrity(keyStoreDir)
+	require.NoError(t, err)
+	require.Empty(t, issues)
+
+	// misnamed key file
+	require.NoError(t, common.ImportTestAccount(keyStoreDir, GetAccount2PKFile()))
+	// key file with address of another account
+	misnamedPath := filepath.Join(keyStoreDir, "UTC--2018-01-01T00-00-00.000000000Z--"+strings.Repeat("0", 40))
+	require.NoError(t, ioutil.WriteFile(misnamedPath, account1KeyFile, 0600))
+	// malformed key file
+	malformedPath := filepath.Join(keyStoreDir, keyFileName(gethcommon.Address{}))
+	require.NoError(t, ioutil.WriteFile(malformedPath, []byte("{not a key"), 0600))
+	// temporary files are ignored
+	require.NoError(t, ioutil.WriteFile(filepath.Join(keyStoreDir, ".key.tmp"), []byte("{not a key"), 0600))
+
+	issues, err = accManager.VerifyKeystoreIntegrity(keyStoreDir)
+	require.NoError(t, err)
+
+	expected := map[string]IntegrityIssue{
+		filepath.Join(keyStoreDir, GetAccount2PKFile()): {
+			Address: gethcommon.HexToAddress(TestConfig.Account2.Address),
+			Err:     ErrKeyFileNameMismatch,
+		},
+		misnamedPath: {
+			Address: account1Address,
+			Err:     ErrKeyFileNameMismatch,
+		},
+		malformedPath: {
+			Err: ErrKeyFileMalformed,
+		},
+	}
+	require.Len(t, issues, len(expected))
+	for _, issue := range issues {
+		expectedIssue, ok := expected[issue.Path]
+		require.True(t, ok, "unexpected issue: %+v", issue)
+		require.Equal(t, expectedIssue.Address, issue.Address)
+		require.Equal(t, expectedIssue.Err, issue.Err)
+	}
+
+	// non-existent key store
+	_, err = accManager.VerifyKeystoreIntegrity(filepath.Join(keyStoreDir, "missing"))
+	require.Error(t, err)
+}
+
+func TestValidateMnemonic(t *testing.T) {
+	testCases := []struct {
+		name          string
+		mnemonic      string
+		expectedError error
+	}{
+		{
+			"valid 12-word phrase",
+			"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+			nil,
+		},
+		{
+			"word is not in the word list",
+			"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abuot",
+			ErrInvalidMnemonic,
+		},
+		{
+			"bad checksum",
+			"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon",
+			ErrInvalidMnemonic,
+		},
+		{
+			"wrong number of words",
+			"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+			ErrInvalidMnemonic,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			require.Equal(t, testCase.expectedError, ValidateMnemonic(testCase.mnemonic))
+		})
+	}
+}
+
+func TestValidatePasswordStrength(t *testing.T) {
+	policy := PasswordPolicy{
+		MinLength:      10,
+		RequireUpper:   true,
+		RequireLower:   true,
+		RequireDigit:   true,
+		RequireSpecial: true,
+	}
+
+	testCases := []struct {
+		name          string
+		password      string
+		expectedError error
+	}{
+		{
+			"strong password",
+			"Correct-Horse-42",
+			nil,
+		},
+		{
+			"all requirements unmet",
+			"",
+			&PasswordPolicyError{Unmet: []string{"at least 10 characters", "an upper case letter", "a lower case letter", "a digit", "a special character"}},
+		},
+		{
+			"too short",
+			"Sh0rt!",
+			&PasswordPolicyError{Unmet: []string{"at least 10 characters"}},
+		},
+		{
+			"lower case letters and digits only",
+			"password1234",
+			&PasswordPolicyError{Unmet: []string{"an upper case letter", "a special character"}},
+		},
+		{
+			"non-ASCII characters are counted once",
+			"Пароль-123",
+			nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			require.Equal(t, testCase.expectedError, ValidatePasswordStrength(testCase.password, policy))
+		})
+	}
+
+	require.NoError(t, ValidatePasswordStrength("", PasswordPolicy{}))
+	require.EqualError(t, ValidatePasswordStrength("password", policy),
+		"password is too weak: at least 10 characters, an upper case letter, a digit, a special character")
+}
+
+func TestVerifyKeyJSON(t *testing.T) {
+	accManager := NewManager(nil)
+	keyJSON := static.MustAsset("keys/" + GetAccount1PKFile())
+
+	testCases := []struct {
+		name          string
+		address       string
+		password      string
+		expectedError error
+	}{
+		{
+			"correct address, correct password",
+			TestConfig.Account1.Address,
+			TestConfig.Account1.Password,
+			nil,
+		},
+		{
+			"correct lowercase address, correct password",
+			strings.ToLower(TestConfig.Account1.Address),
+			TestConfig.Account1.Password,
+			nil,
+		},
+		{
+			"correct address, wrong password",
+			TestConfig.Account1.Address,
+			"wrong password",
+			errors.New("could not decrypt key with given passphrase"),
+		},
+		{
+			"wrong address, correct password",
+			"0x79791d3e8f2daa1f7fec29649d152c0ada3cc535",
+			TestConfig.Account1.Password,
+			fmt.Errorf("account mismatch: have %s, want %s",
+				gethcommon.HexToAddress(TestConfig.Account1.Address).Hex(), "0x79791d3E8F2dAa1F7FeC29649d152c0aDA3cc535"),
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			accountKey, err := accManager.VerifyKeyJSON(keyJSON, testCase.address, testCase.password)
+			require.Equal(t, testCase.expectedError, err)
+			if err == nil {
+				require.Equal(t, gethcommon.HexToAddress(testCase.address), accountKey.Address)
+			}
+		})
+	}
+}
+
 var (
 	errWhisper    = errors.New("Can't return a whisper service")
 	errKeyStore   = errors.New("Can't return a key store")
 	errAccManager = errors.New("Can't return an account manager")
+	errNodeConfig = errors.New("Can't return a node config")
+	errChainID    = errors.New("Can't return a chain ID")
 )
 
 func TestManagerTestSuite(t *testing.T) {
@@ -203,65 +643,1201 @@ func (s *ManagerTestSuite) TestCreateAccount() {
 	s.Equal(errKeyStore, err)
 }
 
-func (s *ManagerTestSuite) TestRecoverAccount() {
+func (s *ManagerTestSuite) TestCreateAccountWithEntropySource() {
+	s.accManager.SetEntropySource(bytes.NewReader(make([]byte, 16)))
+	defer s.accManager.SetEntropySource(nil)
+
 	s.nodeManager.EXPECT().AccountKeyStore().Return(s.keyStore, nil)
-	addr, pubKey, err := s.accManager.RecoverAccount(s.password, s.mnemonic)
+	address, _, mnemonic, err := s.accManager.CreateAccount(s.password)
 	s.NoError(err)
-	s.Equal(s.address, addr)
-	s.Equal(s.pubKey, pubKey)
+	s.Equal("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about", mnemonic)
+	s.Equal("0x4f3E5D1Df8Ad36Dddadd453767540A6e4aD6C1b4", address)
+}
+
+func (s *ManagerTestSuite) TestReturnedAddressesAreChecksummed() {
+	checksummed := func(address string) string {
+		return gethcommon.HexToAddress(address).Hex()
+	}
+
+	s.nodeManager.EXPECT().AccountKeyStore().Return(s.keyStore, nil).AnyTimes()
+
+	address, _, mnemonic, err := s.accManager.CreateAccount(s.password)
+	s.NoError(err)
+	s.Equal(checksummed(address), address)
+
+	recovered, _, err := s.accManager.RecoverAccount(s.password, mnemonic)
+	s.NoError(err)
+	s.Equal(address, recovered)
+
+	child, _, err := s.accManager.CreateChildAccount(address, s.password)
+	s.NoError(err)
+	s.Equal(checksummed(child), child)
+
+	derived, _, err := DeriveAccountFromMnemonic(mnemonic, "")
+	s.NoError(err)
+	s.Equal(checksummed(derived), derived)
+}
+
+func (s *ManagerTestSuite) TestHasAccount() {
+	s.nodeManager.EXPECT().AccountKeyStore().Return(s.keyStore, nil).Times(3)
+
+	ok, err := s.accManager.HasAccount(s.address)
+	s.NoError(err)
+	s.True(ok)
+
+	ok, err = s.accManager.HasAccount(strings.ToLower(s.address))
+	s.NoError(err)
+	s.True(ok)
+
+	ok, err = s.accManager.HasAccount("0x79791d3E8F2dAa1F7FeC29649d152c0aDA3cc535")
+	s.NoError(err)
+	s.False(ok)
+
+	_, err = s.accManager.HasAccount("invalid")
+	s.Equal(ErrAddressToAccountMappingFailure, err)
 
 	s.nodeManager.EXPECT().AccountKeyStore().Return(nil, errKeyStore)
-	_, _, err = s.accManager.RecoverAccount(s.password, s.mnemonic)
+	_, err = s.accManager.HasAccount(s.address)
 	s.Equal(errKeyStore, err)
 }
 
-func (s *ManagerTestSuite) TestSelectAccount() {
+func (s *ManagerTestSuite) TestMemoryKeyStore() {
+	keyStoreDir, err := ioutil.TempDir(os.TempDir(), "accounts_memory")
+	s.Require().NoError(err)
+	defer os.RemoveAll(keyStoreDir) //nolint: errcheck
+
+	// the same scenario is run against both backends, to make sure they behave identically
 	testCases := []struct {
-		name                  string
-		accountKeyStoreReturn []interface{}
-		whisperServiceReturn  []interface{}
-		address               string
-		password              string
-		expectedError         error
+		name     string
+		keyStore KeyStore
 	}{
-		{
-			"success",
-			[]interface{}{s.keyStore, nil},
-			[]interface{}{s.shh, nil},
-			s.address,
-			s.password,
-			nil,
-		},
-		{
-			"fail_keyStore",
-			[]interface{}{nil, errKeyStore},
-			[]interface{}{s.shh, nil},
-			s.address,
-			s.password,
-			errKeyStore,
-		},
-		{
-			"fail_whisperService",
-			[]interface{}{s.keyStore, nil},
-			[]interface{}{nil, errWhisper},
-			s.address,
-			s.password,
-			errWhisper,
-		},
-		{
-			"fail_wrongAddress",
-			[]interface{}{s.keyStore, nil},
-			[]interface{}{s.shh, nil},
-			"wrong-address",
-			s.password,
+		{"file", nil},
+		{"memory", NewMemoryKeyStore()},
+	}
+
+	for _, testCase := range testCases {
+		s.T().Run(testCase.name, func(t *testing.T) {
+			s.reinitMock()
+			s.accManager.SetKeyStore(testCase.keyStore)
+			defer s.accManager.SetKeyStore(nil)
+
+			if testCase.keyStore != nil {
+				// keystore of the node is never used
+				s.nodeManager.EXPECT().AccountKeyStore().Times(0)
+			} else {
+				s.nodeManager.EXPECT().AccountKeyStore().Return(s.keyStore, nil).AnyTimes()
+			}
+			s.nodeManager.EXPECT().WhisperService().Return(s.shh, nil).AnyTimes()
+			s.nodeManager.EXPECT().NodeConfig().Return(&params.NodeConfig{KeyStoreDir: keyStoreDir}, nil).AnyTimes()
+
+			// create
+			address, pubKey, _, err := s.accManager.CreateAccount(s.password)
+			s.Require().NoError(err)
+
+			addresses, err := s.accManager.KeyStoreAccounts()
+			s.NoError(err)
+			s.Contains(addresses, gethcommon.HexToAddress(address))
+
+			ok, err := s.accManager.HasAccount(address)
+			s.NoError(err)
+			s.True(ok)
+
+			// verify
+			account, key, err := s.accManager.AddressToDecryptedAccount(address, s.password)
+			s.NoError(err)
+			s.Equal(address, account.Address.Hex())
+			s.Equal(pubKey, gethcommon.ToHex(crypto.FromECDSAPub(&key.PrivateKey.PublicKey)))
+
+			_, _, err = s.accManager.AddressToDecryptedAccount(address, "wrong-password")
+			s.Equal(keystore.ErrDecrypt, err)
+
+			s.NoError(s.accManager.SelectAccount(address, s.password))
+			selected, err := s.accManager.SelectedAccount()
+			s.NoError(err)
+			s.Equal(address, selected.Address.Hex())
+			s.NoError(s.accManager.Logout())
+
+			// derive sub-accounts, parent key is read from and updated in the same store
+			child1, _, err := s.accManager.CreateChildAccount(address, s.password)
+			s.Require().NoError(err)
+			child2, _, err := s.accManager.CreateChildAccount(address, s.password)
+			s.Require().NoError(err)
+			s.NotEqual(child1, child2)
+			_, key, err = s.accManager.AddressToDecryptedAccount(address, s.password)
+			s.NoError(err)
+			s.Equal(uint32(2), key.SubAccountIndex)
+			_, _, err = s.accManager.AddressToDecryptedAccount(child2, s.password)
+			s.NoError(err)
+
+			_, _, err = s.accManager.CreateChildAccount(address, "wrong-password")
+			s.EqualError(err, "cannot retrieve a valid key for a given account: could not decrypt key with given passphrase")
+
+			// import
+			privateKey, err := crypto.GenerateKey()
+			s.Require().NoError(err)
+			privateKeyHex := hex.EncodeToString(crypto.FromECDSA(privateKey))
+
+			importedAddress, _, err := s.accManager.ImportPrivateKey(privateKeyHex, s.password)
+			s.NoError(err)
+			s.Equal(crypto.PubkeyToAddress(privateKey.PublicKey).Hex(), importedAddress)
+
+			_, _, err = s.accManager.ImportPrivateKey(privateKeyHex, s.password)
+			s.EqualError(err, ErrAccountExists.Error())
+
+			// export
+			keyJSON, err := s.accManager.ExportAccount(address, s.password)
+			s.NoError(err)
+			exportedKey, err := keystore.DecryptKey(keyJSON, s.password)
+			s.Require().NoError(err)
+			s.Equal(address, exportedKey.Address.Hex())
+
+			_, err = s.accManager.ExportAccount(address, "wrong-password")
+			s.Equal(keystore.ErrDecrypt, err)
+
+			// re-encrypt, and back
+			err = s.accManager.ReEncryptAccount(address, "wrong-password", "new-password")
+			s.EqualError(err, "cannot retrieve a valid key for a given account: could not decrypt key with given passphrase")
+			s.NoError(s.accManager.ReEncryptAccount(address, s.password, "new-password"))
+			_, _, err = s.accManager.AddressToDecryptedAccount(address, s.password)
+			s.Equal(keystore.ErrDecrypt, err)
+			s.NoError(s.accManager.ReEncryptAccount(address, "new-password", s.password))
+
+			// keystore of the node may have keys of other tests
+			migrated, err := s.accManager.UpgradeKeystoreSecurity(s.password, keystore.LightScryptN, keystore.LightScryptP)
+			s.NoError(err)
+			s.True(migrated >= 2, "migrated %d keys", migrated)
+
+			// delete
+			s.Equal(keystore.ErrDecrypt, s.accManager.DeleteAccount(address, "wrong-password"))
+			s.NoError(s.accManager.DeleteAccount(address, s.password))
+			s.NoError(s.accManager.DeleteAccount(importedAddress, s.password))
+			err = s.accManager.DeleteAccount(address, s.password)
+			s.EqualError(err, "cannot locate account for address: "+address)
+			s.True(IsKind(err, ErrAccountNotFound))
+
+			ok, err = s.accManager.HasAccount(address)
+			s.NoError(err)
+			s.False(ok)
+
+			_, _, err = s.accManager.AddressToDecryptedAccount(address, s.password)
+			s.Equal(keystore.ErrNoMatch, err)
+		})
+	}
+}
+
+func (s *ManagerTestSuite) TestCreateAccountWithPasswordPolicy() {
+	s.accManager.SetPasswordPolicy(PasswordPolicy{MinLength: 8, RequireDigit: true})
+	defer s.accManager.SetPasswordPolicy(PasswordPolicy{})
+
+	// weak password is rejected before keystore is touched
+	_, _, _, err := s.accManager.CreateAccount("weak")
+	s.Equal(&PasswordPolicyError{Unmet: []string{"at least 8 characters", "a digit"}}, err)
+
+	_, _, _, err = s.accManager.CreateAccountAt("weak", DefaultDerivationPath)
+	s.IsType(&PasswordPolicyError{}, err)
+
+	s.nodeManager.EXPECT().AccountKeyStore().Return(s.keyStore, nil)
+	_, _, _, err = s.accManager.CreateAccount("strong-password-1")
+	s.NoError(err)
+}
+
+func (s *ManagerTestSuite) TestCreateRandomAccount() {
+	s.nodeManager.EXPECT().AccountKeyStore().Return(s.keyStore, nil).AnyTimes()
+	s.nodeManager.EXPECT().WhisperService().Return(s.shh, nil).AnyTimes()
+
+	address, pubKey, err := s.accManager.CreateRandomAccount(s.password)
+	s.Require().NoError(err)
+	s.True(s.keyStore.HasAddress(gethcommon.HexToAddress(address)))
+
+	pubKeyBytes, err := hexutil.Decode(pubKey)
+	s.NoError(err)
+	s.Equal(address, crypto.PubkeyToAddress(*crypto.ToECDSAPub(pubKeyBytes)).Hex())
+
+	// account has no sub-accounts, but can be selected
+	s.NoError(s.accManager.SelectAccount(address, s.password))
+	selected, err := s.accManager.SelectedAccount()
+	s.NoError(err)
+	s.Equal(address, selected.Address.Hex())
+	s.Empty(selected.SubAccounts)
+	s.NoError(s.accManager.Logout())
+
+	data := []byte("hello world")
+	signature, err := s.accManager.SignMessage(data, address, s.password)
+	s.NoError(err)
+	signature[64] -= 27
+	hash := crypto.Keccak256([]byte(fmt.Sprintf("\x19Ethereum Signed Message:\n%d%s", len(data), data)))
+	signer, err := crypto.SigToPub(hash, signature)
+	s.NoError(err)
+	s.Equal(address, crypto.PubkeyToAddress(*signer).Hex())
+
+	// every account is different
+	otherAddress, _, err := s.accManager.CreateRandomAccount(s.password)
+	s.NoError(err)
+	s.NotEqual(address, otherAddress)
+}
+
+// stallingKeyStore is a MemoryKeyStore listing accounts only once release is closed.
+type stallingKeyStore struct {
+	*MemoryKeyStore
+	release chan struct{}
+}
+
+func (s *stallingKeyStore) Accounts() ([]gethcommon.Address, error) {
+	<-s.release
+	return s.MemoryKeyStore.Accounts()
+}
+
+func (s *ManagerTestSuite) TestSelectAccountContext() {
+	s.nodeManager.EXPECT().WhisperService().Return(s.shh, nil).AnyTimes()
+	s.Require().NoError(s.accManager.Logout())
+
+	// sub-accounts are looked up while key is injected, so listing accounts stalls injection
+	keyStore := &stallingKeyStore{MemoryKeyStore: NewMemoryKeyStore(), release: make(chan struct{})}
+	s.accManager.SetKeyStore(keyStore)
+	defer s.accManager.SetKeyStore(nil)
+
+	address, pubKey, _, err := s.accManager.CreateAccount(s.password)
+	s.Require().NoError(err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	s.Equal(context.Canceled, s.accManager.SelectAccountContext(ctx, address, s.password))
+
+	ctx, cancel = context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err = s.accManager.SelectAccountContext(ctx, address, s.password)
+	s.Equal(context.DeadlineExceeded, err)
+	s.True(time.Since(start) < time.Second)
+
+	// stalled injection completes in background, but the account isn't selected, as ctx is done
+	// before selection completes, and identity injected by it is rolled back
+	close(keyStore.release)
+	s.accManager.mu.Lock() // acquired once background injection is over
+	s.accManager.mu.Unlock()
+	_, err = s.accManager.SelectedAccount()
+	s.Equal(ErrNoAccountSelected, err)
+	s.False(s.shh.HasKeyPair(pubKey))
+
+	s.NoError(s.accManager.SelectAccountContext(context.Background(), address, s.password))
+	s.True(s.shh.HasKeyPair(pubKey))
+	s.NoError(s.accManager.Logout())
+}
+
+func (s *ManagerTestSuite) TestSelectAccountContextRollback() {
+	s.nodeManager.EXPECT().AccountKeyStore().Return(s.keyStore, nil).AnyTimes()
+	s.nodeManager.EXPECT().WhisperService().Return(s.shh, nil).AnyTimes()
+
+	address1, pubKey1, _, err := s.accManager.CreateAccount(s.password)
+	s.Require().NoError(err)
+	s.Require().NoError(s.accManager.SelectAccount(address1, s.password))
+	defer s.accManager.Logout() //nolint: errcheck
+
+	// selection of another account is stalled, and cancelled before it completes
+	keyStore := &stallingKeyStore{MemoryKeyStore: NewMemoryKeyStore(), release: make(chan struct{})}
+	s.accManager.SetKeyStore(keyStore)
+	defer s.accManager.SetKeyStore(nil)
+	address2, pubKey2, _, err := s.accManager.CreateAccount(s.password)
+	s.Require().NoError(err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- s.accManager.SelectAccountContext(ctx, address2, s.password)
+	}()
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	s.Equal(context.Canceled, <-done)
+
+	close(keyStore.release)
+	s.accManager.mu.Lock() // acquired once background injection is over
+	s.accManager.mu.Unlock()
+
+	// previously selected account and its identity are kept
+	selectedAccount, err := s.accManager.SelectedAccount()
+	s.Require().NoError(err)
+	s.Equal(address1, selectedAccount.Address.Hex())
+	s.True(s.shh.HasKeyPair(pubKey1))
+	s.False(s.shh.HasKeyPair(pubKey2))
+}
+
+func (s *ManagerTestSuite) TestCreateAccountV2() {
+	entropy := bytes.Repeat([]byte{0x7f}, 16)
+	defer s.accManager.SetEntropySource(nil)
+
+	s.accManager.SetEntropySource(bytes.NewReader(entropy))
+	s.nodeManager.EXPECT().AccountKeyStore().Return(s.keyStore, nil)
+	address, pubKey, mnemonic, err := s.accManager.CreateAccount(s.password)
+	s.NoError(err)
+
+	s.accManager.SetEntropySource(bytes.NewReader(entropy))
+	s.nodeManager.EXPECT().AccountKeyStore().Return(s.keyStore, nil)
+	info, err := s.accManager.CreateAccountV2(s.password)
+	s.NoError(err)
+	s.Equal(CreateAccountInfo{
+		Address:        address,
+		PubKey:         pubKey,
+		Mnemonic:       mnemonic,
+		DerivationPath: DefaultDerivationPath,
+	}, info)
+
+	s.accManager.SetEntropySource(nil)
+	s.nodeManager.EXPECT().AccountKeyStore().Return(nil, errKeyStore)
+	_, err = s.accManager.CreateAccountV2(s.password)
+	s.Equal(errKeyStore, err)
+}
+
+func (s *ManagerTestSuite) TestCreateAccountContext() {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// no node manager calls are expected with context cancelled beforehand
+	_, _, _, err := s.accManager.CreateAccountContext(ctx, s.password)
+	s.Equal(context.Canceled, err)
+	_, _, err = s.accManager.RecoverAccountContext(ctx, s.password, s.mnemonic)
+	s.Equal(context.Canceled, err)
+
+	// completes normally
+	s.nodeManager.EXPECT().AccountKeyStore().Return(s.keyStore, nil)
+	address, pubKey, mnemonic, err := s.accManager.CreateAccountContext(context.Background(), s.password)
+	s.NoError(err)
+	s.NotEmpty(address)
+	s.NotEmpty(pubKey)
+	s.NotEmpty(mnemonic)
+
+	s.nodeManager.EXPECT().AccountKeyStore().Return(s.keyStore, nil)
+	address, pubKey, err = s.accManager.RecoverAccountContext(context.Background(), s.password, s.mnemonic)
+	s.NoError(err)
+	s.Equal(s.address, address)
+	s.Equal(s.pubKey, pubKey)
+
+	// cancelled before key is stored
+	accounts := s.keyStore.Accounts()
+	s.nodeManager.EXPECT().AccountKeyStore().Do(func() {
+		time.Sleep(500 * time.Millisecond)
+	}).Return(s.keyStore, nil).Times(2)
+
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, _, _, err = s.accManager.CreateAccountContext(ctx, s.password)
+	s.Equal(context.DeadlineExceeded, err)
+	s.True(time.Since(start) < 400*time.Millisecond)
+
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start = time.Now()
+	_, _, err = s.accManager.RecoverAccountContext(ctx, s.password, s.mnemonic)
+	s.Equal(context.DeadlineExceeded, err)
+	s.True(time.Since(start) < 400*time.Millisecond)
+
+	// abandoned tasks don't store keys once they get to it
+	time.Sleep(time.Second)
+	s.Equal(accounts, s.keyStore.Accounts())
+}
+
+// blockingKeyStore is a MemoryKeyStore storing keys only once release is closed,
+// storing is closed when storing is started.
+type blockingKeyStore struct {
+	*MemoryKeyStore
+	storing chan struct{}
+	release chan struct{}
+}
+
+func (s *blockingKeyStore) StoreKey(key *keystore.Key, password string) error {
+	close(s.storing)
+	<-s.release
+	return s.MemoryKeyStore.StoreKey(key, password)
+}
+
+func (s *ManagerTestSuite) TestCreateAccountContextCancelledWhileStoring() {
+	keyStore := &blockingKeyStore{
+		MemoryKeyStore: NewMemoryKeyStore(),
+		storing:        make(chan struct{}),
+		release:        make(chan struct{}),
+	}
+	s.accManager.SetKeyStore(keyStore)
+	defer s.accManager.SetKeyStore(nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-keyStore.storing
+		cancel()
+	}()
+
+	_, _, _, err := s.accManager.CreateAccountContext(ctx, s.password)
+	s.Equal(context.Canceled, err)
+
+	// key stored once ctx is cancelled is deleted
+	close(keyStore.release)
+	s.accManager.keyStoreMu.Lock() // acquired once background store is over
+	s.accManager.keyStoreMu.Unlock()
+	accounts, err := keyStore.Accounts()
+	s.NoError(err)
+	s.Empty(accounts)
+}
+
+// decryptingKeyStore is a MemoryKeyStore counting keys it decrypts with GetKey.
+type decryptingKeyStore struct {
+	*MemoryKeyStore
+	decrypted int32
+}
+
+func (s *decryptingKeyStore) GetKey(address gethcommon.Address, password string) (*keystore.Key, error) {
+	atomic.AddInt32(&s.decrypted, 1)
+	return s.MemoryKeyStore.GetKey(address, password)
+}
+
+// TestImportKeyDecryptsOnlyStoredKeys verifies that a new key isn't decrypted again once
+// it's stored, while the password of a key stored already must match it.
+func (s *ManagerTestSuite) TestImportKeyDecryptsOnlyStoredKeys() {
+	keyStore := &decryptingKeyStore{MemoryKeyStore: NewMemoryKeyStore()}
+	s.accManager.SetKeyStore(keyStore)
+	defer s.accManager.SetKeyStore(nil)
+
+	address, pubKey, mnemonic, err := s.accManager.CreateAccount(s.password)
+	s.Require().NoError(err)
+	s.EqualValues(0, atomic.LoadInt32(&keyStore.decrypted))
+
+	recoveredAddress, recoveredPubKey, err := s.accManager.RecoverAccount(s.password, mnemonic)
+	s.NoError(err)
+	s.Equal(address, recoveredAddress)
+	s.Equal(pubKey, recoveredPubKey)
+	s.EqualValues(1, atomic.LoadInt32(&keyStore.decrypted))
+
+	// stored key is not replaced with the one of another password
+	_, _, err = s.accManager.RecoverAccountWithPassphrase("other-password", mnemonic, s.password)
+	s.Equal(keystore.ErrDecrypt, err)
+	s.EqualValues(2, atomic.LoadInt32(&keyStore.decrypted))
+
+	privateKey, err := crypto.GenerateKey()
+	s.Require().NoError(err)
+	privateKeyHex := hex.EncodeToString(crypto.FromECDSA(privateKey))
+	_, _, err = s.accManager.ImportPrivateKey(privateKeyHex, s.password)
+	s.NoError(err)
+	s.EqualValues(2, atomic.LoadInt32(&keyStore.decrypted))
+}
+
+func (s *ManagerTestSuite) TestCreateAccountWithParams() {
+	keyStoreDir, err := ioutil.TempDir(os.TempDir(), "accounts_params")
+	s.Require().NoError(err)
+	defer os.RemoveAll(keyStoreDir) //nolint: errcheck
+	nodeKeyStore := keystore.NewKeyStore(keyStoreDir, keystore.LightScryptN, keystore.LightScryptP)
+
+	// scrypt N and P of a stored key
+	kdfParams := func(keyJSON []byte) (n, p int) {
+		var keyFile struct {
+			Crypto struct {
+				KDFParams struct {
+					N int `json:"n"`
+					P int `json:"p"`
+				} `json:"kdfparams"`
+			} `json:"crypto"`
+		}
+		s.Require().NoError(json.Unmarshal(keyJSON, &keyFile))
+		return keyFile.Crypto.KDFParams.N, keyFile.Crypto.KDFParams.P
+	}
+
+	s.nodeManager.EXPECT().AccountKeyStore().Return(nodeKeyStore, nil)
+	addr, pubKey, mnemonic, err := s.accManager.CreateAccountWithParams(s.password, keystore.StandardScryptN, keystore.StandardScryptP)
+	s.NoError(err)
+	s.NotEmpty(addr)
+	s.NotEmpty(pubKey)
+	s.NotEmpty(mnemonic)
+
+	// keystore of the node knows the account, key file keeps the params
+	account, err := nodeKeyStore.Find(accounts.Account{Address: gethcommon.HexToAddress(addr)})
+	s.Require().NoError(err)
+	keyJSON, err := ioutil.ReadFile(account.URL.Path)
+	s.Require().NoError(err)
+	n, p := kdfParams(keyJSON)
+	s.Equal(keystore.StandardScryptN, n)
+	s.Equal(keystore.StandardScryptP, p)
+	keyFiles, err := ioutil.ReadDir(keyStoreDir)
+	s.Require().NoError(err)
+	s.Len(keyFiles, 1)
+
+	// recovered account must match the created one
+	s.nodeManager.EXPECT().AccountKeyStore().Return(s.keyStore, nil)
+	recoveredAddr, recoveredPubKey, err := s.accManager.RecoverAccount(s.password, mnemonic)
+	s.NoError(err)
+	s.Equal(addr, recoveredAddr)
+	s.Equal(pubKey, recoveredPubKey)
+
+	// keys are stored by the keystore set with SetKeyStore
+	memoryKeyStore := NewMemoryKeyStore()
+	s.accManager.SetKeyStore(memoryKeyStore)
+	addr, _, _, err = s.accManager.CreateAccountWithParams(s.password, keystore.StandardScryptN, keystore.StandardScryptP)
+	s.accManager.SetKeyStore(nil)
+	s.NoError(err)
+	n, p = kdfParams(memoryKeyStore.keys[gethcommon.HexToAddress(addr)])
+	s.Equal(keystore.StandardScryptN, n)
+	s.Equal(keystore.StandardScryptP, p)
+	key, err := memoryKeyStore.GetKey(gethcommon.HexToAddress(addr), s.password)
+	s.NoError(err)
+	s.Equal(addr, key.Address.Hex())
+
+	s.nodeManager.EXPECT().AccountKeyStore().Return(nil, errKeyStore)
+	_, _, _, err = s.accManager.CreateAccountWithParams(s.password, keystore.LightScryptN, keystore.LightScryptP)
+	s.Equal(errKeyStore, err)
+}
+
+func (s *ManagerTestSuite) TestCreateAccountAt() {
+	s.nodeManager.EXPECT().AccountKeyStore().Return(s.keyStore, nil).AnyTimes()
+
+	// default path matches the account key of master account
+	addr, pubKey, mnemonic, err := s.accManager.CreateAccountAt(s.password, DefaultDerivationPath)
+	s.NoError(err)
+	recoveredAddr, recoveredPubKey, err := s.accManager.RecoverAccount(s.password, mnemonic)
+	s.NoError(err)
+	s.Equal(recoveredAddr, addr)
+	s.Equal(recoveredPubKey, pubKey)
+
+	// different paths from the same mnemonic produce different addresses
+	_, extKey, err := s.accManager.newMasterKey(s.password)
+	s.Require().NoError(err)
+	path1, err := extkeys.ParsePath("m/44'/60'/0'/0/0")
+	s.Require().NoError(err)
+	path2, err := extkeys.ParsePath("m/44'/60'/0'/0/1")
+	s.Require().NoError(err)
+	addr1, _, err := s.accManager.importExtendedKeyAt(extKey, path1, s.password)
+	s.NoError(err)
+	addr2, _, err := s.accManager.importExtendedKeyAt(extKey, path2, s.password)
+	s.NoError(err)
+	s.NotEqual(addr1, addr2)
+
+	// sub-accounts are the same as of the account recovered from mnemonic, whatever the path is
+	for _, path := range []string{DefaultDerivationPath, "m/44'/60'/0'/0/2"} {
+		keyStore := NewMemoryKeyStore()
+		s.accManager.SetKeyStore(keyStore)
+		addr, _, mnemonic, err := s.accManager.CreateAccountAt(s.password, path)
+		s.Require().NoError(err)
+		key, err := keyStore.GetKey(gethcommon.HexToAddress(addr), s.password)
+		s.Require().NoError(err)
+
+		recoveredKeyStore := NewMemoryKeyStore()
+		s.accManager.SetKeyStore(recoveredKeyStore)
+		recoveredAddr, _, err := s.accManager.RecoverAccount(s.password, mnemonic)
+		s.Require().NoError(err)
+		recoveredKey, err := recoveredKeyStore.GetKey(gethcommon.HexToAddress(recoveredAddr), s.password)
+		s.Require().NoError(err)
+
+		s.Equal(recoveredKey.ExtendedKey.String(), key.ExtendedKey.String(), path)
+	}
+	s.accManager.SetKeyStore(nil)
+
+	_, _, _, err = s.accManager.CreateAccountAt(s.password, "44'/60'/0'/0/0")
+	s.Equal(extkeys.ErrInvalidPath, err)
+}
+
+func (s *ManagerTestSuite) TestRecoverAccount() {
+	s.nodeManager.EXPECT().AccountKeyStore().Return(s.keyStore, nil)
+	addr, pubKey, err := s.accManager.RecoverAccount(s.password, s.mnemonic)
+	s.NoError(err)
+	s.Equal(s.address, addr)
+	s.Equal(s.pubKey, pubKey)
+
+	s.nodeManager.EXPECT().AccountKeyStore().Return(nil, errKeyStore)
+	_, _, err = s.accManager.RecoverAccount(s.password, s.mnemonic)
+	s.Equal(errKeyStore, err)
+
+	_, _, err = s.accManager.RecoverAccount(s.password, "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon")
+	s.Equal(ErrInvalidMnemonic, err)
+}
+
+func (s *ManagerTestSuite) TestRecoverAccountWithPassphrase() {
+	s.nodeManager.EXPECT().AccountKeyStore().Return(s.keyStore, nil).AnyTimes()
+
+	// account is created with password used as BIP39 passphrase
+	addr, pubKey, err := s.accManager.RecoverAccountWithPassphrase(s.password, s.mnemonic, s.password)
+	s.NoError(err)
+	s.Equal(s.address, addr)
+	s.Equal(s.pubKey, pubKey)
+
+	addrWithoutPassphrase, _, err := s.accManager.RecoverAccountWithPassphrase(s.password, s.mnemonic, "")
+	s.NoError(err)
+	s.NotEqual(addr, addrWithoutPassphrase)
+
+	addrWithPassphrase, _, err := s.accManager.RecoverAccountWithPassphrase(s.password, s.mnemonic, "25th-word")
+	s.NoError(err)
+	s.NotEqual(addr, addrWithPassphrase)
+	s.NotEqual(addrWithoutPassphrase, addrWithPassphrase)
+
+	// passphrase alone is fed into seed derivation, the password only encrypts the key
+	keyStore := NewMemoryKeyStore()
+	s.accManager.SetKeyStore(keyStore)
+	defer s.accManager.SetKeyStore(nil)
+	addrWithOtherPassword, _, err := s.accManager.RecoverAccountWithPassphrase("other-password", s.mnemonic, "25th-word")
+	s.NoError(err)
+	s.Equal(addrWithPassphrase, addrWithOtherPassword)
+	_, err = keyStore.GetKey(gethcommon.HexToAddress(addrWithOtherPassword), "other-password")
+	s.NoError(err)
+}
+
+func (s *ManagerTestSuite) TestRecoverAccounts() {
+	s.nodeManager.EXPECT().AccountKeyStore().Return(s.keyStore, nil).AnyTimes()
+
+	infos, err := s.accManager.RecoverAccounts(s.password, s.mnemonic, 3)
+	s.Require().NoError(err)
+	s.Require().Len(infos, 3)
+
+	// the first account is the one recovered by RecoverAccount
+	s.Equal(s.address, infos[0].Address)
+	s.Equal(s.pubKey, infos[0].PubKey)
+
+	// sub-accounts of the first account are the ones of the account recovered by RecoverAccount
+	keyStore := NewMemoryKeyStore()
+	s.accManager.SetKeyStore(keyStore)
+	_, err = s.accManager.RecoverAccounts(s.password, s.mnemonic, 1)
+	s.Require().NoError(err)
+	key, err := keyStore.GetKey(gethcommon.HexToAddress(s.address), s.password)
+	s.Require().NoError(err)
+
+	recoveredKeyStore := NewMemoryKeyStore()
+	s.accManager.SetKeyStore(recoveredKeyStore)
+	_, _, err = s.accManager.RecoverAccount(s.password, s.mnemonic)
+	s.Require().NoError(err)
+	recoveredKey, err := recoveredKeyStore.GetKey(gethcommon.HexToAddress(s.address), s.password)
+	s.Require().NoError(err)
+	s.accManager.SetKeyStore(nil)
+
+	s.Equal(recoveredKey.ExtendedKey.String(), key.ExtendedKey.String())
+
+	addresses := make(map[string]bool)
+	for i, info := range infos {
+		s.Equal(fmt.Sprintf("m/44'/60'/0'/0/%d", i), info.DerivationPath)
+		s.Empty(info.Mnemonic)
+		s.True(s.keyStore.HasAddress(gethcommon.HexToAddress(info.Address)))
+		addresses[info.Address] = true
+	}
+	s.Len(addresses, 3)
+
+	// derivation is deterministic
+	again, err := s.accManager.RecoverAccounts(s.password, s.mnemonic, 3)
+	s.NoError(err)
+	s.Equal(infos, again)
+
+	_, err = s.accManager.RecoverAccounts(s.password, s.mnemonic, 0)
+	s.Equal(ErrInvalidAccountsCount, err)
+
+	_, err = s.accManager.RecoverAccounts(s.password, "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon", 3)
+	s.Equal(ErrInvalidMnemonic, err)
+}
+
+func (s *ManagerTestSuite) TestDeriveAccountFromMnemonic() {
+	// no node manager calls are expected, keystore is not touched
+	address, pubKey, err := DeriveAccountFromMnemonic(s.mnemonic, s.password)
+	s.NoError(err)
+	s.Equal(s.address, address)
+	s.Equal(s.pubKey, pubKey)
+
+	s.nodeManager.EXPECT().AccountKeyStore().Return(s.keyStore, nil)
+	recoveredAddress, recoveredPubKey, err := s.accManager.RecoverAccount(s.password, s.mnemonic)
+	s.NoError(err)
+	s.Equal(recoveredAddress, address)
+	s.Equal(recoveredPubKey, pubKey)
+
+	// different passphrase maps to a different account
+	address, _, err = DeriveAccountFromMnemonic(s.mnemonic, "other")
+	s.NoError(err)
+	s.NotEqual(s.address, address)
+
+	_, _, err = DeriveAccountFromMnemonic("not a mnemonic", s.password)
+	s.Equal(ErrInvalidMnemonic, err)
+}
+
+func (s *ManagerTestSuite) TestReEncryptAccount() {
+	s.nodeManager.EXPECT().AccountKeyStore().Return(s.keyStore, nil).AnyTimes()
+
+	addr, _, _, err := s.accManager.CreateAccount(s.password)
+	s.Require().NoError(err)
+
+	account, err := s.keyStore.Find(accounts.Account{Address: gethcommon.HexToAddress(addr)})
+	s.Require().NoError(err)
+	originalKeyJSON, err := ioutil.ReadFile(account.URL.Path)
+	s.Require().NoError(err)
+
+	s.T().Run("fail_wrongPassword", func(t *testing.T) {
+		err := s.accManager.ReEncryptAccount(addr, "wrong-password", "new-password")
+		s.Equal(errors.New("cannot retrieve a valid key for a given account: could not decrypt key with given passphrase"), err)
+
+		keyJSON, err := ioutil.ReadFile(account.URL.Path)
+		s.NoError(err)
+		s.Equal(originalKeyJSON, keyJSON)
+	})
+
+	s.T().Run("fail_wrongAddress", func(t *testing.T) {
+		err := s.accManager.ReEncryptAccount("wrong-address", s.password, "new-password")
+		s.Equal(ErrAddressToAccountMappingFailure, err)
+	})
+
+	s.T().Run("success", func(t *testing.T) {
+		err := s.accManager.ReEncryptAccount(addr, s.password, "new-password")
+		s.NoError(err)
+
+		_, _, err = s.accManager.AddressToDecryptedAccount(addr, "new-password")
+		s.NoError(err)
+		_, _, err = s.accManager.AddressToDecryptedAccount(addr, s.password)
+		s.Error(err)
+	})
+}
+
+func (s *ManagerTestSuite) TestExportAndImportAccount() {
+	s.nodeManager.EXPECT().AccountKeyStore().Return(s.keyStore, nil).AnyTimes()
+
+	addr, _, _, err := s.accManager.CreateAccount(s.password)
+	s.Require().NoError(err)
+
+	keyJSON, err := s.accManager.ExportAccount(addr, s.password)
+	s.Require().NoError(err)
+
+	var exportedKey struct {
+		Address string `json:"address"`
+		Version int    `json:"version"`
+	}
+	s.Require().NoError(json.Unmarshal(keyJSON, &exportedKey))
+	s.Equal(3, exportedKey.Version)
+	s.Equal(gethcommon.HexToAddress(addr), gethcommon.HexToAddress(exportedKey.Address))
+
+	_, err = s.accManager.ExportAccount(addr, "wrong-password")
+	s.Equal(errors.New("could not decrypt key with given passphrase"), err)
+
+	_, err = s.accManager.ExportAccount("wrong-address", s.password)
+	s.Equal(ErrAddressToAccountMappingFailure, err)
+
+	// remove account, and bring it back from exported JSON
+	s.Require().NoError(s.accManager.DeleteAccount(addr, s.password))
+
+	_, err = s.accManager.ImportAccount(keyJSON, "wrong-password")
+	s.Equal(errors.New("could not decrypt key with given passphrase"), err)
+
+	importedAddr, err := s.accManager.ImportAccount(keyJSON, s.password)
+	s.NoError(err)
+	s.Equal(addr, importedAddr)
+
+	_, _, err = s.accManager.AddressToDecryptedAccount(importedAddr, s.password)
+	s.NoError(err)
+}
+
+func (s *ManagerTestSuite) TestImportExtendedKey() {
+	// master key of BIP32 test vector 2, its child at index 0 is
+	// xprv9vHkqa6EV4sPZHYqZznhT2NPtPCjKuDKGY38FBWLvgaDx45zo9WQRUT3dKYnjwih2yJD9mkrocEZXo1ex8G81dwSM1fwqWpWkeS3v86pgKt
+	const xprv = "xprv9s21ZrQH143K31xYSDQpPDxsXRTUcvj2iNHm5NUtrGiGG5e2DtALGdso3pGz6ssrdK4PFmM8NSpSBHNqPqm55Qn3LqFtT2emdEXVYsCzC2U"
+	const xpub = "xpub661MyMwAqRbcFW31YEwpkMuc5THy2PSt5bDMsktWQcFF8syAmRUapSCGu8ED9W6oDMSgv6Zz8idoc4a6mr8BDzTJY47LJhkJ8UB7WEGuduB"
+
+	s.nodeManager.EXPECT().AccountKeyStore().Return(s.keyStore, nil).AnyTimes()
+
+	address, pubKey, err := s.accManager.ImportExtendedKey(xprv, s.password, 0)
+	s.NoError(err)
+	s.Equal("0xaBBcd4471a0b6E76A2f6fdc44008fE53831E208e", address)
+	s.NotEmpty(pubKey)
+
+	_, key, err := s.accManager.AddressToDecryptedAccount(address, s.password)
+	s.NoError(err)
+	s.Equal(address, key.Address.Hex())
+
+	// keys at other indexes are different accounts
+	hardened, _, err := s.accManager.ImportExtendedKey(xprv, s.password, extkeys.HardenedKeyStart)
+	s.NoError(err)
+	s.NotEqual(address, hardened)
+
+	for _, invalid := range []string{"", "xprv-invalid", xpub} {
+		_, _, err = s.accManager.ImportExtendedKey(invalid, s.password, 0)
+		s.Equal(ErrInvalidExtendedKey, err, invalid)
+	}
+}
+
+func (s *ManagerTestSuite) TestImportAccountIn() {
+	s.nodeManager.EXPECT().AccountKeyStore().Return(s.keyStore, nil).AnyTimes()
+
+	keyJSON, err := s.accManager.ExportAccount(s.address, s.password)
+	s.Require().NoError(err)
+
+	keyStoreDir, err := ioutil.TempDir(os.TempDir(), "accounts_restore")
+	s.Require().NoError(err)
+	defer os.RemoveAll(keyStoreDir) //nolint: errcheck
+
+	// directory is created if missing
+	restoreDir := filepath.Join(keyStoreDir, "restore")
+
+	_, err = s.accManager.ImportAccountIn(restoreDir, keyJSON, "wrong-password")
+	s.Equal(keystore.ErrDecrypt, err)
+
+	address, err := s.accManager.ImportAccountIn(restoreDir, keyJSON, s.password)
+	s.NoError(err)
+	s.Equal(s.address, address)
+
+	_, err = s.accManager.ImportAccountIn(restoreDir, keyJSON, s.password)
+	s.Equal(ErrAccountExists, err)
+
+	_, err = s.accManager.VerifyAccountPassword(restoreDir, address, s.password)
+	s.NoError(err)
+	_, err = s.accManager.VerifyAccountPassword(restoreDir, address, "wrong-password")
+	s.Equal(keystore.ErrDecrypt, err)
+
+	// account created in the directory is verified against it, and not added to the keystore of the node
+	created, _, _, err := s.accManager.CreateAccountIn(restoreDir, s.password)
+	s.NoError(err)
+	_, err = s.accManager.VerifyAccountPassword(restoreDir, created, s.password)
+	s.NoError(err)
+	s.False(s.keyStore.HasAddress(gethcommon.HexToAddress(created)))
+
+	issues, err := s.accManager.VerifyKeystoreIntegrity(restoreDir)
+	s.NoError(err)
+	s.Empty(issues)
+}
+
+func (s *ManagerTestSuite) TestImportPrivateKey() {
+	s.nodeManager.EXPECT().AccountKeyStore().Return(s.keyStore, nil).AnyTimes()
+
+	testCases := []struct {
+		name            string
+		privateKeyHex   string
+		expectedAddress string
+		expectedError   error
+	}{
+		{
+			"success",
+			"0x4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318",
+			"0x2c7536E3605D9C16a7a3D7b1898e529396a65c23",
+			nil,
+		},
+		{
+			"fail_malformedHex",
+			"0x4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f36231z",
+			"",
+			ErrInvalidPrivateKeyEncoding,
+		},
+		{
+			"fail_wrongLength",
+			"0x4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f3623",
+			"",
+			ErrInvalidPrivateKeyEncoding,
+		},
+		{
+			"fail_outsideCurveOrder",
+			"0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+			"",
+			ErrInvalidPrivateKey,
+		},
+		{
+			"fail_zeroKey",
+			"0x0000000000000000000000000000000000000000000000000000000000000000",
+			"",
+			ErrInvalidPrivateKey,
+		},
+	}
+
+	for _, testCase := range testCases {
+		s.T().Run(testCase.name, func(t *testing.T) {
+			addr, pubKey, err := s.accManager.ImportPrivateKey(testCase.privateKeyHex, s.password)
+			s.Equal(testCase.expectedError, err)
+			s.Equal(testCase.expectedAddress, addr)
+			if err == nil {
+				s.NotEmpty(pubKey)
+				_, _, err = s.accManager.AddressToDecryptedAccount(addr, s.password)
+				s.NoError(err)
+			}
+		})
+	}
+}
+
+func (s *ManagerTestSuite) TestSelectAccount() {
+	testCases := []struct {
+		name                  string
+		accountKeyStoreReturn []interface{}
+		whisperServiceReturn  []interface{}
+		address               string
+		password              string
+		expectedError         error
+		expectedKinds         []error
+	}{
+		{
+			"success",
+			[]interface{}{s.keyStore, nil},
+			[]interface{}{s.shh, nil},
+			s.address,
+			s.password,
+			nil,
+			nil,
+		},
+		{
+			"fail_keyStore",
+			[]interface{}{nil, errKeyStore},
+			[]interface{}{s.shh, nil},
+			s.address,
+			s.password,
+			errKeyStore,
+			[]error{ErrKeyStoreUnavailable, errKeyStore},
+		},
+		{
+			"fail_whisperService",
+			[]interface{}{s.keyStore, nil},
+			[]interface{}{nil, errWhisper},
+			s.address,
+			s.password,
+			errWhisper,
+			[]error{ErrWhisperUnavailable, errWhisper},
+		},
+		{
+			"fail_whisperServiceUnavailable",
+			[]interface{}{s.keyStore, nil},
+			[]interface{}{nil, common.ErrWhisperServiceUnavailable},
+			s.address,
+			s.password,
+			common.ErrWhisperServiceUnavailable,
+			[]error{ErrWhisperUnavailable, common.ErrWhisperServiceUnavailable},
+		},
+		{
+			"fail_wrongAddress",
+			[]interface{}{s.keyStore, nil},
+			[]interface{}{s.shh, nil},
+			"wrong-address",
+			s.password,
+			ErrAddressToAccountMappingFailure,
+			[]error{ErrAddressToAccountMappingFailure},
+		},
+		{
+			"fail_unknownAccount",
+			[]interface{}{s.keyStore, nil},
+			[]interface{}{s.shh, nil},
+			"0x79791d3e8f2daa1f7fec29649d152c0ada3cc535",
+			s.password,
+			errors.New("cannot retrieve a valid key for a given account: no key for given address or file"),
+			[]error{ErrAccountToKeyMappingFailure, ErrAccountNotFound},
+		},
+		{
+			"fail_wrongPassword",
+			[]interface{}{s.keyStore, nil},
+			[]interface{}{s.shh, nil},
+			s.address,
+			"wrong-password",
+			errors.New("cannot retrieve a valid key for a given account: could not decrypt key with given passphrase"),
+			[]error{ErrAccountToKeyMappingFailure, ErrWrongPassword},
+		},
+	}
+
+	for _, testCase := range testCases {
+		s.T().Run(testCase.name, func(t *testing.T) {
+			s.reinitMock()
+			s.nodeManager.EXPECT().AccountKeyStore().Return(testCase.accountKeyStoreReturn...).AnyTimes()
+			s.nodeManager.EXPECT().WhisperService().Return(testCase.whisperServiceReturn...).AnyTimes()
+			err := s.accManager.SelectAccount(testCase.address, testCase.password)
+			if testCase.expectedError == nil {
+				s.NoError(err)
+				return
+			}
+
+			s.EqualError(err, testCase.expectedError.Error())
+			for _, kind := range testCase.expectedKinds {
+				s.True(IsKind(err, kind), "%v is not %v", err, kind)
+			}
+		})
+	}
+}
+
+func (s *ManagerTestSuite) TestDeleteAccount() {
+	s.nodeManager.EXPECT().AccountKeyStore().Return(s.keyStore, nil).AnyTimes()
+	s.nodeManager.EXPECT().WhisperService().Return(s.shh, nil).AnyTimes()
+
+	addr, _, _, err := s.accManager.CreateAccount(s.password)
+	s.NoError(err)
+
+	s.T().Run("fail_wrongPassword", func(t *testing.T) {
+		// password is verified the way VerifyAccountPassword does it
+		decryptions, restore := countDecryptions()
+		defer restore()
+
+		err := s.accManager.DeleteAccount(addr, "wrong-password")
+		s.Equal(errors.New("could not decrypt key with given passphrase"), err)
+		s.EqualValues(1, atomic.LoadInt32(decryptions))
+		s.True(s.keyStore.HasAddress(gethcommon.HexToAddress(addr)))
+	})
+
+	s.T().Run("fail_missingAddress", func(t *testing.T) {
+		missingAddress := "0x79791d3E8F2dAa1F7FeC29649d152c0aDA3cc535"
+		err := s.accManager.DeleteAccount(missingAddress, s.password)
+		s.EqualError(err, fmt.Sprintf("cannot locate account for address: %s", missingAddress))
+		s.True(IsKind(err, ErrAccountNotFound))
+	})
+
+	s.T().Run("fail_selectedAccount", func(t *testing.T) {
+		s.NoError(s.accManager.SelectAccount(addr, s.password))
+		err := s.accManager.DeleteAccount(addr, s.password)
+		s.Equal(ErrSelectedAccountDeletion, err)
+		s.NoError(s.accManager.Logout())
+	})
+
+	s.T().Run("success", func(t *testing.T) {
+		err := s.accManager.DeleteAccount(addr, s.password)
+		s.NoError(err)
+		s.False(s.keyStore.HasAddress(gethcommon.HexToAddress(addr)))
+	})
+
+	s.T().Run("success_labelNotCleared", func(t *testing.T) {
+		addr, _, _, err := s.accManager.CreateAccount(s.password)
+		s.Require().NoError(err)
+		account, err := s.keyStore.Find(accounts.Account{Address: gethcommon.HexToAddress(addr)})
+		s.Require().NoError(err)
+
+		// key is deleted already, when labels can't be updated
+		labelsPath := filepath.Join(filepath.Dir(account.URL.Path), labelsFileName)
+		s.Require().NoError(ioutil.WriteFile(labelsPath, []byte("not json"), 0600))
+		defer os.Remove(labelsPath) //nolint: errcheck
+
+		s.NoError(s.accManager.DeleteAccount(addr, s.password))
+		s.False(s.keyStore.HasAddress(gethcommon.HexToAddress(addr)))
+	})
+}
+
+// TestDeleteAccountConcurrentSelect verifies that an account being deleted
+// can't get selected in between of the selection check and the deletion.
+func (s *ManagerTestSuite) TestDeleteAccountConcurrentSelect() {
+	s.nodeManager.EXPECT().AccountKeyStore().Return(s.keyStore, nil).AnyTimes()
+	s.nodeManager.EXPECT().WhisperService().Return(s.shh, nil).AnyTimes()
+
+	addr, _, _, err := s.accManager.CreateAccount(s.password)
+	s.Require().NoError(err)
+
+	// selection holds the lock the deletion checks selected account under
+	s.accManager.mu.Lock()
+	deleted := make(chan error, 1)
+	go func() {
+		deleted <- s.accManager.DeleteAccount(addr, s.password)
+	}()
+
+	select {
+	case err := <-deleted:
+		s.Failf("account is not expected to be deleted while it may be selected", "error: %v", err)
+	case <-time.After(500 * time.Millisecond):
+	}
+	s.True(s.keyStore.HasAddress(gethcommon.HexToAddress(addr)))
+
+	s.accManager.mu.Unlock()
+	s.NoError(<-deleted)
+	s.False(s.keyStore.HasAddress(gethcommon.HexToAddress(addr)))
+}
+
+func (s *ManagerTestSuite) TestAccountLabels() {
+	keyStoreDir, err := ioutil.TempDir(os.TempDir(), "accounts_labels")
+	s.Require().NoError(err)
+	defer os.RemoveAll(keyStoreDir) //nolint: errcheck
+
+	keyStore := keystore.NewKeyStore(keyStoreDir, keystore.LightScryptN, keystore.LightScryptP)
+	s.nodeManager.EXPECT().NodeConfig().Return(&params.NodeConfig{KeyStoreDir: keyStoreDir}, nil).AnyTimes()
+	s.nodeManager.EXPECT().AccountKeyStore().Return(keyStore, nil).AnyTimes()
+
+	addr, _, _, err := s.accManager.CreateAccount(s.password)
+	s.NoError(err)
+
+	_, ok := s.accManager.GetAccountLabel(addr)
+	s.False(ok)
+
+	s.NoError(s.accManager.SetAccountLabel(addr, "Savings"))
+	s.Equal(ErrAddressToAccountMappingFailure, s.accManager.SetAccountLabel("invalid", "Savings"))
+
+	// labels survive restart, addresses are matched regardless of case
+	restarted := NewManager(s.nodeManager)
+	label, ok := restarted.GetAccountLabel(strings.ToLower(addr))
+	s.True(ok)
+	s.Equal("Savings", label)
+
+	// label file is not treated as a key
+	issues, err := restarted.VerifyKeystoreIntegrity(keyStoreDir)
+	s.NoError(err)
+	s.Empty(issues)
+
+	s.NoError(restarted.DeleteAccount(addr, s.password))
+	_, ok = restarted.GetAccountLabel(addr)
+	s.False(ok)
+}
+
+func (s *ManagerTestSuite) TestAccountLabelsKeyFileDir() {
+	keyStoreDir, err := ioutil.TempDir(os.TempDir(), "accounts_labels")
+	s.Require().NoError(err)
+	defer os.RemoveAll(keyStoreDir) //nolint: errcheck
+
+	configKeyStoreDir, err := ioutil.TempDir(os.TempDir(), "accounts_labels_config")
+	s.Require().NoError(err)
+	defer os.RemoveAll(configKeyStoreDir) //nolint: errcheck
+
+	// key files are kept outside of key store directory of the node config
+	keyStore := keystore.NewKeyStore(keyStoreDir, keystore.LightScryptN, keystore.LightScryptP)
+	s.nodeManager.EXPECT().NodeConfig().Return(&params.NodeConfig{KeyStoreDir: configKeyStoreDir}, nil).AnyTimes()
+	s.nodeManager.EXPECT().AccountKeyStore().Return(keyStore, nil).AnyTimes()
+
+	addr, _, _, err := s.accManager.CreateAccount(s.password)
+	s.Require().NoError(err)
+
+	// labels are stored next to key files, where DeleteAccount removes them from
+	s.NoError(s.accManager.SetAccountLabel(addr, "Savings"))
+	_, err = os.Stat(filepath.Join(keyStoreDir, labelsFileName))
+	s.NoError(err)
+	_, err = os.Stat(filepath.Join(configKeyStoreDir, labelsFileName))
+	s.True(os.IsNotExist(err))
+
+	label, ok := s.accManager.GetAccountLabel(addr)
+	s.True(ok)
+	s.Equal("Savings", label)
+
+	s.NoError(s.accManager.DeleteAccount(addr, s.password))
+	_, ok = s.accManager.GetAccountLabel(addr)
+	s.False(ok)
+	labels, err := readLabels(keyStoreDir)
+	s.NoError(err)
+	s.Empty(labels)
+}
+
+func (s *ManagerTestSuite) TestCreateChildAccount() {
+	// First, test the negative case where an account is not selected
+	// and an address is not provided.
+	s.accManager.selectedAccount = nil
+	s.T().Run("fail_noAccount", func(t *testing.T) {
+		s.nodeManager.EXPECT().AccountKeyStore().Return(s.keyStore, nil).AnyTimes()
+		_, _, err := s.accManager.CreateChildAccount("", s.password)
+		s.Equal(ErrNoAccountSelected, err)
+	})
+
+	// Now, select the test account for rest of the test cases.
+	s.reinitMock()
+	s.nodeManager.EXPECT().AccountKeyStore().Return(s.keyStore, nil).AnyTimes()
+	s.nodeManager.EXPECT().WhisperService().Return(s.shh, nil).AnyTimes()
+	err := s.accManager.SelectAccount(s.address, s.password)
+	s.NoError(err)
+
+	testCases := []struct {
+		name                  string
+		address               string
+		password              string
+		accountKeyStoreReturn []interface{}
+		expectedError         error
+	}{
+		{
+			"success",
+			s.address,
+			s.password,
+			[]interface{}{s.keyStore, nil},
+			nil,
+		},
+		{
+			"fail_keyStore",
+			s.address,
+			s.password,
+			[]interface{}{nil, errKeyStore},
+			errKeyStore,
+		},
+		{
+			"fail_wrongAddress",
+			"wrong-address",
+			s.password,
+			[]interface{}{s.keyStore, nil},
 			ErrAddressToAccountMappingFailure,
 		},
 		{
 			"fail_wrongPassword",
-			[]interface{}{s.keyStore, nil},
-			[]interface{}{s.shh, nil},
 			s.address,
 			"wrong-password",
+			[]interface{}{s.keyStore, nil},
 			errors.New("cannot retrieve a valid key for a given account: could not decrypt key with given passphrase"),
 		},
 	}
@@ -270,130 +1846,590 @@ func (s *ManagerTestSuite) TestSelectAccount() {
 		s.T().Run(testCase.name, func(t *testing.T) {
 			s.reinitMock()
 			s.nodeManager.EXPECT().AccountKeyStore().Return(testCase.accountKeyStoreReturn...).AnyTimes()
-			s.nodeManager.EXPECT().WhisperService().Return(testCase.whisperServiceReturn...).AnyTimes()
-			err := s.accManager.SelectAccount(testCase.address, testCase.password)
-			s.Equal(testCase.expectedError, err)
+			childAddr, childPubKey, err := s.accManager.CreateChildAccount(testCase.address, testCase.password)
+			if testCase.expectedError != nil {
+				s.Equal(testCase.expectedError, err)
+			} else {
+				s.NoError(err)
+				s.NotEmpty(childAddr)
+				s.NotEmpty(childPubKey)
+			}
+		})
+	}
+}
+
+func (s *ManagerTestSuite) TestSelectedAndReSelectAccount() {
+	// Select the test account
+	s.nodeManager.EXPECT().AccountKeyStore().Return(s.keyStore, nil).AnyTimes()
+	s.nodeManager.EXPECT().WhisperService().Return(s.shh, nil).AnyTimes()
+	err := s.accManager.SelectAccount(s.address, s.password)
+	s.NoError(err)
+
+	s.T().Run("success", func(t *testing.T) {
+		acc, err := s.accManager.SelectedAccount()
+		s.NoError(err)
+		s.NotNil(acc)
+
+		err = s.accManager.ReSelectAccount()
+		s.NoError(err)
+	})
+
+	s.T().Run("ReSelect_fail_whisper", func(t *testing.T) {
+		s.reinitMock()
+		s.nodeManager.EXPECT().WhisperService().Return(nil, errWhisper).AnyTimes()
+		err = s.accManager.ReSelectAccount()
+		s.Equal(errWhisper, err)
+	})
+
+	s.accManager.selectedAccount = nil
+	s.reinitMock()
+	s.nodeManager.EXPECT().AccountKeyStore().Return(s.keyStore, nil).AnyTimes()
+	s.nodeManager.EXPECT().WhisperService().Return(s.shh, nil).AnyTimes()
+
+	s.T().Run("Selected_fail_noAccount", func(t *testing.T) {
+		_, err := s.accManager.SelectedAccount()
+		s.Equal(ErrNoAccountSelected, err)
+	})
+
+	s.T().Run("ReSelect_success_noAccount", func(t *testing.T) {
+		err = s.accManager.ReSelectAccount()
+		s.NoError(err)
+	})
+}
+
+func (s *ManagerTestSuite) TestReSelectAccountIdentities() {
+	shh := whisper.New(nil)
+	s.NoError(shh.Start(nil))
+	defer shh.Stop() //nolint: errcheck
+
+	s.nodeManager.EXPECT().AccountKeyStore().Return(s.keyStore, nil).AnyTimes()
+	s.nodeManager.EXPECT().WhisperService().Return(shh, nil).AnyTimes()
+
+	address1, pubKey1, _, err := s.accManager.CreateAccount(s.password)
+	s.NoError(err)
+
+	s.NoError(s.accManager.SelectAccount(s.address, s.password))
+	defer s.accManager.Logout() //nolint: errcheck
+	s.NoError(s.accManager.AddWhisperIdentity(address1, s.password))
+	keyID, err := s.accManager.RotateWhisperIdentity()
+	s.NoError(err)
+
+	// identities are lost once node is restarted
+	s.NoError(shh.DeleteKeyPairs())
+	s.False(shh.HasKeyPair(keyID))
+	s.False(shh.HasKeyPair(pubKey1))
+
+	s.NoError(s.accManager.ReSelectAccount())
+	s.True(shh.HasKeyPair(keyID))
+	s.True(shh.HasKeyPair(pubKey1))
+	s.False(shh.HasKeyPair(s.pubKey))
+}
+
+func (s *ManagerTestSuite) TestLogout() {
+	s.nodeManager.EXPECT().WhisperService().Return(s.shh, nil)
+	err := s.accManager.Logout()
+	s.NoError(err)
+
+	// select account, and make sure it is unusable after logout
+	s.nodeManager.EXPECT().AccountKeyStore().Return(s.keyStore, nil).AnyTimes()
+	s.nodeManager.EXPECT().WhisperService().Return(s.shh, nil).Times(2)
+	err = s.accManager.SelectAccount(s.address, s.password)
+	s.NoError(err)
+	selectedAccount, err := s.accManager.SelectedAccount()
+	s.NoError(err)
+	s.accManager.mu.RLock()
+	accountKey := s.accManager.selectedAccount.AccountKey
+	s.accManager.mu.RUnlock()
+	s.False(selectedAccount.AccountKey == accountKey, "selected account key is expected to be a copy")
+
+	err = s.accManager.Logout()
+	s.NoError(err)
+	for _, word := range accountKey.PrivateKey.D.Bits() {
+		s.Zero(word)
+	}
+	// copy handed out before is left intact
+	s.Equal(s.address, crypto.PubkeyToAddress(selectedAccount.AccountKey.PrivateKey.PublicKey).Hex())
+	_, err = s.accManager.SelectedAccount()
+	s.Equal(ErrNoAccountSelected, err)
+	_, _, err = s.accManager.CreateChildAccount("", s.password)
+	s.Equal(ErrNoAccountSelected, err)
+
+	s.nodeManager.EXPECT().WhisperService().Return(nil, errWhisper)
+	err = s.accManager.Logout()
+	s.Equal(errWhisper, err)
+}
+
+func (s *ManagerTestSuite) TestSelectAccountWipesReplacedKey() {
+	s.nodeManager.EXPECT().AccountKeyStore().Return(s.keyStore, nil).AnyTimes()
+	s.nodeManager.EXPECT().WhisperService().Return(s.shh, nil).AnyTimes()
+
+	s.Require().NoError(s.accManager.SelectAccount(s.address, s.password))
+	defer s.accManager.Logout() //nolint: errcheck
+	s.accManager.mu.RLock()
+	accountKey := s.accManager.selectedAccount.AccountKey
+	s.accManager.mu.RUnlock()
+
+	s.Require().NoError(s.accManager.SelectAccount(s.address, s.password))
+	for _, word := range accountKey.PrivateKey.D.Bits() {
+		s.Zero(word)
+	}
+
+	// identity of the account is still injected
+	s.True(s.shh.HasKeyPair(s.pubKey))
+}
+
+func (s *ManagerTestSuite) TestSendWhisperMessage() {
+	topic := whisper.BytesToTopic([]byte("test"))
+	payload := []byte("hello")
+	symKey := make([]byte, 32)
+	copy(symKey, "test-symmetric-key")
+
+	// No account selected
+	_, err := s.accManager.SendWhisperMessage(topic, payload, symKey)
+	s.Equal(ErrNoAccountSelected, err)
+
+	shh := whisper.New(nil)
+	s.NoError(shh.Start(nil))
+	defer shh.Stop() //nolint: errcheck
+
+	s.nodeManager.EXPECT().AccountKeyStore().Return(s.keyStore, nil).AnyTimes()
+	s.nodeManager.EXPECT().WhisperService().Return(shh, nil).AnyTimes()
+	s.NoError(s.accManager.SelectAccount(s.address, s.password))
+
+	filterID, err := shh.Subscribe(&whisper.Filter{
+		KeySym:     symKey,
+		SymKeyHash: crypto.Keccak256Hash(symKey),
+		Topics:     [][]byte{topic[:]},
+		Messages:   make(map[gethcommon.Hash]*whisper.ReceivedMessage),
+	})
+	s.NoError(err)
+
+	hash, err := s.accManager.SendWhisperMessage(topic, payload, symKey)
+	s.NoError(err)
+
+	var received []*whisper.ReceivedMessage
+	for i := 0; i < 50 && len(received) == 0; i++ {
+		time.Sleep(100 * time.Millisecond)
+		received = shh.Messages(filterID)
+	}
+	s.Require().Len(received, 1)
+	s.Equal(hash, received[0].EnvelopeHash)
+	s.Equal(topic, received[0].Topic)
+	s.Equal(payload, received[0].Payload)
+	s.Equal(gethcommon.HexToAddress(s.address), crypto.PubkeyToAddress(*received[0].Src))
+}
+
+func (s *ManagerTestSuite) TestSendWhisperMessageWithParams() {
+	topic := whisper.BytesToTopic([]byte("test"))
+	payload := []byte("hello")
+	symKey := make([]byte, 32)
+	copy(symKey, "test-symmetric-key")
+	params := WhisperMessageParams{TTL: 7, PoW: whisper.DefaultMinimumPoW, WorkTime: 1}
+
+	shh := whisper.New(nil)
+	s.NoError(shh.Start(nil))
+	defer shh.Stop() //nolint: errcheck
+
+	s.nodeManager.EXPECT().AccountKeyStore().Return(s.keyStore, nil).AnyTimes()
+	s.nodeManager.EXPECT().WhisperService().Return(shh, nil).AnyTimes()
+	s.NoError(s.accManager.SelectAccount(s.address, s.password))
+
+	filterID, err := shh.Subscribe(&whisper.Filter{
+		KeySym:     symKey,
+		SymKeyHash: crypto.Keccak256Hash(symKey),
+		Topics:     [][]byte{topic[:]},
+		Messages:   make(map[gethcommon.Hash]*whisper.ReceivedMessage),
+	})
+	s.NoError(err)
+
+	hash, err := s.accManager.SendWhisperMessageWithParams(topic, payload, symKey, params)
+	s.NoError(err)
+
+	var received []*whisper.ReceivedMessage
+	for i := 0; i < 50 && len(received) == 0; i++ {
+		time.Sleep(100 * time.Millisecond)
+		received = shh.Messages(filterID)
+	}
+	s.Require().Len(received, 1)
+	s.Equal(hash, received[0].EnvelopeHash)
+	s.Equal(uint32(7), received[0].TTL)
+	s.True(received[0].PoW >= whisper.DefaultMinimumPoW)
+	s.Equal(payload, received[0].Payload)
+
+	// Zero TTL falls back to the default one
+	_, err = s.accManager.SendWhisperMessageWithParams(topic, []byte("default"), symKey,
+		WhisperMessageParams{PoW: whisper.DefaultMinimumPoW, WorkTime: 1})
+	s.NoError(err)
+
+	var defaultTTL *whisper.ReceivedMessage
+	for i := 0; i < 50 && defaultTTL == nil; i++ {
+		time.Sleep(100 * time.Millisecond)
+		for _, message := range shh.Messages(filterID) {
+			if string(message.Payload) == "default" {
+				defaultTTL = message
+			}
+		}
+	}
+	s.Require().NotNil(defaultTTL)
+	s.Equal(uint32(whisper.DefaultTTL), defaultTTL.TTL)
+}
+
+func (s *ManagerTestSuite) TestWhisperIdentities() {
+	shh := whisper.New(nil)
+	s.NoError(shh.Start(nil))
+	defer shh.Stop() //nolint: errcheck
+
+	s.nodeManager.EXPECT().AccountKeyStore().Return(s.keyStore, nil).AnyTimes()
+	s.nodeManager.EXPECT().WhisperService().Return(shh, nil).AnyTimes()
+
+	address1, pubKey1, _, err := s.accManager.CreateAccount(s.password)
+	s.NoError(err)
+	address2, pubKey2, _, err := s.accManager.CreateAccount(s.password)
+	s.NoError(err)
+
+	s.NoError(s.accManager.SelectAccount(s.address, s.password))
+	s.NoError(s.accManager.AddWhisperIdentity(address1, s.password))
+	s.NoError(s.accManager.AddWhisperIdentity(address2, s.password))
+	s.True(shh.HasKeyPair(s.pubKey))
+
+	// both identities receive messages addressed to them
+	topic := whisper.BytesToTopic([]byte("test"))
+	for _, pubKey := range []string{pubKey1, pubKey2} {
+		key, err := shh.GetPrivateKey(pubKey)
+		s.NoError(err)
+
+		filterID, err := shh.Subscribe(&whisper.Filter{
+			KeyAsym:  key,
+			Topics:   [][]byte{topic[:]},
+			Messages: make(map[gethcommon.Hash]*whisper.ReceivedMessage),
 		})
+		s.NoError(err)
+
+		params := &whisper.MessageParams{
+			Dst:      &key.PublicKey,
+			Topic:    topic,
+			Payload:  []byte(pubKey),
+			PoW:      shh.MinPow(),
+			WorkTime: 5,
+		}
+		message, err := whisper.NewSentMessage(params)
+		s.NoError(err)
+		envelope, err := message.Wrap(params)
+		s.NoError(err)
+		s.NoError(shh.Send(envelope))
+
+		var received []*whisper.ReceivedMessage
+		for i := 0; i < 50 && len(received) == 0; i++ {
+			time.Sleep(100 * time.Millisecond)
+			received = shh.Messages(filterID)
+		}
+		s.Require().Len(received, 1)
+		s.Equal([]byte(pubKey), received[0].Payload)
+	}
+
+	// re-selecting account keeps additional identities
+	s.NoError(s.accManager.SelectAccount(s.address, s.password))
+	s.True(shh.HasKeyPair(pubKey1))
+	s.True(shh.HasKeyPair(pubKey2))
+
+	s.NoError(s.accManager.RemoveWhisperIdentity(address1))
+	s.False(shh.HasKeyPair(pubKey1))
+	s.True(shh.HasKeyPair(pubKey2))
+	s.True(shh.HasKeyPair(s.pubKey))
+	s.Equal(ErrWhisperIdentityNotFound, s.accManager.RemoveWhisperIdentity(address1))
+
+	// selected account identity is kept on removal
+	s.NoError(s.accManager.AddWhisperIdentity(s.address, s.password))
+	s.NoError(s.accManager.RemoveWhisperIdentity(s.address))
+	s.True(shh.HasKeyPair(s.pubKey))
+
+	s.NoError(s.accManager.Logout())
+	s.False(shh.HasKeyPair(pubKey2))
+	s.Equal(ErrWhisperIdentityNotFound, s.accManager.RemoveWhisperIdentity(address2))
+}
+
+func (s *ManagerTestSuite) TestRotateWhisperIdentity() {
+	shh := whisper.New(nil)
+	s.NoError(shh.Start(nil))
+	defer shh.Stop() //nolint: errcheck
+
+	s.nodeManager.EXPECT().AccountKeyStore().Return(s.keyStore, nil).AnyTimes()
+	s.nodeManager.EXPECT().WhisperService().Return(shh, nil).AnyTimes()
+
+	// No account selected
+	_, err := s.accManager.RotateWhisperIdentity()
+	s.Equal(ErrNoAccountSelected, err)
+
+	s.NoError(s.accManager.SelectAccount(s.address, s.password))
+
+	var rotated [][2]string
+	s.accManager.OnWhisperIdentityRotated(func(oldKeyID, newKeyID string) {
+		rotated = append(rotated, [2]string{oldKeyID, newKeyID})
+	})
+	defer s.accManager.OnWhisperIdentityRotated(nil)
+
+	keyID, err := s.accManager.RotateWhisperIdentity()
+	s.NoError(err)
+	s.True(shh.HasKeyPair(keyID))
+	s.False(shh.HasKeyPair(s.pubKey))
+	s.Require().Len(rotated, 1)
+	s.NotEqual(rotated[0][0], keyID)
+	s.Equal(keyID, rotated[0][1])
+
+	// messages addressed to the new key are received
+	key, err := shh.GetPrivateKey(keyID)
+	s.NoError(err)
+	topic := whisper.BytesToTopic([]byte("test"))
+	filterID, err := shh.Subscribe(&whisper.Filter{
+		KeyAsym:  key,
+		Topics:   [][]byte{topic[:]},
+		Messages: make(map[gethcommon.Hash]*whisper.ReceivedMessage),
+	})
+	s.NoError(err)
+
+	params := &whisper.MessageParams{
+		Dst:      &key.PublicKey,
+		Topic:    topic,
+		Payload:  []byte("hello"),
+		PoW:      shh.MinPow(),
+		WorkTime: 5,
+	}
+	message, err := whisper.NewSentMessage(params)
+	s.NoError(err)
+	envelope, err := message.Wrap(params)
+	s.NoError(err)
+	s.NoError(shh.Send(envelope))
+
+	var received []*whisper.ReceivedMessage
+	for i := 0; i < 50 && len(received) == 0; i++ {
+		time.Sleep(100 * time.Millisecond)
+		received = shh.Messages(filterID)
+	}
+	s.Require().Len(received, 1)
+	s.Equal([]byte("hello"), received[0].Payload)
+
+	// rotating again replaces the rotated key
+	nextKeyID, err := s.accManager.RotateWhisperIdentity()
+	s.NoError(err)
+	s.NotEqual(keyID, nextKeyID)
+	s.False(shh.HasKeyPair(keyID))
+	s.Require().Len(rotated, 2)
+	s.Equal([2]string{keyID, nextKeyID}, rotated[1])
+
+	// selecting the account again restores its identity
+	s.NoError(s.accManager.SelectAccount(s.address, s.password))
+	s.True(shh.HasKeyPair(s.pubKey))
+	s.False(shh.HasKeyPair(nextKeyID))
+
+	s.NoError(s.accManager.Logout())
+}
+
+func (s *ManagerTestSuite) TestSymKeyFromPassword() {
+	shh := whisper.New(nil)
+	s.nodeManager.EXPECT().WhisperService().Return(shh, nil).AnyTimes()
+
+	keyID, err := s.accManager.AddSymKeyFromPassword("public-chat")
+	s.NoError(err)
+	symKey, err := shh.GetSymKey(keyID)
+	s.NoError(err)
+
+	// message encrypted with a key derived from the same password
+	otherKeyID, err := s.accManager.AddSymKeyFromPassword("public-chat")
+	s.NoError(err)
+	s.NotEqual(keyID, otherKeyID)
+	otherSymKey, err := shh.GetSymKey(otherKeyID)
+	s.NoError(err)
+
+	params := &whisper.MessageParams{
+		KeySym:  otherSymKey,
+		Topic:   whisper.BytesToTopic([]byte("test")),
+		Payload: []byte("hello"),
+	}
+	message, err := whisper.NewSentMessage(params)
+	s.NoError(err)
+	envelope, err := message.Wrap(params)
+	s.NoError(err)
+
+	received, err := envelope.OpenSymmetric(symKey)
+	s.NoError(err)
+	s.True(received.ValidateAndParse())
+	s.Equal([]byte("hello"), received.Payload)
+
+	s.NoError(s.accManager.DeleteSymKey(keyID))
+	s.False(shh.HasSymKey(keyID))
+	s.Equal(ErrWhisperSymKeyNotFound, s.accManager.DeleteSymKey(keyID))
+
+	s.reinitMock()
+	s.nodeManager.EXPECT().WhisperService().Return(nil, errWhisper).AnyTimes()
+	_, err = s.accManager.AddSymKeyFromPassword("public-chat")
+	s.Equal(errWhisper, err)
+	s.Equal(errWhisper, s.accManager.DeleteSymKey(otherKeyID))
+}
+
+type logRecord struct {
+	level string
+	msg   string
+	ctx   []interface{}
+}
+
+type captureLogger struct {
+	mu      sync.Mutex
+	records []logRecord
+}
+
+func (l *captureLogger) add(level, msg string, ctx []interface{}) {
+	l.mu.Lock()
+	l.records = append(l.records, logRecord{level, msg, ctx})
+	l.mu.Unlock()
+}
+
+func (l *captureLogger) Debug(msg string, ctx ...interface{}) { l.add("debug", msg, ctx) }
+func (l *captureLogger) Info(msg string, ctx ...interface{})  { l.add("info", msg, ctx) }
+func (l *captureLogger) Warn(msg string, ctx ...interface{})  { l.add("warn", msg, ctx) }
+
+func (s *ManagerTestSuite) TestLogger() {
+	logger := &captureLogger{}
+	s.accManager.SetLogger(logger)
+	defer s.accManager.SetLogger(nil)
+
+	s.nodeManager.EXPECT().AccountKeyStore().Return(s.keyStore, nil).AnyTimes()
+	s.nodeManager.EXPECT().WhisperService().Return(s.shh, nil).AnyTimes()
+	s.NoError(s.accManager.SelectAccount(s.address, s.password))
+	s.Error(s.accManager.SelectAccount(s.address, "wrong-password"))
+
+	s.Require().Len(logger.records, 2)
+	s.Equal(logRecord{"info", "select account", []interface{}{"address", s.address}}, logger.records[0])
+	s.Equal("warn", logger.records[1].level)
+	s.Equal("select account failed", logger.records[1].msg)
+	s.Equal([]interface{}{"address", s.address}, logger.records[1].ctx[:2])
+
+	// secrets are never logged
+	for _, record := range logger.records {
+		for _, value := range record.ctx {
+			s.NotContains(fmt.Sprint(value), s.password)
+			s.NotContains(fmt.Sprint(value), "wrong-password")
+		}
 	}
 }
 
-func (s *ManagerTestSuite) TestCreateChildAccount() {
-	// First, test the negative case where an account is not selected
-	// and an address is not provided.
-	s.accManager.selectedAccount = nil
-	s.T().Run("fail_noAccount", func(t *testing.T) {
-		s.nodeManager.EXPECT().AccountKeyStore().Return(s.keyStore, nil).AnyTimes()
-		_, _, err := s.accManager.CreateChildAccount("", s.password)
-		s.Equal(ErrNoAccountSelected, err)
+func (s *ManagerTestSuite) TestOnSelectedAccountChanged() {
+	var notifications []string
+	s.accManager.OnSelectedAccountChanged(func(address string) {
+		notifications = append(notifications, address)
 	})
+	defer s.accManager.OnSelectedAccountChanged(nil)
 
-	// Now, select the test account for rest of the test cases.
-	s.reinitMock()
 	s.nodeManager.EXPECT().AccountKeyStore().Return(s.keyStore, nil).AnyTimes()
 	s.nodeManager.EXPECT().WhisperService().Return(s.shh, nil).AnyTimes()
-	err := s.accManager.SelectAccount(s.address, s.password)
+
+	// failed selection doesn't notify
+	err := s.accManager.SelectAccount(s.address, "wrong-password")
+	s.Error(err)
+	s.Len(notifications, 0)
+
+	err = s.accManager.SelectAccount(s.address, s.password)
 	s.NoError(err)
+	s.Equal([]string{s.address}, notifications)
 
-	testCases := []struct {
-		name                  string
-		address               string
-		password              string
-		accountKeyStoreReturn []interface{}
-		expectedError         error
-	}{
-		{
-			"success",
-			s.address,
-			s.password,
-			[]interface{}{s.keyStore, nil},
-			nil,
-		},
-		{
-			"fail_keyStore",
-			s.address,
-			s.password,
-			[]interface{}{nil, errKeyStore},
-			errKeyStore,
-		},
-		{
-			"fail_wrongAddress",
-			"wrong-address",
-			s.password,
-			[]interface{}{s.keyStore, nil},
-			ErrAddressToAccountMappingFailure,
-		},
-		{
-			"fail_wrongPassword",
-			s.address,
-			"wrong-password",
-			[]interface{}{s.keyStore, nil},
-			errors.New("cannot retrieve a valid key for a given account: could not decrypt key with given passphrase"),
-		},
-	}
+	err = s.accManager.SelectAccount(s.address, s.password)
+	s.NoError(err)
+	s.Equal([]string{s.address, s.address}, notifications)
 
-	for _, testCase := range testCases {
-		s.T().Run(testCase.name, func(t *testing.T) {
-			s.reinitMock()
-			s.nodeManager.EXPECT().AccountKeyStore().Return(testCase.accountKeyStoreReturn...).AnyTimes()
-			childAddr, childPubKey, err := s.accManager.CreateChildAccount(testCase.address, testCase.password)
-			if testCase.expectedError != nil {
-				s.Equal(testCase.expectedError, err)
-			} else {
-				s.NoError(err)
-				s.NotEmpty(childAddr)
-				s.NotEmpty(childPubKey)
-			}
-		})
-	}
+	err = s.accManager.Logout()
+	s.NoError(err)
+	s.Equal([]string{s.address, s.address, ""}, notifications)
 }
 
-func (s *ManagerTestSuite) TestSelectedAndReSelectAccount() {
-	// Select the test account
+func (s *ManagerTestSuite) TestSelectedAccountInfo() {
 	s.nodeManager.EXPECT().AccountKeyStore().Return(s.keyStore, nil).AnyTimes()
 	s.nodeManager.EXPECT().WhisperService().Return(s.shh, nil).AnyTimes()
-	err := s.accManager.SelectAccount(s.address, s.password)
-	s.NoError(err)
 
-	s.T().Run("success", func(t *testing.T) {
-		acc, err := s.accManager.SelectedAccount()
-		s.NoError(err)
-		s.NotNil(acc)
+	_, _, err := s.accManager.SelectedAccountInfo()
+	s.Equal(ErrNoAccountSelected, err)
 
-		err = s.accManager.ReSelectAccount()
-		s.NoError(err)
-	})
+	s.NoError(s.accManager.SelectAccount(s.address, s.password))
+	address, pubKey, err := s.accManager.SelectedAccountInfo()
+	s.NoError(err)
+	s.Equal(s.address, address)
+	s.Equal(s.pubKey, pubKey)
 
-	s.T().Run("ReSelect_fail_whisper", func(t *testing.T) {
-		s.reinitMock()
-		s.nodeManager.EXPECT().WhisperService().Return(nil, errWhisper).AnyTimes()
-		err = s.accManager.ReSelectAccount()
-		s.Equal(errWhisper, err)
-	})
+	s.NoError(s.accManager.Logout())
+	_, _, err = s.accManager.SelectedAccountInfo()
+	s.Equal(ErrNoAccountSelected, err)
+}
 
-	s.accManager.selectedAccount = nil
-	s.reinitMock()
+// TestConcurrentSelectAccount tests concurrent SelectAccount/Logout calls,
+// supposed to be run with '-race' flag.
+func (s *ManagerTestSuite) TestConcurrentSelectAccount() {
 	s.nodeManager.EXPECT().AccountKeyStore().Return(s.keyStore, nil).AnyTimes()
 	s.nodeManager.EXPECT().WhisperService().Return(s.shh, nil).AnyTimes()
+	s.nodeManager.EXPECT().AccountManager().Return(s.gethAccManager, nil).AnyTimes()
 
-	s.T().Run("Selected_fail_noAccount", func(t *testing.T) {
-		_, err := s.accManager.SelectedAccount()
-		s.Equal(ErrNoAccountSelected, err)
-	})
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			s.NoError(s.accManager.SelectAccount(s.address, s.password))
+			s.accManager.SelectedAccount() //nolint: errcheck
+		}()
+		go func() {
+			defer wg.Done()
+			s.NoError(s.accManager.Logout())
+			s.accManager.Accounts() //nolint: errcheck
+		}()
+	}
+	wg.Wait()
 
-	s.T().Run("ReSelect_success_noAccount", func(t *testing.T) {
-		err = s.accManager.ReSelectAccount()
-		s.NoError(err)
-	})
+	s.NoError(s.accManager.SelectAccount(s.address, s.password))
+	selectedAccount, err := s.accManager.SelectedAccount()
+	s.NoError(err)
+	s.Equal(s.address, selectedAccount.Address.Hex())
 }
 
-func (s *ManagerTestSuite) TestLogout() {
-	s.nodeManager.EXPECT().WhisperService().Return(s.shh, nil)
-	err := s.accManager.Logout()
-	s.NoError(err)
+// TestConcurrentCreateAccount tests concurrent CreateAccount/RecoverAccount calls,
+// supposed to be run with '-race' flag.
+func (s *ManagerTestSuite) TestConcurrentCreateAccount() {
+	s.nodeManager.EXPECT().AccountKeyStore().Return(s.keyStore, nil).AnyTimes()
 
-	s.nodeManager.EXPECT().WhisperService().Return(nil, errWhisper)
-	err = s.accManager.Logout()
-	s.Equal(errWhisper, err)
+	const n = 5
+	addresses := make([]string, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			address, _, _, err := s.accManager.CreateAccount(s.password)
+			s.NoError(err)
+			addresses[i] = address
+		}(i)
+		go func() {
+			defer wg.Done()
+			_, _, err := s.accManager.RecoverAccount(s.password, s.mnemonic)
+			s.NoError(err)
+		}()
+	}
+	wg.Wait()
+
+	created := make(map[string]bool)
+	for _, address := range addresses {
+		s.False(created[address], "duplicate account %s", address)
+		created[address] = true
+
+		s.True(s.keyStore.HasAddress(gethcommon.HexToAddress(address)))
+		_, key, err := s.accManager.AddressToDecryptedAccount(address, s.password)
+		s.NoError(err)
+		s.Equal(address, key.Address.Hex())
+	}
+
+	// the recovered account must be stored only once
+	var stored int
+	for _, account := range s.keyStore.Accounts() {
+		if account.Address.Hex() == s.address {
+			stored++
+		}
+	}
+	s.Equal(1, stored)
 }
 
 // TestAccounts tests cases for (*Manager).Accounts.
@@ -423,6 +2459,290 @@ func (s *ManagerTestSuite) TestAccounts() {
 	s.NotNil(accs)
 }
 
+func (s *ManagerTestSuite) TestSignerChainID() {
+	tx := types.NewTransaction(0, gethcommon.Address{}, big.NewInt(1), 21000, big.NewInt(1), nil)
+	s.nodeManager.EXPECT().AccountKeyStore().Return(s.keyStore, nil).AnyTimes()
+
+	// Transactions are signed for the chain the node runs on
+	for _, chainID := range []uint64{1, 777} {
+		s.nodeManager.EXPECT().ChainID().Return(chainID, nil)
+		signedTx, err := s.accManager.SignTransaction(tx, s.address, s.password)
+		s.NoError(err)
+		s.True(signedTx.Protected())
+		s.Equal(new(big.Int).SetUint64(chainID), signedTx.ChainId())
+
+		sender, err := types.Sender(types.NewEIP155Signer(signedTx.ChainId()), signedTx)
+		s.NoError(err)
+		s.Equal(gethcommon.HexToAddress(s.address), sender)
+	}
+
+	// A transaction signed for one chain can't be replayed on another
+	s.nodeManager.EXPECT().ChainID().Return(uint64(777), nil)
+	signedTx, err := s.accManager.SignTransaction(tx, s.address, s.password)
+	s.NoError(err)
+	_, err = types.Sender(types.NewEIP155Signer(big.NewInt(1)), signedTx)
+	s.Error(err)
+
+	// Can't get a chain ID
+	s.nodeManager.EXPECT().ChainID().Return(uint64(0), errChainID)
+	_, err = s.accManager.signer()
+	s.Equal(errChainID, err)
+}
+
+func (s *ManagerTestSuite) TestSignTransaction() {
+	tx := types.NewTransaction(0, gethcommon.Address{}, big.NewInt(1), 21000, big.NewInt(1), nil)
+
+	s.nodeManager.EXPECT().AccountKeyStore().Return(s.keyStore, nil).AnyTimes()
+	s.nodeManager.EXPECT().ChainID().Return(uint64(777), nil)
+	signedTx, err := s.accManager.SignTransaction(tx, s.address, s.password)
+	s.NoError(err)
+	s.Equal(big.NewInt(777), signedTx.ChainId())
+
+	sender, err := types.Sender(types.NewEIP155Signer(big.NewInt(777)), signedTx)
+	s.NoError(err)
+	s.Equal(gethcommon.HexToAddress(s.address), sender)
+
+	// Wrong password
+	_, err = s.accManager.SignTransaction(tx, s.address, "wrong-password")
+	s.Equal(keystore.ErrDecrypt, err)
+
+	// Can't get a chain ID
+	s.nodeManager.EXPECT().ChainID().Return(uint64(0), errChainID)
+	_, err = s.accManager.SignTransaction(tx, s.address, s.password)
+	s.Equal(errChainID, err)
+}
+
+func (s *ManagerTestSuite) TestSignMessage() {
+	data := []byte("hello world")
+
+	s.nodeManager.EXPECT().AccountKeyStore().Return(s.keyStore, nil).AnyTimes()
+	signature, err := s.accManager.SignMessage(data, s.address, s.password)
+	s.NoError(err)
+	s.Len(signature, 65)
+	s.Contains([]byte{27, 28}, signature[64])
+
+	// recover signer the same way personal_ecRecover does
+	sig := make([]byte, len(signature))
+	copy(sig, signature)
+	sig[64] -= 27
+	hash := crypto.Keccak256([]byte(fmt.Sprintf("\x19Ethereum Signed Message:\n%d%s", len(data), data)))
+	pubKey, err := crypto.SigToPub(hash, sig)
+	s.NoError(err)
+	s.Equal(gethcommon.HexToAddress(s.address), crypto.PubkeyToAddress(*pubKey))
+
+	// Wrong password
+	_, err = s.accManager.SignMessage(data, s.address, "wrong-password")
+	s.Equal(keystore.ErrDecrypt, err)
+}
+
+func (s *ManagerTestSuite) TestVerifySignature() {
+	data := []byte("hello world")
+
+	s.nodeManager.EXPECT().AccountKeyStore().Return(s.keyStore, nil).AnyTimes()
+	signature, err := s.accManager.SignMessage(data, s.address, s.password)
+	s.NoError(err)
+
+	// Signer, regardless of the address case
+	for _, address := range []string{s.address, strings.ToLower(s.address), strings.ToUpper(s.address[2:])} {
+		ok, err := VerifySignature(data, signature, address)
+		s.NoError(err)
+		s.True(ok, address)
+	}
+
+	// Different account
+	ok, err := VerifySignature(data, signature, "0x4f3E5D1Df8Ad36Dddadd453767540A6e4aD6C1b4")
+	s.NoError(err)
+	s.False(ok)
+
+	// Different data
+	ok, err = VerifySignature([]byte("hello"), signature, s.address)
+	s.NoError(err)
+	s.False(ok)
+
+	// Invalid address
+	_, err = VerifySignature(data, signature, "0x1")
+	s.Equal(ErrAddressToAccountMappingFailure, err)
+
+	// Malformed signatures
+	_, err = VerifySignature(data, signature[:64], s.address)
+	s.Equal(ErrInvalidSignature, err)
+
+	invalidV := append([]byte{}, signature...)
+	invalidV[64] = 1
+	_, err = VerifySignature(data, invalidV, s.address)
+	s.Equal(ErrInvalidSignature, err)
+}
+
+func (s *ManagerTestSuite) TestSignRPCHandlers() {
+	data := []byte("hello world")
+	dataHex := hexutil.Encode(data)
+	signHandler := s.accManager.SignRPCHandler()
+	personalSignHandler := s.accManager.PersonalSignRPCHandler()
+
+	// No account selected
+	s.accManager.selectedAccount = nil
+	_, err := signHandler(context.Background(), s.address, dataHex)
+	s.Equal(ErrNoAccountSelected, err)
+
+	s.nodeManager.EXPECT().AccountKeyStore().Return(s.keyStore, nil).AnyTimes()
+	s.nodeManager.EXPECT().WhisperService().Return(s.shh, nil).AnyTimes()
+	s.NoError(s.accManager.SelectAccount(s.address, s.password))
+
+	// eth_accounts returns the selected account (and its sub-accounts)
+	s.nodeManager.EXPECT().AccountManager().Return(accounts.NewManager(s.keyStore), nil)
+	addresses, err := s.accManager.AccountsRPCHandler()(context.Background())
+	s.NoError(err)
+	s.Contains(addresses, gethcommon.HexToAddress(s.address))
+
+	// eth_sign produces the same signature as SignMessage, using the selected account key
+	expected, err := s.accManager.SignMessage(data, s.address, s.password)
+	s.NoError(err)
+
+	signature, err := signHandler(context.Background(), s.address, dataHex)
+	s.NoError(err)
+	s.Equal(hexutil.Bytes(expected), signature)
+
+	// personal_sign takes arguments in reversed order
+	signature, err = personalSignHandler(context.Background(), dataHex, strings.ToLower(s.address))
+	s.NoError(err)
+	s.Equal(hexutil.Bytes(expected), signature)
+
+	// Other account
+	_, err = signHandler(context.Background(), "0x0000000000000000000000000000000000000001", dataHex)
+	s.Equal(ErrNotSelectedAccount, err)
+
+	// Invalid parameters
+	_, err = signHandler(context.Background(), s.address)
+	s.Equal(ErrInvalidSignParams, err)
+	_, err = signHandler(context.Background(), s.address, "not hex")
+	s.Equal(ErrInvalidSignParams, err)
+	_, err = signHandler(context.Background(), "not an address", dataHex)
+	s.Equal(ErrInvalidSignParams, err)
+}
+
+func (s *ManagerTestSuite) TestUpgradeKeystoreSecurity() {
+	keyStoreDir, err := ioutil.TempDir(os.TempDir(), "accounts")
+	s.NoError(err)
+	defer os.RemoveAll(keyStoreDir) //nolint: errcheck
+
+	keyStore := keystore.NewKeyStore(keyStoreDir, keystore.LightScryptN, keystore.LightScryptP)
+	account1, err := keyStore.NewAccount(s.password)
+	s.NoError(err)
+	account2, err := keyStore.NewAccount(s.password)
+	s.NoError(err)
+	otherAccount, err := keyStore.NewAccount("other-password")
+	s.NoError(err)
+
+	const scryptN, scryptP = 1 << 14, 2
+
+	s.nodeManager.EXPECT().AccountKeyStore().Return(keyStore, nil)
+	migrated, err := s.accManager.UpgradeKeystoreSecurity(s.password, scryptN, scryptP)
+	s.NoError(err)
+	s.Equal(2, migrated)
+
+	kdfParams := func(path string) (int, int) {
+		keyJSON, err := ioutil.ReadFile(path)
+		s.NoError(err)
+
+		var k struct {
+			Crypto struct {
+				KDFParams struct {
+					N int `json:"n"`
+					P int `json:"p"`
+				} `json:"kdfparams"`
+			} `json:"crypto"`
+		}
+		s.NoError(json.Unmarshal(keyJSON, &k))
+		return k.Crypto.KDFParams.N, k.Crypto.KDFParams.P
+	}
+
+	for _, account := range []accounts.Account{account1, account2} {
+		n, p := kdfParams(account.URL.Path)
+		s.Equal(scryptN, n)
+		s.Equal(scryptP, p)
+
+		_, _, err = keyStore.AccountDecryptedKey(account, s.password)
+		s.NoError(err)
+	}
+
+	// account protected with another password is left intact
+	n, p := kdfParams(otherAccount.URL.Path)
+	s.Equal(keystore.LightScryptN, n)
+	s.Equal(keystore.LightScryptP, p)
+
+	// Can't get a key store
+	s.nodeManager.EXPECT().AccountKeyStore().Return(nil, errKeyStore)
+	_, err = s.accManager.UpgradeKeystoreSecurity(s.password, scryptN, scryptP)
+	s.Equal(errKeyStore, err)
+}
+
+func (s *ManagerTestSuite) TestUpgradeKeystoreSecurityWithProgress() {
+	keyStoreDir, err := ioutil.TempDir(os.TempDir(), "accounts")
+	s.NoError(err)
+	defer os.RemoveAll(keyStoreDir) //nolint: errcheck
+
+	type call struct{ done, total int }
+	var calls []call
+	progress := func(done, total int) {
+		calls = append(calls, call{done, total})
+	}
+
+	// Empty key store reports completion once
+	keyStore := keystore.NewKeyStore(keyStoreDir, keystore.LightScryptN, keystore.LightScryptP)
+	s.nodeManager.EXPECT().AccountKeyStore().Return(keyStore, nil)
+	migrated, err := s.accManager.UpgradeKeystoreSecurityWithProgress(s.password, keystore.LightScryptN, keystore.LightScryptP, progress)
+	s.NoError(err)
+	s.Equal(0, migrated)
+	s.Equal([]call{{0, 0}}, calls)
+
+	const n = 3
+	for i := 0; i < n; i++ {
+		_, err := keyStore.NewAccount(s.password)
+		s.NoError(err)
+	}
+	_, err = keyStore.NewAccount("other-password")
+	s.NoError(err)
+
+	// Skipped keys are reported too
+	calls = nil
+	s.nodeManager.EXPECT().AccountKeyStore().Return(keyStore, nil)
+	migrated, err = s.accManager.UpgradeKeystoreSecurityWithProgress(s.password, keystore.LightScryptN, keystore.LightScryptP, progress)
+	s.NoError(err)
+	s.Equal(n, migrated)
+	s.Require().Len(calls, n+1)
+	for i, c := range calls {
+		s.Equal(call{i + 1, n + 1}, c)
+	}
+}
+
+func (s *ManagerTestSuite) TestKeyStoreAccounts() {
+	keyStoreDir, err := ioutil.TempDir(os.TempDir(), "accounts")
+	s.NoError(err)
+	defer os.RemoveAll(keyStoreDir) //nolint: errcheck
+
+	s.NoError(common.ImportTestAccount(keyStoreDir, GetAccount1PKFile()))
+	s.NoError(common.ImportTestAccount(keyStoreDir, GetAccount2PKFile()))
+	keyStore := keystore.NewKeyStore(keyStoreDir, keystore.LightScryptN, keystore.LightScryptP)
+
+	expected := []gethcommon.Address{
+		gethcommon.HexToAddress(TestConfig.Account1.Address),
+		gethcommon.HexToAddress(TestConfig.Account2.Address),
+	}
+	if bytes.Compare(expected[0][:], expected[1][:]) > 0 {
+		expected[0], expected[1] = expected[1], expected[0]
+	}
+
+	s.nodeManager.EXPECT().AccountKeyStore().Return(keyStore, nil)
+	addresses, err := s.accManager.KeyStoreAccounts()
+	s.NoError(err)
+	s.Equal(expected, addresses)
+
+	// Can't get a key store
+	s.nodeManager.EXPECT().AccountKeyStore().Return(nil, errKeyStore)
+	_, err = s.accManager.KeyStoreAccounts()
+	s.Equal(errKeyStore, err)
+}
+
 func (s *ManagerTestSuite) TestAddressToDecryptedAccount() {
 	testCases := []struct {
 		name                  string
@@ -477,3 +2797,54 @@ func (s *ManagerTestSuite) TestAddressToDecryptedAccount() {
 		})
 	}
 }
+
+func (s *ManagerTestSuite) TestPendingNonce() {
+	client, err := rpc.NewClient(nil, params.UpstreamRPCConfig{})
+	s.NoError(err)
+	client.RegisterHandler("eth_getTransactionCount", func(ctx context.Context, args ...interface{}) (interface{}, error) {
+		s.Equal(s.address, args[0].(gethcommon.Address).Hex())
+		s.Equal("pending", args[1])
+		return hexutil.Uint64(5), nil
+	})
+	s.nodeManager.EXPECT().RPCClient().Return(client).AnyTimes()
+
+	nonce, err := s.accManager.PendingNonce(s.address)
+	s.NoError(err)
+	s.Equal(uint64(5), nonce)
+
+	// nonce of transactions sent locally is used if it's higher
+	nonces := &fakeNonceSource{nonces: map[gethcommon.Address]uint64{
+		gethcommon.HexToAddress(s.address): 7,
+	}}
+	s.accManager.SetNonceSource(nonces)
+	defer s.accManager.SetNonceSource(nil)
+	nonce, err = s.accManager.PendingNonce(s.address)
+	s.NoError(err)
+	s.Equal(uint64(7), nonce)
+
+	// nonce of the node is used if it's higher
+	nonces.nonces[gethcommon.HexToAddress(s.address)] = 3
+	nonce, err = s.accManager.PendingNonce(s.address)
+	s.NoError(err)
+	s.Equal(uint64(5), nonce)
+
+	_, err = s.accManager.PendingNonce("0xinvalid")
+	s.Equal(ErrAddressToAccountMappingFailure, err)
+}
+
+// fakeNonceSource is a NonceSource returning nonces from a map.
+type fakeNonceSource struct {
+	nonces map[gethcommon.Address]uint64
+}
+
+func (f *fakeNonceSource) LocalNonce(address gethcommon.Address) (uint64, bool) {
+	nonce, ok := f.nonces[address]
+	return nonce, ok
+}
+
+func (s *ManagerTestSuite) TestPendingNonceWithoutRPCClient() {
+	s.nodeManager.EXPECT().RPCClient().Return(nil)
+
+	_, err := s.accManager.PendingNonce(s.address)
+	s.Equal(ErrNoRPCClient, err)
+}
@@ -0,0 +1,75 @@
+package account
+
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/status-im/status-go/geth/common"
+)
+
+// Errors describing the kind of failure of an account operation, to be checked with IsKind.
+// Errors returned by operations keep their messages, they're only marked with these ones.
+var (
+	// ErrAccountNotFound is matched when there's no key of an account in the keystore.
+	// It's an alias of keystore.ErrNoMatch, so errors of keystore match it as well.
+	ErrAccountNotFound = keystore.ErrNoMatch
+	// ErrWrongPassword is matched when the key of an account can't be decrypted with a given password.
+	// It's an alias of keystore.ErrDecrypt, so errors of keystore match it as well.
+	ErrWrongPassword = keystore.ErrDecrypt
+	// ErrKeyStoreUnavailable is matched when the keystore (or key store directory) can't be accessed.
+	ErrKeyStoreUnavailable = errors.New("keystore is not available")
+	// ErrWhisperUnavailable is matched when the Whisper service of the node can't be accessed.
+	// It's an alias of common.ErrWhisperServiceUnavailable the node manager reports.
+	ErrWhisperUnavailable = common.ErrWhisperServiceUnavailable
+)
+
+// kindError marks an underlying error with the kind of failure, keeping its message,
+// so that IsKind (as well as errors.Is) matches both the kind and the underlying error.
+type kindError struct {
+	kind error
+	err  error
+}
+
+// withKind marks err with a given kind, nil is returned if err is nil.
+func withKind(kind, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &kindError{kind: kind, err: err}
+}
+
+func (e *kindError) Error() string {
+	return e.err.Error()
+}
+
+// Is reports whether the error is of a given kind.
+func (e *kindError) Is(target error) bool {
+	return target == e.kind
+}
+
+// Unwrap returns the underlying error.
+func (e *kindError) Unwrap() error {
+	return e.err
+}
+
+// IsKind reports whether err is a given kind of failure, i.e. it's either
+// the kind itself or an error marked with it.
+func IsKind(err, kind error) bool {
+	for err != nil {
+		if err == kind {
+			return true
+		}
+
+		e, ok := err.(*kindError)
+		if !ok {
+			return false
+		}
+		if e.kind == kind {
+			return true
+		}
+		err = e.err
+	}
+
+	return false
+}
@@ -0,0 +1,346 @@
+package account
+
+import (
+	"crypto/ecdsa"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pborman/uuid"
+	"github.com/status-im/status-go/extkeys"
+)
+
+// newKeyFromMasterKey creates account key out of a master extended key, the same way
+// keystore does it on import: CKD#1 is used as account key, CKD#2 is stored as sub-account root.
+func newKeyFromMasterKey(extKey *extkeys.ExtendedKey) (*keystore.Key, error) {
+	// CKD#1 - main account
+	extChild1, err := extKey.BIP44Child(extkeys.CoinTypeETH, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	// CKD#2 - sub-accounts root
+	extChild2, err := extKey.BIP44Child(extkeys.CoinTypeETH, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	return newKeyFromChildKeys(extChild1, extChild2), nil
+}
+
+// newKeyFromExtendedKey creates account key out of an extended key, the same way keystore
+// does it on import: master key is handled by newKeyFromMasterKey, while any other key is
+// used both as account key and sub-account root.
+func newKeyFromExtendedKey(extKey *extkeys.ExtendedKey) (*keystore.Key, error) {
+	if extKey.Depth == 0 {
+		return newKeyFromMasterKey(extKey)
+	}
+
+	return newKeyFromChildKeys(extKey, extKey), nil
+}
+
+// newKeyFromChildKeys creates account key, with extChild2 stored as sub-account root.
+func newKeyFromChildKeys(extChild1, extChild2 *extkeys.ExtendedKey) *keystore.Key {
+	privateKey := extChild1.ToECDSA()
+	return &keystore.Key{
+		Id:          uuid.NewRandom(),
+		Address:     crypto.PubkeyToAddress(privateKey.PublicKey),
+		PrivateKey:  privateKey,
+		ExtendedKey: extChild2,
+	}
+}
+
+// newKeyFromECDSA creates plain account key, with no sub-account derivation support.
+func newKeyFromECDSA(privateKey *ecdsa.PrivateKey) *keystore.Key {
+	return &keystore.Key{
+		Id:         uuid.NewRandom(),
+		Address:    crypto.PubkeyToAddress(privateKey.PublicKey),
+		PrivateKey: privateKey,
+	}
+}
+
+// keyFileName implements the naming convention for key files used by keystore:
+// UTC--<created_at UTC ISO8601>--<address hex>
+func keyFileName(address gethcommon.Address) string {
+	ts := time.Now().UTC()
+	return fmt.Sprintf("UTC--%04d-%02d-%02dT%02d-%02d-%02d.%09dZ--%s",
+		ts.Year(), ts.Month(), ts.Day(), ts.Hour(), ts.Minute(), ts.Second(), ts.Nanosecond(),
+		hex.EncodeToString(address[:]))
+}
+
+// IntegrityIssue describes a problem found with a key file in the key store directory.
+type IntegrityIssue struct {
+	Path    string
+	Address gethcommon.Address // address stored in key file, zero if file can not be parsed
+	Err     error
+}
+
+// isIgnoredKeyFile returns true for files keystore doesn't treat as keys (hidden, temporary, README).
+func isIgnoredKeyFile(name string) bool {
+	return strings.HasPrefix(name, ".") || strings.HasSuffix(name, "~") || name == "README"
+}
+
+// checkKeyFile parses key file at a given path and makes sure that its name
+// ends with the address stored within.
+func checkKeyFile(path string) *IntegrityIssue {
+	rawKeyFile, err := ioutil.ReadFile(path)
+	if err != nil {
+		return &IntegrityIssue{Path: path, Err: err}
+	}
+
+	var accountKey struct {
+		Address string `json:"address"`
+	}
+	if err := json.Unmarshal(rawKeyFile, &accountKey); err != nil || !gethcommon.IsHexAddress(accountKey.Address) {
+		return &IntegrityIssue{Path: path, Err: ErrKeyFileMalformed}
+	}
+
+	address := gethcommon.HexToAddress(accountKey.Address)
+	if !strings.HasSuffix(strings.ToLower(filepath.Base(path)), "--"+hex.EncodeToString(address[:])) {
+		return &IntegrityIssue{Path: path, Address: address, Err: ErrKeyFileNameMismatch}
+	}
+
+	return nil
+}
+
+// walkKeyFiles calls fn for every file within key store directory tree, except the ones keystore
+// ignores (see isIgnoredKeyFile). If key store directory itself is a symlink, it is followed.
+// Symlinked sub-directories are not descended into, to avoid loops. Files removed while the
+// directory is walked (e.g. temporary files of atomic writes) are skipped.
+func walkKeyFiles(keyStoreDir string, fn func(path string, fileInfo os.FileInfo) error) error {
+	root, err := filepath.EvalSymlinks(keyStoreDir)
+	if err != nil {
+		return err
+	}
+
+	return filepath.Walk(root, func(path string, fileInfo os.FileInfo, err error) error {
+		if err != nil {
+			if path != root && os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if fileInfo.IsDir() || isIgnoredKeyFile(fileInfo.Name()) {
+			return nil
+		}
+		if fileInfo.Mode()&os.ModeSymlink != 0 {
+			if target, err := os.Stat(path); err == nil && target.IsDir() {
+				return nil
+			}
+		}
+		return fn(path, fileInfo)
+	})
+}
+
+// keyFileIndex maps account addresses to key file paths, per key store directory.
+// Directory is indexed on first lookup, and the index is kept until it's invalidated:
+// Manager does it whenever it writes or deletes a key file, or fails to read an indexed one.
+// Key files written outside of the Manager are found once the directory is re-indexed
+// on a miss, as its modification time changes.
+type keyFileIndex struct {
+	mu   sync.RWMutex
+	dirs map[string]*keyFileDirIndex
+}
+
+// keyFileDirIndex maps account addresses to key file paths within a single key store directory.
+type keyFileDirIndex struct {
+	files    map[gethcommon.Address]string
+	modTimes map[string]time.Time // modification times of indexed directories, taken before they were read
+	scryptN  int                  // the highest scrypt N of indexed key files, 0 if there's none
+	scryptP  int                  // scrypt P of the key file of the highest N
+}
+
+// changed returns true if any of the indexed directories has been modified since it was indexed.
+func (di *keyFileDirIndex) changed() bool {
+	for dir, modTime := range di.modTimes {
+		fileInfo, err := os.Stat(dir)
+		if err != nil || !fileInfo.ModTime().Equal(modTime) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// lookup returns path of the key file for a given address, or empty string if there's no such file.
+// Key store directory is read if it's not indexed yet, or if an unknown address is looked up and
+// the directory has been modified since it was indexed, e.g. by keystore of the node.
+func (idx *keyFileIndex) lookup(keyStoreDir string, address gethcommon.Address) (string, error) {
+	idx.mu.RLock()
+	dirIndex, ok := idx.dirs[keyStoreDir]
+	idx.mu.RUnlock()
+	if ok {
+		if path, found := dirIndex.files[address]; found || !dirIndex.changed() {
+			return path, nil
+		}
+	}
+
+	dirIndex, err := indexKeyFiles(keyStoreDir)
+	if err != nil {
+		return "", err
+	}
+
+	idx.mu.Lock()
+	if idx.dirs == nil {
+		idx.dirs = make(map[string]*keyFileDirIndex)
+	}
+	idx.dirs[keyStoreDir] = dirIndex
+	idx.mu.Unlock()
+
+	// decoy key of the directory is generated while indexing, for both known and unknown addresses
+	decoyKeyJSON(dirIndex.decoyParams())
+
+	return dirIndex.files[address], nil
+}
+
+// decoyParams returns scrypt params a decoy key of the directory is encrypted with: the highest
+// ones of its key files, so that decrypting the decoy never takes less time than decrypting a key
+// file does. Light params keystore of the node uses are returned if there are no key files.
+func (di *keyFileDirIndex) decoyParams() (scryptN, scryptP int) {
+	if di == nil || di.scryptN == 0 {
+		return keystore.LightScryptN, keystore.LightScryptP
+	}
+
+	return di.scryptN, di.scryptP
+}
+
+// decoyParams returns scrypt params of the decoy key for a given key store directory, see
+// keyFileDirIndex.decoyParams.
+func (idx *keyFileIndex) decoyParams(keyStoreDir string) (scryptN, scryptP int) {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	return idx.dirs[keyStoreDir].decoyParams()
+}
+
+// invalidate drops all indexed directories, so that they are re-indexed on next lookup.
+func (idx *keyFileIndex) invalidate() {
+	idx.mu.Lock()
+	idx.dirs = nil
+	idx.mu.Unlock()
+}
+
+// decryptKey decrypts key JSON while a password is verified,
+// tests replace it to count decryptions.
+var decryptKey = keystore.DecryptKey
+
+var (
+	decoyKeysMu sync.Mutex
+	decoyKeys   = make(map[[2]int][]byte) // scrypt N and P to decoy key JSON
+)
+
+// decoyKeyJSON returns key JSON of a random key, encrypted with a random password and given
+// scrypt params, so that decrypting it takes as long as decrypting a key file of the same
+// params does. It's generated once per params, when it's needed for the first time,
+// nil is returned if it can't be generated.
+func decoyKeyJSON(scryptN, scryptP int) []byte {
+	decoyKeysMu.Lock()
+	defer decoyKeysMu.Unlock()
+
+	params := [2]int{scryptN, scryptP}
+	if keyJSON, ok := decoyKeys[params]; ok {
+		return keyJSON
+	}
+
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		return nil
+	}
+
+	keyJSON, err := keystore.EncryptKey(newKeyFromECDSA(privateKey), uuid.New(), scryptN, scryptP)
+	if err != nil {
+		return nil
+	}
+	decoyKeys[params] = keyJSON
+
+	return keyJSON
+}
+
+// indexKeyFiles reads all key files within key store directory, and maps addresses to their paths.
+// Files which can't be read or parsed as keys are skipped, so they never fail a lookup.
+// Modification times of the directory and sub-directories with key files are recorded as well,
+// along with the highest scrypt params of the key files.
+func indexKeyFiles(keyStoreDir string) (*keyFileDirIndex, error) {
+	dirIndex := &keyFileDirIndex{}
+	files := make(map[gethcommon.Address]string)
+	modTimes := make(map[string]time.Time)
+	recordModTime := func(dir string) {
+		if _, ok := modTimes[dir]; ok {
+			return
+		}
+		if dirInfo, err := os.Stat(dir); err == nil {
+			modTimes[dir] = dirInfo.ModTime()
+		}
+	}
+
+	recordModTime(keyStoreDir)
+	err := walkKeyFiles(keyStoreDir, func(path string, fileInfo os.FileInfo) error {
+		recordModTime(filepath.Dir(path))
+
+		// files which can't be read or aren't keys are skipped, the way keystore does it
+		rawKeyFile, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil
+		}
+
+		var accountKey struct {
+			Address string `json:"address"`
+			Crypto  struct {
+				KDFParams struct {
+					N int `json:"n"`
+					P int `json:"p"`
+				} `json:"kdfparams"`
+			} `json:"crypto"`
+		}
+		if err := json.Unmarshal(rawKeyFile, &accountKey); err != nil || !gethcommon.IsHexAddress(accountKey.Address) {
+			return nil
+		}
+
+		address := gethcommon.HexToAddress(accountKey.Address)
+		if _, exists := files[address]; !exists {
+			files[address] = path
+		}
+		if kdfParams := accountKey.Crypto.KDFParams; kdfParams.N > dirIndex.scryptN && kdfParams.P > 0 {
+			dirIndex.scryptN, dirIndex.scryptP = kdfParams.N, kdfParams.P
+		}
+		return nil
+	})
+
+	dirIndex.files = files
+	dirIndex.modTimes = modTimes
+	return dirIndex, err
+}
+
+// writeKeyFile atomically writes key file content: temporary file is created first,
+// and then moved into place.
+func writeKeyFile(path string, content []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+
+	f, err := ioutil.TempFile(filepath.Dir(path), "."+filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.Write(content); err != nil {
+		f.Close()           //nolint: errcheck
+		os.Remove(f.Name()) //nolint: errcheck
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), path)
+}
@@ -0,0 +1,387 @@
+package account
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+// ErrAccountExists is returned by KeyStore.StoreKey if a key of the same address is already stored.
+// It's an alias of keystore.ErrAccountAlreadyExists, so errors of keystore match it as well.
+var ErrAccountExists = keystore.ErrAccountAlreadyExists
+
+// KeyStore is a storage of account keys used by Manager. Keys are stored encrypted,
+// the password they were stored with is required to get or delete them.
+type KeyStore interface {
+	// GetKey returns decrypted key of an account with a given address.
+	GetKey(address gethcommon.Address, password string) (*keystore.Key, error)
+
+	// StoreKey stores a new key encrypted with a given password,
+	// ErrAccountExists is returned if the account is already stored.
+	StoreKey(key *keystore.Key, password string) error
+
+	// ImportKey stores a new key already encrypted into keyJSON (web3 secret storage),
+	// keeping its encryption. ErrAccountExists is returned if the account is already stored.
+	ImportKey(keyJSON []byte, password string) error
+
+	// ExportKey returns key of an account with a given address as web3 secret storage JSON,
+	// encrypted with the same password.
+	ExportKey(address gethcommon.Address, password string) ([]byte, error)
+
+	// UpdateKey re-encrypts key of an account with a given address with a new password, using
+	// given scrypt params, or the ones of the store if they're zero. keystore.ErrDecrypt is
+	// returned, and the key is left intact, if password is wrong.
+	UpdateKey(address gethcommon.Address, password, newPassword string, scryptN, scryptP int) error
+
+	// IncSubAccountIndex increments index of the next sub-account derived from key of an account
+	// with a given address, if password is correct.
+	IncSubAccountIndex(address gethcommon.Address, password string) error
+
+	// DeleteKey removes key of an account with a given address, if password is correct.
+	DeleteKey(address gethcommon.Address, password string) error
+
+	// Accounts returns addresses of all stored accounts.
+	Accounts() ([]gethcommon.Address, error)
+}
+
+// FileKeyStore is a KeyStore keeping keys in key files, it is backed by keystore of the running node.
+type FileKeyStore struct {
+	keyStore *keystore.KeyStore
+}
+
+// NewFileKeyStore returns a KeyStore backed by a given keystore.
+func NewFileKeyStore(keyStore *keystore.KeyStore) *FileKeyStore {
+	return &FileKeyStore{keyStore: keyStore}
+}
+
+// GetKey returns decrypted key of an account with a given address.
+func (s *FileKeyStore) GetKey(address gethcommon.Address, password string) (*keystore.Key, error) {
+	_, key, err := s.keyStore.AccountDecryptedKey(accounts.Account{Address: address}, password)
+	return key, err
+}
+
+// StoreKey creates key file of a new account, encrypted with scrypt params of the keystore.
+func (s *FileKeyStore) StoreKey(key *keystore.Key, password string) error {
+	_, err := s.keyStore.StoreKey(key, password)
+	return err
+}
+
+// ImportKey creates key file of a new account, with the content of keyJSON.
+func (s *FileKeyStore) ImportKey(keyJSON []byte, password string) error {
+	_, err := s.keyStore.ImportKeyJSON(keyJSON, password)
+	return err
+}
+
+// ExportKey returns key of an account with a given address, encrypted with scrypt params of the keystore.
+func (s *FileKeyStore) ExportKey(address gethcommon.Address, password string) ([]byte, error) {
+	return s.keyStore.Export(accounts.Account{Address: address}, password, password)
+}
+
+// UpdateKey re-encrypts key file of an account with a given address in place.
+func (s *FileKeyStore) UpdateKey(address gethcommon.Address, password, newPassword string, scryptN, scryptP int) error {
+	account := accounts.Account{Address: address}
+	if scryptN == 0 && scryptP == 0 {
+		return s.keyStore.Update(account, password, newPassword)
+	}
+
+	account, err := s.keyStore.Find(account)
+	if err != nil {
+		return err
+	}
+
+	return reEncryptKeyFile(account.URL.Path, password, newPassword, scryptN, scryptP)
+}
+
+// reEncryptKeyFile re-encrypts a key file with a new password, using provided scrypt parameters.
+// keystore.ErrDecrypt is returned if the key is protected with another password.
+func reEncryptKeyFile(path, password, newPassword string, scryptN, scryptP int) error {
+	keyJSON, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	key, err := keystore.DecryptKey(keyJSON, password)
+	if err != nil {
+		return keystore.ErrDecrypt
+	}
+
+	keyJSON, err = keystore.EncryptKey(key, newPassword, scryptN, scryptP)
+	zeroKey(key)
+	if err != nil {
+		return err
+	}
+
+	return writeKeyFile(path, keyJSON)
+}
+
+// IncSubAccountIndex updates key file of an account with a given address in place.
+func (s *FileKeyStore) IncSubAccountIndex(address gethcommon.Address, password string) error {
+	return s.keyStore.IncSubAccountIndex(accounts.Account{Address: address}, password)
+}
+
+// DeleteKey removes key file of an account with a given address.
+func (s *FileKeyStore) DeleteKey(address gethcommon.Address, password string) error {
+	account, err := s.keyStore.Find(accounts.Account{Address: address})
+	if err != nil {
+		return fmt.Errorf("cannot locate account for address: %s", address.Hex())
+	}
+
+	return s.keyStore.Delete(account, password)
+}
+
+// keyFileDir returns directory of the key file of an account with a given address.
+func (s *FileKeyStore) keyFileDir(address gethcommon.Address) (string, error) {
+	account, err := s.keyStore.Find(accounts.Account{Address: address})
+	if err != nil {
+		return "", fmt.Errorf("cannot locate account for address: %s", address.Hex())
+	}
+
+	return filepath.Dir(account.URL.Path), nil
+}
+
+// Accounts returns addresses of all accounts having key files.
+func (s *FileKeyStore) Accounts() ([]gethcommon.Address, error) {
+	keyStoreAccounts := s.keyStore.Accounts()
+	addresses := make([]gethcommon.Address, 0, len(keyStoreAccounts))
+	for _, account := range keyStoreAccounts {
+		addresses = append(addresses, account.Address)
+	}
+
+	return addresses, nil
+}
+
+// MemoryKeyStore is a KeyStore keeping keys in memory, encrypted the same way key files are.
+// It's meant for tests and ephemeral sessions, as keys are lost once it's discarded.
+type MemoryKeyStore struct {
+	mu      sync.RWMutex
+	keys    map[gethcommon.Address][]byte // address to encrypted key JSON
+	scryptN int
+	scryptP int
+}
+
+// NewMemoryKeyStore returns an empty MemoryKeyStore, encrypting keys with light scrypt parameters.
+func NewMemoryKeyStore() *MemoryKeyStore {
+	return NewMemoryKeyStoreWithParams(keystore.LightScryptN, keystore.LightScryptP)
+}
+
+// NewMemoryKeyStoreWithParams returns an empty MemoryKeyStore, encrypting keys with given scrypt parameters.
+func NewMemoryKeyStoreWithParams(scryptN, scryptP int) *MemoryKeyStore {
+	return &MemoryKeyStore{
+		keys:    make(map[gethcommon.Address][]byte),
+		scryptN: scryptN,
+		scryptP: scryptP,
+	}
+}
+
+// GetKey returns decrypted key of an account with a given address.
+func (s *MemoryKeyStore) GetKey(address gethcommon.Address, password string) (*keystore.Key, error) {
+	s.mu.RLock()
+	keyJSON, ok := s.keys[address]
+	s.mu.RUnlock()
+
+	if !ok {
+		return nil, keystore.ErrNoMatch
+	}
+
+	return keystore.DecryptKey(keyJSON, password)
+}
+
+// StoreKey encrypts and stores key of a new account.
+func (s *MemoryKeyStore) StoreKey(key *keystore.Key, password string) error {
+	keyJSON, err := keystore.EncryptKey(key, password, s.scryptN, s.scryptP)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.keys[key.Address]; ok {
+		return ErrAccountExists
+	}
+	s.keys[key.Address] = keyJSON
+
+	return nil
+}
+
+// ImportKey stores key of a new account as is.
+func (s *MemoryKeyStore) ImportKey(keyJSON []byte, password string) error {
+	key, err := keystore.DecryptKey(keyJSON, password)
+	if err != nil {
+		return err
+	}
+	zeroKey(key)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.keys[key.Address]; ok {
+		return ErrAccountExists
+	}
+	s.keys[key.Address] = append([]byte(nil), keyJSON...)
+
+	return nil
+}
+
+// ExportKey returns key of an account with a given address as it's stored.
+func (s *MemoryKeyStore) ExportKey(address gethcommon.Address, password string) ([]byte, error) {
+	s.mu.RLock()
+	keyJSON, ok := s.keys[address]
+	s.mu.RUnlock()
+
+	if !ok {
+		return nil, keystore.ErrNoMatch
+	}
+
+	key, err := keystore.DecryptKey(keyJSON, password)
+	if err != nil {
+		return nil, err
+	}
+	zeroKey(key)
+
+	return append([]byte(nil), keyJSON...), nil
+}
+
+// UpdateKey re-encrypts key of an account with a given address.
+func (s *MemoryKeyStore) UpdateKey(address gethcommon.Address, password, newPassword string, scryptN, scryptP int) error {
+	if scryptN == 0 && scryptP == 0 {
+		scryptN, scryptP = s.scryptN, s.scryptP
+	}
+
+	return s.updateKey(address, password, func(key *keystore.Key) ([]byte, error) {
+		return keystore.EncryptKey(key, newPassword, scryptN, scryptP)
+	})
+}
+
+// IncSubAccountIndex updates key of an account with a given address.
+func (s *MemoryKeyStore) IncSubAccountIndex(address gethcommon.Address, password string) error {
+	return s.updateKey(address, password, func(key *keystore.Key) ([]byte, error) {
+		key.SubAccountIndex++
+		return keystore.EncryptKey(key, password, s.scryptN, s.scryptP)
+	})
+}
+
+// updateKey replaces key of an account with a given address with the one
+// encrypted by a given function, once it's decrypted with a given password.
+func (s *MemoryKeyStore) updateKey(address gethcommon.Address, password string,
+	encrypt func(key *keystore.Key) ([]byte, error)) error {
+	s.mu.RLock()
+	keyJSON, ok := s.keys[address]
+	s.mu.RUnlock()
+
+	if !ok {
+		return keystore.ErrNoMatch
+	}
+
+	key, err := keystore.DecryptKey(keyJSON, password)
+	if err != nil {
+		return err
+	}
+	defer zeroKey(key)
+
+	keyJSON, err = encrypt(key)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// key may have been deleted in the meantime
+	if _, ok := s.keys[address]; !ok {
+		return keystore.ErrNoMatch
+	}
+	s.keys[address] = keyJSON
+
+	return nil
+}
+
+// DeleteKey removes key of an account with a given address.
+func (s *MemoryKeyStore) DeleteKey(address gethcommon.Address, password string) error {
+	s.mu.RLock()
+	keyJSON, ok := s.keys[address]
+	s.mu.RUnlock()
+
+	if !ok {
+		return fmt.Errorf("cannot locate account for address: %s", address.Hex())
+	}
+
+	if _, err := keystore.DecryptKey(keyJSON, password); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	delete(s.keys, address)
+	s.mu.Unlock()
+
+	return nil
+}
+
+// Accounts returns addresses of all stored accounts, in ascending order.
+func (s *MemoryKeyStore) Accounts() ([]gethcommon.Address, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	addresses := make([]gethcommon.Address, 0, len(s.keys))
+	for address := range s.keys {
+		addresses = append(addresses, address)
+	}
+	sort.Slice(addresses, func(i, j int) bool {
+		return bytes.Compare(addresses[i][:], addresses[j][:]) < 0
+	})
+
+	return addresses, nil
+}
+
+// SetKeyStore replaces the keystore of the running node as a storage of account keys,
+// used by all the operations on keys. Passing nil restores the default.
+func (m *Manager) SetKeyStore(keyStore KeyStore) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.keyStore = keyStore
+}
+
+// accountKeyStore returns the storage of account keys, set by SetKeyStore or the default one.
+func (m *Manager) accountKeyStore() (KeyStore, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.accountKeyStoreLocked()
+}
+
+// accountKeyStoreLocked is accountKeyStore for callers already holding the lock.
+func (m *Manager) accountKeyStoreLocked() (KeyStore, error) {
+	if m.keyStore != nil {
+		return m.keyStore, nil
+	}
+
+	nodeKeyStore, err := m.nodeManager.AccountKeyStore()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewFileKeyStore(nodeKeyStore), nil
+}
+
+// labelsDir returns directory account labels are stored in: the one key files are in,
+// or key store directory of the node if keys are not stored in files.
+func (m *Manager) labelsDir(keyStore KeyStore, address gethcommon.Address) (string, error) {
+	if fileKeyStore, ok := keyStore.(*FileKeyStore); ok {
+		return fileKeyStore.keyFileDir(address)
+	}
+
+	config, err := m.nodeManager.NodeConfig()
+	if err != nil {
+		return "", err
+	}
+
+	return config.KeyStoreDir, nil
+}
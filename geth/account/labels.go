@@ -0,0 +1,124 @@
+package account
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/status-im/status-go/geth/common"
+)
+
+// labelsFileName is the name of the file account labels are stored in, within
+// the key store directory. It's hidden, so that keystore doesn't treat it as a key.
+const labelsFileName = ".labels.json"
+
+// labelStore persists labels of accounts as a JSON object, mapping
+// checksummed addresses to labels.
+type labelStore struct {
+	mu sync.Mutex
+}
+
+func (s *labelStore) get(keyStoreDir, address string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	labels, err := readLabels(keyStoreDir)
+	if err != nil {
+		return "", false
+	}
+
+	label, ok := labels[address]
+	return label, ok
+}
+
+// set stores label of an account, empty label removes it.
+func (s *labelStore) set(keyStoreDir, address, label string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	labels, err := readLabels(keyStoreDir)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := labels[address]; !ok && label == "" {
+		return nil
+	}
+
+	if label == "" {
+		delete(labels, address)
+	} else {
+		labels[address] = label
+	}
+
+	content, err := json.Marshal(labels)
+	if err != nil {
+		return err
+	}
+
+	return writeKeyFile(filepath.Join(keyStoreDir, labelsFileName), content)
+}
+
+// readLabels reads labels stored in the key store directory, missing file means no labels.
+func readLabels(keyStoreDir string) (map[string]string, error) {
+	labels := make(map[string]string)
+
+	content, err := ioutil.ReadFile(filepath.Join(keyStoreDir, labelsFileName))
+	if os.IsNotExist(err) {
+		return labels, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(content, &labels); err != nil {
+		return nil, err
+	}
+
+	return labels, nil
+}
+
+// SetAccountLabel sets a human readable label (e.g. "Savings") of an account identified by
+// a given address. Labels are stored in the directory of the key file of the account (see labelsDir)
+// and removed along with the account by DeleteAccount. Passing an empty label removes it.
+func (m *Manager) SetAccountLabel(address, label string) error {
+	account, err := common.ParseAccountString(address)
+	if err != nil {
+		return ErrAddressToAccountMappingFailure
+	}
+
+	keyStore, err := m.accountKeyStore()
+	if err != nil {
+		return err
+	}
+
+	labelsDir, err := m.labelsDir(keyStore, account.Address)
+	if err != nil {
+		return err
+	}
+
+	return m.labels.set(labelsDir, account.Address.Hex(), label)
+}
+
+// GetAccountLabel returns label of an account identified by a given address,
+// and false if the account has no label.
+func (m *Manager) GetAccountLabel(address string) (string, bool) {
+	account, err := common.ParseAccountString(address)
+	if err != nil {
+		return "", false
+	}
+
+	keyStore, err := m.accountKeyStore()
+	if err != nil {
+		return "", false
+	}
+
+	labelsDir, err := m.labelsDir(keyStore, account.Address)
+	if err != nil {
+		return "", false
+	}
+
+	return m.labels.get(labelsDir, account.Address.Hex())
+}
@@ -0,0 +1,63 @@
+package account
+
+import (
+	"context"
+	"time"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// pendingNonceTimeout limits the time the node is queried for a pending nonce.
+const pendingNonceTimeout = 10 * time.Second
+
+// NonceSource reports nonces of transactions sent locally, which the node may not
+// include in its pending state yet. It's implemented by transactions.Manager.
+type NonceSource interface {
+	// LocalNonce returns the nonce the next transaction of the account is going to use,
+	// false is returned if no transaction of the account was sent locally.
+	LocalNonce(address gethcommon.Address) (uint64, bool)
+}
+
+// SetNonceSource sets the source of locally tracked nonces used by PendingNonce.
+// Passing nil makes PendingNonce rely on the node only.
+func (m *Manager) SetNonceSource(source NonceSource) {
+	m.mu.Lock()
+	m.nonceSource = source
+	m.mu.Unlock()
+}
+
+// PendingNonce returns the nonce the next transaction of the account should use.
+// It's the nonce of the pending state of the node, unless the nonce source set by
+// SetNonceSource tracks a higher one for transactions sent locally.
+func (m *Manager) PendingNonce(address string) (uint64, error) {
+	if !gethcommon.IsHexAddress(address) {
+		return 0, ErrAddressToAccountMappingFailure
+	}
+	account := gethcommon.HexToAddress(address)
+
+	client := m.nodeManager.RPCClient()
+	if client == nil {
+		return 0, ErrNoRPCClient
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), pendingNonceTimeout)
+	defer cancel()
+
+	var nonce hexutil.Uint64
+	if err := client.CallContext(ctx, &nonce, "eth_getTransactionCount", account, "pending"); err != nil {
+		return 0, err
+	}
+
+	m.mu.RLock()
+	source := m.nonceSource
+	m.mu.RUnlock()
+
+	if source != nil {
+		if localNonce, ok := source.LocalNonce(account); ok && localNonce > uint64(nonce) {
+			return localNonce, nil
+		}
+	}
+
+	return uint64(nonce), nil
+}
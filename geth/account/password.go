@@ -0,0 +1,87 @@
+package account
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// PasswordPolicy defines requirements a password must meet, zero value has no requirements.
+type PasswordPolicy struct {
+	MinLength      int  // minimum number of characters
+	RequireUpper   bool // at least one upper case letter
+	RequireLower   bool // at least one lower case letter
+	RequireDigit   bool // at least one digit
+	RequireSpecial bool // at least one character which is neither a letter nor a digit
+}
+
+// PasswordPolicyError is returned by ValidatePasswordStrength if a password doesn't meet
+// requirements of a policy. It lists the unmet ones, in the order they're defined in the policy.
+type PasswordPolicyError struct {
+	Unmet []string
+}
+
+func (e *PasswordPolicyError) Error() string {
+	return "password is too weak: " + strings.Join(e.Unmet, ", ")
+}
+
+// ValidatePasswordStrength checks that password meets all requirements of a given policy.
+// Otherwise, *PasswordPolicyError describing the unmet requirements is returned.
+func ValidatePasswordStrength(password string, policy PasswordPolicy) error {
+	var hasUpper, hasLower, hasDigit, hasSpecial bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case !unicode.IsLetter(r):
+			hasSpecial = true
+		}
+	}
+
+	var unmet []string
+	if utf8.RuneCountInString(password) < policy.MinLength {
+		unmet = append(unmet, fmt.Sprintf("at least %d characters", policy.MinLength))
+	}
+	if policy.RequireUpper && !hasUpper {
+		unmet = append(unmet, "an upper case letter")
+	}
+	if policy.RequireLower && !hasLower {
+		unmet = append(unmet, "a lower case letter")
+	}
+	if policy.RequireDigit && !hasDigit {
+		unmet = append(unmet, "a digit")
+	}
+	if policy.RequireSpecial && !hasSpecial {
+		unmet = append(unmet, "a special character")
+	}
+
+	if len(unmet) > 0 {
+		return &PasswordPolicyError{Unmet: unmet}
+	}
+
+	return nil
+}
+
+// SetPasswordPolicy sets the policy passwords of new accounts must meet, it's enforced by
+// CreateAccount and its variants (e.g. CreateAccountAt), which return *PasswordPolicyError
+// if a password is too weak. Passwords of recovered and imported accounts are not checked.
+// Zero policy, which is the default, disables the check.
+func (m *Manager) SetPasswordPolicy(policy PasswordPolicy) {
+	m.mu.Lock()
+	m.passwordPolicy = policy
+	m.mu.Unlock()
+}
+
+// checkPasswordPolicy validates password of a new account against the policy set by SetPasswordPolicy.
+func (m *Manager) checkPasswordPolicy(password string) error {
+	m.mu.RLock()
+	policy := m.passwordPolicy
+	m.mu.RUnlock()
+
+	return ValidatePasswordStrength(password, policy)
+}
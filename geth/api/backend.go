@@ -40,6 +40,7 @@ func NewStatusBackend() *StatusBackend {
 	nodeManager := node.NewNodeManager()
 	accountManager := account.NewManager(nodeManager)
 	txQueueManager := transactions.NewManager(nodeManager, accountManager)
+	accountManager.SetNonceSource(txQueueManager)
 	jailManager := jail.New(nodeManager)
 	notificationManager := fcm.NewNotification(fcmServerKey)
 
@@ -224,6 +225,8 @@ func (b *StatusBackend) registerHandlers() error {
 		return node.ErrRPCClient
 	}
 	rpcClient.RegisterHandler("eth_accounts", b.accountManager.AccountsRPCHandler())
+	rpcClient.RegisterHandler("eth_sign", b.accountManager.SignRPCHandler())
+	rpcClient.RegisterHandler("personal_sign", b.accountManager.PersonalSignRPCHandler())
 	rpcClient.RegisterHandler("eth_sendTransaction", b.txQueueManager.SendTransactionRPCHandler)
 	return nil
 }
@@ -24,7 +24,8 @@ import (
 
 // errors
 var (
-	ErrDeprecatedMethod = errors.New("Method is depricated and will be removed in future release")
+	ErrDeprecatedMethod          = errors.New("Method is depricated and will be removed in future release")
+	ErrWhisperServiceUnavailable = errors.New("whisper service is unavailable")
 )
 
 // SelectedExtKey is a container for currently selected (logged in) account
@@ -61,6 +62,9 @@ type NodeManager interface {
 	// NodeConfig returns reference to running node's configuration
 	NodeConfig() (*params.NodeConfig, error)
 
+	// ChainID returns chain ID of the network running node is connected to
+	ChainID() (uint64, error)
+
 	// Node returns underlying Status node
 	Node() (*node.Node, error)
 
@@ -76,9 +80,13 @@ type NodeManager interface {
 	// LightEthereumService exposes reference to LES service running on top of the node
 	LightEthereumService() (*les.LightEthereum, error)
 
-	// WhisperService returns reference to running Whisper service
+	// WhisperService returns reference to running Whisper service,
+	// or ErrWhisperServiceUnavailable if it's not running on the node
 	WhisperService() (*whisper.Whisper, error)
 
+	// IsWhisperEnabled returns true if Whisper service is running on the node
+	IsWhisperEnabled() bool
+
 	// AccountManager returns reference to node's account manager
 	AccountManager() (*accounts.Manager, error)
 
@@ -130,6 +138,12 @@ type AccountManager interface {
 	// AccountsRPCHandler returns RPC wrapper for Accounts()
 	AccountsRPCHandler() rpc.Handler
 
+	// SignRPCHandler returns RPC handler for eth_sign, signing with the selected account
+	SignRPCHandler() rpc.Handler
+
+	// PersonalSignRPCHandler returns RPC handler for personal_sign, signing with the selected account
+	PersonalSignRPCHandler() rpc.Handler
+
 	// AddressToDecryptedAccount tries to load decrypted key for a given account.
 	// The running node, has a keystore directory which is loaded on start. Key file
 	// for a given address is expected to be in that directory prior to node start.
@@ -102,6 +102,19 @@ func (mr *MockNodeManagerMockRecorder) NodeConfig() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NodeConfig", reflect.TypeOf((*MockNodeManager)(nil).NodeConfig))
 }
 
+// ChainID mocks base method
+func (m *MockNodeManager) ChainID() (uint64, error) {
+	ret := m.ctrl.Call(m, "ChainID")
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChainID indicates an expected call of ChainID
+func (mr *MockNodeManagerMockRecorder) ChainID() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainID", reflect.TypeOf((*MockNodeManager)(nil).ChainID))
+}
+
 // Node mocks base method
 func (m *MockNodeManager) Node() (*node.Node, error) {
 	ret := m.ctrl.Call(m, "Node")
@@ -151,6 +164,18 @@ func (mr *MockNodeManagerMockRecorder) PeerCount() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PeerCount", reflect.TypeOf((*MockNodeManager)(nil).PeerCount))
 }
 
+// IsWhisperEnabled mocks base method
+func (m *MockNodeManager) IsWhisperEnabled() bool {
+	ret := m.ctrl.Call(m, "IsWhisperEnabled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsWhisperEnabled indicates an expected call of IsWhisperEnabled
+func (mr *MockNodeManagerMockRecorder) IsWhisperEnabled() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsWhisperEnabled", reflect.TypeOf((*MockNodeManager)(nil).IsWhisperEnabled))
+}
+
 // LightEthereumService mocks base method
 func (m *MockNodeManager) LightEthereumService() (*les.LightEthereum, error) {
 	ret := m.ctrl.Call(m, "LightEthereumService")
@@ -368,6 +393,30 @@ func (mr *MockAccountManagerMockRecorder) AccountsRPCHandler() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AccountsRPCHandler", reflect.TypeOf((*MockAccountManager)(nil).AccountsRPCHandler))
 }
 
+// SignRPCHandler mocks base method
+func (m *MockAccountManager) SignRPCHandler() rpc.Handler {
+	ret := m.ctrl.Call(m, "SignRPCHandler")
+	ret0, _ := ret[0].(rpc.Handler)
+	return ret0
+}
+
+// SignRPCHandler indicates an expected call of SignRPCHandler
+func (mr *MockAccountManagerMockRecorder) SignRPCHandler() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignRPCHandler", reflect.TypeOf((*MockAccountManager)(nil).SignRPCHandler))
+}
+
+// PersonalSignRPCHandler mocks base method
+func (m *MockAccountManager) PersonalSignRPCHandler() rpc.Handler {
+	ret := m.ctrl.Call(m, "PersonalSignRPCHandler")
+	ret0, _ := ret[0].(rpc.Handler)
+	return ret0
+}
+
+// PersonalSignRPCHandler indicates an expected call of PersonalSignRPCHandler
+func (mr *MockAccountManagerMockRecorder) PersonalSignRPCHandler() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PersonalSignRPCHandler", reflect.TypeOf((*MockAccountManager)(nil).PersonalSignRPCHandler))
+}
+
 // AddressToDecryptedAccount mocks base method
 func (m *MockAccountManager) AddressToDecryptedAccount(address, password string) (accounts.Account, *keystore.Key, error) {
 	ret := m.ctrl.Call(m, "AddressToDecryptedAccount", address, password)
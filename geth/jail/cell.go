@@ -3,17 +3,72 @@ package jail
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/robertkrimen/otto"
+	"github.com/status-im/status-go/geth/jail/internal/base64"
 	"github.com/status-im/status-go/geth/jail/internal/fetch"
+	"github.com/status-im/status-go/geth/jail/internal/localstorage"
 	"github.com/status-im/status-go/geth/jail/internal/loop"
 	"github.com/status-im/status-go/geth/jail/internal/loop/looptask"
+	"github.com/status-im/status-go/geth/jail/internal/textencoding"
 	"github.com/status-im/status-go/geth/jail/internal/timers"
 	"github.com/status-im/status-go/geth/jail/internal/vm"
+	"github.com/status-im/status-go/geth/jail/internal/webcrypto"
+	"github.com/status-im/status-go/geth/jail/internal/websocket"
 )
 
-const timeout = 5 * time.Second
+const (
+	timeout = 5 * time.Second
+
+	// drainPollInterval defines how often cell is checked for pending work on graceful stop.
+	drainPollInterval = 10 * time.Millisecond
+
+	// minIdlePollInterval limits how often cell is checked for pending work when idle timeout is set.
+	minIdlePollInterval = 10 * time.Millisecond
+)
+
+var (
+	// ErrCallTimeout is returned when a synchronous call doesn't finish in time.
+	ErrCallTimeout = errors.New("call timed out")
+	// ErrExecutionTimeout is returned when JS execution exceeds the cell execution timeout.
+	ErrExecutionTimeout = vm.ErrExecutionTimeout
+	// ErrMemoryLimitExceeded is returned when JS execution exceeds the cell memory limit.
+	ErrMemoryLimitExceeded = vm.ErrMemoryLimitExceeded
+	// ErrCellStopTimeout is returned when the cell event loop doesn't stop in time.
+	ErrCellStopTimeout = errors.New("stopping the cell timed out")
+	// ErrPingTimeout is returned when the cell event loop doesn't respond to Ping in time.
+	ErrPingTimeout = errors.New("cell event loop is not responsive")
+	// ErrTaskPanicked is the cause of a PanicError returned when a call or
+	// a callback panics. The cell event loop keeps running.
+	ErrTaskPanicked = loop.ErrTaskPanicked
+	// ErrLoopPanicked is the cause of a PanicError the cell event loop fails
+	// with when it panics outside of calls and callbacks, see Cell.Err.
+	ErrLoopPanicked = loop.ErrLoopPanicked
+)
+
+// CellError is the error a cell event loop fails with, see Cell.Err.
+type CellError struct {
+	// CellID is the ID of the failed cell.
+	CellID string
+	// Err is the error returned by the event loop.
+	Err error
+}
+
+func (e *CellError) Error() string {
+	return fmt.Sprintf("cell %s: %v", e.CellID, e.Err)
+}
+
+// Cause returns the error returned by the event loop.
+func (e *CellError) Cause() error {
+	return e.Err
+}
 
 // Manager defines methods for managing jailed environments
 type Manager interface {
@@ -70,6 +125,41 @@ type JSCell interface {
 	Stop() error
 }
 
+// LocalStorage is a key-value store backing localStorage object of a cell.
+type LocalStorage = localstorage.Store
+
+// FetchRetryPolicy configures retrying of failed fetch GET requests.
+type FetchRetryPolicy = fetch.RetryPolicy
+
+// FetchInterceptor observes and modifies fetch requests, or blocks them.
+type FetchInterceptor = fetch.Interceptor
+
+// TimerObserver is notified whenever a timer callback starts and finishes.
+type TimerObserver = timers.Observer
+
+// VM is a concurrency safe JavaScript VM of a cell.
+type VM = vm.VM
+
+// Loop is an event loop of a cell.
+type Loop = loop.Loop
+
+// PanicError is the error a panic recovered by the event loop of a cell is
+// converted to. Its cause is either ErrTaskPanicked or ErrLoopPanicked.
+type PanicError = loop.PanicError
+
+// CellHandler defines additional functions and objects in the VM of a cell.
+type CellHandler interface {
+	Define(vm *VM, lo *Loop) error
+}
+
+// CellHandlerFunc is an adapter allowing to use ordinary functions as CellHandler.
+type CellHandlerFunc func(vm *VM, lo *Loop) error
+
+// Define calls f(vm, lo).
+func (f CellHandlerFunc) Define(vm *VM, lo *Loop) error {
+	return f(vm, lo)
+}
+
 // Cell represents a single jail cell, which is basically a JavaScript VM.
 type Cell struct {
 	jsvm   *vm.VM
@@ -79,15 +169,104 @@ type Cell struct {
 	loop        *loop.Loop
 	loopStopped chan struct{}
 	loopErr     error
+
+	// globals defined before user code runs, see Reset and CellPool
+	globals map[string]otto.Value
+
+	// config the cell was created with, see Clone
+	config CellConfig
+
+	// lastActive is the time (in unix nanoseconds) of the last Run, Call or Set
+	lastActive int64
+	idleMu     sync.Mutex
+	idleStop   chan struct{}
+
+	// stopHooks are called once the event loop is not running anymore
+	stopHooksMu sync.Mutex
+	stopHooks   []func()
+	stopped     bool
+
+	// whisperSubs are whisper filters installed with shh.subscribe(), see WhisperFilters
+	whisperSubs *whisperSubscriptions
+}
+
+// CellConfig contains options of a jail cell.
+type CellConfig struct {
+	// Storage backs cell's localStorage, if nil, it's kept in memory.
+	Storage LocalStorage
+
+	// FetchTimeout limits the duration of a single fetch request, zero means no limit.
+	FetchTimeout time.Duration
+
+	// FetchMaxResponseSize limits the size of a fetch response body in bytes, zero means no limit.
+	FetchMaxResponseSize int64
+
+	// FetchMaxConcurrent limits the number of fetch requests in flight at the same time,
+	// further requests wait until one completes. Zero means no limit.
+	FetchMaxConcurrent int
+
+	// FetchRetry configures retrying of fetch GET requests failed with connection
+	// errors or 5xx status codes. Requests are not retried by default.
+	FetchRetry FetchRetryPolicy
+
+	// FetchInterceptors are called before every fetch request and after its response,
+	// in the given order. They may e.g. audit requests, add headers or block requests.
+	FetchInterceptors []FetchInterceptor
+
+	// HTTPClient is used by fetch to make requests, e.g. to route them through a proxy
+	// or to pin TLS certificates. If nil, http.DefaultClient is used.
+	HTTPClient *http.Client
+
+	// DisableFetchDecompression makes fetch pass gzip and deflate encoded
+	// response bodies as is, instead of decoding them.
+	DisableFetchDecompression bool
+
+	// TimerObserver is notified with timer IDs and durations whenever callbacks of
+	// timers run, e.g. to find out which timers dominate CPU usage. Nil by default.
+	TimerObserver TimerObserver
+
+	// MemoryLimit limits the amount of memory in bytes JS values of the cell may take while
+	// JS is executed, zero means no limit. Accounting is approximate, see vm.SetMemoryLimit.
+	MemoryLimit uint64
+
+	// ReadyQueueSize is the capacity of the queue of tasks ready to be executed by the
+	// event loop, beyond which CallAsyncNonBlocking sheds calls. Zero means the queue
+	// is unbuffered, so that tasks are accepted only when the loop is waiting for them.
+	ReadyQueueSize int
+
+	// Handlers are registered after the built-in ones, in the given order.
+	Handlers []CellHandler
 }
 
 // NewCell encapsulates what we need to create a new jailCell from the
-// provided vm and eventloop instance.
+// provided vm and eventloop instance. Cell's localStorage is kept in memory.
 func NewCell(id string) (*Cell, error) {
+	return NewCellWithConfig(id, CellConfig{})
+}
+
+// NewCellWithStorage creates a new jailCell with localStorage
+// backed by the provided store.
+func NewCellWithStorage(id string, store LocalStorage) (*Cell, error) {
+	return NewCellWithConfig(id, CellConfig{Storage: store})
+}
+
+// NewCellWithHandlers creates a new jailCell with additional handlers
+// registered after the built-in ones.
+func NewCellWithHandlers(id string, handlers ...CellHandler) (*Cell, error) {
+	return NewCellWithConfig(id, CellConfig{Handlers: handlers})
+}
+
+// NewCellWithConfig creates a new jailCell with the provided options.
+func NewCellWithConfig(id string, config CellConfig) (*Cell, error) {
+	if config.Storage == nil {
+		config.Storage = localstorage.NewMemoryStore()
+	}
+
 	vm := vm.New()
-	lo := loop.New(vm)
+	vm.SetMemoryLimit(config.MemoryLimit)
+	lo := loop.NewWithBacklog(vm, config.ReadyQueueSize)
 
-	err := registerVMHandlers(vm, lo)
+	err := registerVMHandlers(vm, lo, config)
 	if err != nil {
 		return nil, err
 	}
@@ -100,15 +279,23 @@ func NewCell(id string) (*Cell, error) {
 		cancel:      cancel,
 		loop:        lo,
 		loopStopped: loopStopped,
+		config:      config,
+		lastActive:  time.Now().UnixNano(),
+	}
+
+	if err := cell.snapshotGlobals(); err != nil {
+		cancel()
+		return nil, err
 	}
 
 	// Start event loop in the background.
 	go func() {
 		err := lo.Run(ctx)
 		if err != context.Canceled {
-			cell.loopErr = err
+			cell.loopErr = &CellError{CellID: id, Err: err}
 		}
 
+		cell.runStopHooks()
 		close(loopStopped)
 	}()
 
@@ -117,14 +304,82 @@ func NewCell(id string) (*Cell, error) {
 
 // registerHandlers register variuous functions and handlers
 // to the Otto VM, such as Fetch API callbacks or promises.
-func registerVMHandlers(vm *vm.VM, lo *loop.Loop) error {
+func registerVMHandlers(vm *vm.VM, lo *loop.Loop, config CellConfig) error {
 	// setTimeout/setInterval functions
-	if err := timers.Define(vm, lo); err != nil {
+	if err := timers.DefineWithObserver(vm, lo, config.TimerObserver); err != nil {
+		return err
+	}
+
+	// localStorage object
+	if err := localstorage.Define(vm, config.Storage); err != nil {
+		return err
+	}
+
+	// btoa/atob functions
+	if err := base64.Define(vm); err != nil {
+		return err
+	}
+
+	// TextEncoder/TextDecoder, along with typed arrays
+	if err := textencoding.Define(vm); err != nil {
+		return err
+	}
+
+	// crypto.getRandomValues
+	if err := webcrypto.Define(vm); err != nil {
+		return err
+	}
+
+	// WebSocket API
+	if err := websocket.Define(vm, lo); err != nil {
 		return err
 	}
 
 	// FetchAPI functions
-	return fetch.Define(vm, lo)
+	err := fetch.DefineWithOptions(vm, lo, nil, fetch.Options{
+		Timeout:         config.FetchTimeout,
+		MaxResponseSize: config.FetchMaxResponseSize,
+		Client:          config.HTTPClient,
+
+		DisableDecompression: config.DisableFetchDecompression,
+		Retry:                config.FetchRetry,
+		MaxConcurrent:        config.FetchMaxConcurrent,
+		Interceptors:         config.FetchInterceptors,
+	})
+	if err != nil {
+		return err
+	}
+
+	// custom handlers
+	for _, h := range config.Handlers {
+		if err := h.Define(vm, lo); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CellStats describes how busy a cell is.
+type CellStats struct {
+	// PendingTasks is the number of loop tasks waiting to be finalised.
+	PendingTasks int
+	// ExecutedTasks is the total number of tasks executed by the loop.
+	ExecutedTasks int64
+	// ActiveTimers is the number of scheduled timeouts and intervals.
+	ActiveTimers int
+	// InFlightFetches is the number of fetch requests which haven't completed yet.
+	InFlightFetches int
+}
+
+// Stats returns execution statistics of the cell.
+func (c *Cell) Stats() CellStats {
+	return CellStats{
+		PendingTasks:    c.loop.PendingTasks(),
+		ExecutedTasks:   c.loop.ExecutedTasks(),
+		ActiveTimers:    timers.ActiveTimers(c.loop),
+		InFlightFetches: fetch.InFlight(c.loop),
+	}
 }
 
 // Stop halts event loop associated with cell.
@@ -135,16 +390,304 @@ func (c *Cell) Stop() error {
 	case <-c.loopStopped:
 		return c.loopErr
 	case <-time.After(time.Second):
-		return errors.New("stopping the cell timed out")
+		return ErrCellStopTimeout
+	}
+}
+
+// onStop registers fn to be called once the cell event loop is not running anymore,
+// before Done is closed. If it's not running already, fn is called right away.
+func (c *Cell) onStop(fn func()) {
+	c.stopHooksMu.Lock()
+	if !c.stopped {
+		c.stopHooks = append(c.stopHooks, fn)
+		c.stopHooksMu.Unlock()
+		return
+	}
+	c.stopHooksMu.Unlock()
+
+	fn()
+}
+
+func (c *Cell) runStopHooks() {
+	c.stopHooksMu.Lock()
+	hooks := c.stopHooks
+	c.stopHooks = nil
+	c.stopped = true
+	c.stopHooksMu.Unlock()
+
+	for _, fn := range hooks {
+		fn()
+	}
+}
+
+// Done returns a channel that's closed once the cell event loop is not running
+// anymore, either because the cell was stopped or the loop failed. Panics of
+// loop tasks, e.g. of calls and callbacks, are recovered without stopping the
+// loop, while a panic outside of them, e.g. when cancelling a task, stops it.
+func (c *Cell) Done() <-chan struct{} {
+	return c.loopStopped
+}
+
+// Err returns the error the cell event loop failed with, a *CellError, whose
+// cause is a *PanicError. It returns nil while the loop is running
+// or if the cell was stopped.
+func (c *Cell) Err() error {
+	select {
+	case <-c.loopStopped:
+		return c.loopErr
+	default:
+		return nil
+	}
+}
+
+// SetIdleTimeout makes the cell stop on its own once it has been idle for the
+// given duration: there are no pending timers or fetch requests, and neither
+// Run, Call nor Set was called. Done is closed once the cell stops, and Err
+// returns nil, the same way it does when the cell is stopped with Stop.
+// Zero value disables the timeout.
+func (c *Cell) SetIdleTimeout(d time.Duration) {
+	c.idleMu.Lock()
+	defer c.idleMu.Unlock()
+
+	if c.idleStop != nil {
+		close(c.idleStop)
+		c.idleStop = nil
+	}
+
+	if d <= 0 {
+		return
+	}
+
+	c.touch()
+	c.idleStop = make(chan struct{})
+	go c.watchIdle(d, c.idleStop)
+}
+
+// watchIdle stops the cell once it's idle for longer than d,
+// or returns when stop is closed.
+func (c *Cell) watchIdle(d time.Duration, stop <-chan struct{}) {
+	interval := d / 4
+	if interval < minIdlePollInterval {
+		interval = minIdlePollInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-c.loopStopped:
+			return
+		case <-ticker.C:
+		}
+
+		if c.loop.PendingTasks() > 0 {
+			c.touch()
+			continue
+		}
+
+		if time.Since(time.Unix(0, atomic.LoadInt64(&c.lastActive))) >= d {
+			c.cancel()
+			return
+		}
+	}
+}
+
+// touch marks the cell as active, resetting the idle timeout.
+func (c *Cell) touch() {
+	atomic.StoreInt64(&c.lastActive, time.Now().UnixNano())
+}
+
+// StopGracefully waits for in-flight fetch requests to complete and their
+// callbacks to run, before halting event loop associated with cell.
+// If ctx is done first, the loop is halted anyway and ctx.Err() is returned.
+func (c *Cell) StopGracefully(ctx context.Context) error {
+	drainErr := c.drain(ctx)
+
+	if err := c.Stop(); err != nil {
+		return err
+	}
+
+	return drainErr
+}
+
+// drain waits until there are no in-flight fetch requests, and no
+// promise callbacks scheduled with setImmediate.
+func (c *Cell) drain(ctx context.Context) error {
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
+
+	for fetch.InFlight(c.loop) > 0 || timers.PendingImmediates(c.loop) > 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+
+	return nil
+}
+
+// Reset wipes the state left by the cell user, while keeping the VM and
+// the handlers registered in it: timers are cancelled, in-flight fetch
+// requests are aborted, tasks queued in the loop are dropped and globals
+// defined by user code are removed. localStorage is kept.
+// It must not be called from within JS code executed by the cell.
+func (c *Cell) Reset() error {
+	c.touch()
+
+	task := &resetTask{cell: c, err: make(chan error, 1)}
+	if err := c.loop.AddAndExecute(task); err != nil {
+		return err
+	}
+
+	return <-task.err
+}
+
+// resetTask resets the cell within the event loop, so that
+// it doesn't interleave with other tasks.
+type resetTask struct {
+	id   int64
+	cell *Cell
+	err  chan error
+}
+
+func (t *resetTask) SetID(id int64) { t.id = id }
+func (t *resetTask) GetID() int64   { return t.id }
+
+func (t *resetTask) Execute(vm *vm.VM, l *loop.Loop) error {
+	// The result is sent first, as resetting the loop cancels this task too.
+	t.err <- t.cell.resetGlobals()
+	l.Reset()
+	return nil
+}
+
+func (t *resetTask) Cancel() {
+	select {
+	case t.err <- loop.ErrClosed:
+	default:
+	}
+}
+
+// Ping checks that the cell event loop is responsive: a no-op task is scheduled with
+// high priority and Ping waits for it to be executed. ErrPingTimeout is returned if it's
+// not executed within the given timeout, e.g. because the loop is stuck in a long
+// synchronous script. Unlike other calls, Ping doesn't count as cell activity for the
+// idle timeout. It must not be called from within JS code executed by the cell.
+func (c *Cell) Ping(timeout time.Duration) error {
+	task := &pingTask{done: make(chan error, 1)}
+
+	go func() {
+		if err := c.loop.AddAndExecute(task); err != nil {
+			task.finish(err)
+		}
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case err := <-task.done:
+		return err
+	case <-timer.C:
+		return ErrPingTimeout
+	}
+}
+
+// pingTask is a no-op task reporting its execution, see Ping.
+type pingTask struct {
+	id   int64
+	done chan error
+}
+
+func (t *pingTask) SetID(id int64)          { t.id = id }
+func (t *pingTask) GetID() int64            { return t.id }
+func (t *pingTask) Priority() loop.Priority { return loop.PriorityHigh }
+
+func (t *pingTask) Execute(vm *vm.VM, l *loop.Loop) error {
+	t.finish(nil)
+	return nil
+}
+
+func (t *pingTask) Cancel() {
+	t.finish(loop.ErrClosed)
+}
+
+// finish reports the outcome of the task, only the first one is kept.
+func (t *pingTask) finish(err error) {
+	select {
+	case t.done <- err:
+	default:
 	}
 }
 
 // CallAsync puts otto's function with given args into
 // event queue loop and schedules for immediate execution.
 // Intended to be used by any cell user that want's to run
-// async call, like callback.
+// async call, like callback. Calls are executed with high priority,
+// before ready timer ticks and fetch callbacks. Once the cell is stopped,
+// the function is not called and loop.ErrClosed is returned.
 func (c *Cell) CallAsync(fn otto.Value, args ...interface{}) error {
 	task := looptask.NewCallTask(fn, args...)
+	return c.scheduleCall(task)
+}
+
+// CallAsyncNonBlocking works like CallAsync, but never waits for the loop to accept
+// the call: if the loop is busy and its ready queue is at capacity (see ReadyQueueSize),
+// the call is dropped and false is returned, so that fast producers can shed load.
+// False is returned once the cell is stopped as well.
+func (c *Cell) CallAsyncNonBlocking(fn otto.Value, args ...interface{}) (queued bool) {
+	c.touch()
+
+	task := looptask.NewCallTask(fn, args...)
+	task.HighPriority = true
+
+	if err := c.loop.Add(task); err != nil {
+		return false
+	}
+
+	queued, err := c.loop.TryReady(task)
+	if err != nil || !queued {
+		c.loop.Remove(task)
+		return false
+	}
+
+	return true
+}
+
+// CallAsyncWithError works like CallAsync, but exceptions thrown by
+// the function don't affect the loop and are passed to onErr instead,
+// along with the JS stack trace.
+func (c *Cell) CallAsyncWithError(fn otto.Value, onErr func(error), args ...interface{}) error {
+	task := looptask.NewCallTask(fn, args...)
+	task.SoftError = true
+
+	if err := c.scheduleCall(task); err != nil {
+		return err
+	}
+
+	go func() {
+		select {
+		case err := <-task.Error:
+			if err != nil && onErr != nil {
+				onErr(newJSError(err))
+			}
+		case <-c.loopStopped:
+		}
+	}()
+
+	return nil
+}
+
+// scheduleCall puts the task into event queue loop and waits
+// until it's accepted for execution.
+func (c *Cell) scheduleCall(task *looptask.CallTask) error {
+	c.touch()
+
+	task.HighPriority = true
+
 	errChan := make(chan error)
 
 	go func() {
@@ -165,12 +708,65 @@ func (c *Cell) CallAsync(fn otto.Value, args ...interface{}) error {
 	case <-timer.C:
 		return errors.New("Timeout")
 	}
+}
+
+// newJSError returns an error describing JS exception together
+// with a trace of where it occurred.
+func newJSError(err error) error {
+	if jsErr, ok := err.(*otto.Error); ok {
+		return errors.New(jsErr.String())
+	}
+
+	return err
+}
+
+// CallSync puts otto's function with given args into event queue loop,
+// waits for it to complete and returns the result. If the function doesn't
+// finish within the given timeout, ErrCallTimeout is returned; the call itself
+// can't be interrupted and keeps the loop busy until it's done.
+// It must not be called from within JS code executed by the cell.
+func (c *Cell) CallSync(fn otto.Value, timeout time.Duration, args ...interface{}) (otto.Value, error) {
+	c.touch()
+	defer c.touch()
+
+	task := looptask.NewCallTask(fn, args...)
+	// exceptions thrown by the function are reported to the caller
+	// and must not stop the loop
+	task.SoftError = true
+	task.HighPriority = true
+	errChan := make(chan error, 1)
 
+	go func() {
+		if err := c.loop.AddAndExecute(task); err != nil {
+			errChan <- err
+		}
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case err := <-errChan:
+		return otto.UndefinedValue(), err
+	case err := <-task.Error:
+		return <-task.Value, err
+	case <-timer.C:
+		return otto.UndefinedValue(), ErrCallTimeout
+	}
 }
 
-// Set calls Set on the underlying JavaScript VM.
+// Set sets the value to be keyed by the provided name in the underlying JavaScript VM.
+// Value is set within the event loop, so it never interleaves with running timers
+// and fetch callbacks. It must not be called from within JS code executed by the cell.
 func (c *Cell) Set(key string, val interface{}) error {
-	return c.jsvm.Set(key, val)
+	c.touch()
+
+	task := looptask.NewSetTask(key, val)
+	if err := c.loop.AddAndExecute(task); err != nil {
+		return err
+	}
+
+	return <-task.Error
 }
 
 // Get calls Get on the underlying JavaScript VM and returns
@@ -184,6 +780,26 @@ func (c *Cell) Get(key string) (JSValue, error) {
 	return JSValue, nil
 }
 
+// Globals returns sorted names of globals defined in the VM by the cell user, e.g. to debug
+// the state of a dapp. Built-in handlers (fetch, setTimeout etc.) and JS standard library
+// globals are excluded, as well as any other globals defined when the cell was created.
+func (c *Cell) Globals() ([]string, error) {
+	names, err := c.globalNames()
+	if err != nil {
+		return nil, err
+	}
+
+	globals := make([]string, 0)
+	for _, name := range names {
+		if _, ok := c.globals[name]; !ok {
+			globals = append(globals, name)
+		}
+	}
+	sort.Strings(globals)
+
+	return globals, nil
+}
+
 // GetObjectValue calls GetObjectValue on the underlying JavaScript VM and returns
 // a wrapper around the otto.Value.
 func (c *Cell) GetObjectValue(v otto.Value, name string) (JSValue, error) {
@@ -195,9 +811,20 @@ func (c *Cell) GetObjectValue(v otto.Value, name string) (JSValue, error) {
 	return JSValue, nil
 }
 
+// SetExecutionTimeout limits the duration of a single synchronous
+// execution of JS code within the cell, including calls scheduled with
+// CallSync and CallAsync, timer and fetch callbacks.
+// Execution exceeding the timeout is aborted with ErrExecutionTimeout.
+func (c *Cell) SetExecutionTimeout(timeout time.Duration) {
+	c.jsvm.SetExecutionTimeout(timeout)
+}
+
 // Run calls Run on the underlying JavaScript VM and returns
 // a wrapper around the otto.Value.
 func (c *Cell) Run(src interface{}) (JSValue, error) {
+	c.touch()
+	defer c.touch()
+
 	v, err := c.jsvm.Run(src)
 	if err != nil {
 		return JSValue{}, err
@@ -206,9 +833,55 @@ func (c *Cell) Run(src interface{}) (JSValue, error) {
 	return JSValue, nil
 }
 
+// ExecFile reads JS source from a given file (e.g. a dapp bundle), and evaluates it within
+// the event loop of the cell, so it never interleaves with running timers and fetch callbacks.
+// Errors are reported with the file name and line they occurred at, while a panic
+// of a Go function called by the script is reported as a *PanicError.
+// It must not be called from within JS code executed by the cell.
+func (c *Cell) ExecFile(path string) (otto.Value, error) {
+	c.touch()
+	defer c.touch()
+
+	src, err := ioutil.ReadFile(path)
+	if err != nil {
+		return otto.UndefinedValue(), err
+	}
+
+	script, err := c.jsvm.Compile(path, src)
+	if err != nil {
+		return otto.UndefinedValue(), err
+	}
+
+	task := looptask.NewEvalTask(script)
+	// exceptions thrown by the script are reported to the caller
+	// and must not stop the loop
+	task.SoftError = true
+	if err := c.loop.AddAndExecute(task); err != nil {
+		return otto.UndefinedValue(), err
+	}
+
+	if err := <-task.Error; err != nil {
+		return otto.UndefinedValue(), newJSError(err)
+	}
+
+	return <-task.Value, nil
+}
+
+// RunWithContext evaluates JS source within the cell, interrupting it once the
+// context is done. In such case ctx.Err() is returned.
+func (c *Cell) RunWithContext(ctx context.Context, src string) (otto.Value, error) {
+	c.touch()
+	defer c.touch()
+
+	return c.jsvm.RunWithContext(ctx, src)
+}
+
 // Call calls Call on the underlying JavaScript VM and returns
 // a wrapper around the otto.Value.
 func (c *Cell) Call(item string, this interface{}, args ...interface{}) (JSValue, error) {
+	c.touch()
+	defer c.touch()
+
 	v, err := c.jsvm.Call(item, this, args...)
 	if err != nil {
 		return JSValue{}, err
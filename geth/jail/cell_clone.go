@@ -0,0 +1,71 @@
+package jail
+
+import (
+	"github.com/robertkrimen/otto"
+)
+
+// snapshotGlobalsJS serializes globals of the cell into a JSON object, mapping
+// global names to their JSON representation. Globals which can't be serialized,
+// like functions or objects with circular references, are left out.
+const snapshotGlobalsJS = `(function() {
+	var global = this;
+	var state = {};
+	Object.getOwnPropertyNames(global).forEach(function(name) {
+		try {
+			var json = JSON.stringify(global[name]);
+			if (json !== undefined) {
+				state[name] = json;
+			}
+		} catch (e) {}
+	});
+	return JSON.stringify(state);
+})`
+
+// restoreGlobalsJS defines globals serialized by snapshotGlobalsJS. Globals
+// which are already defined, like the built-in ones, are not overwritten.
+const restoreGlobalsJS = `(function(snapshot) {
+	var global = this;
+	var state = JSON.parse(snapshot);
+	Object.keys(state).forEach(function(name) {
+		if (!(name in global)) {
+			global[name] = JSON.parse(state[name]);
+		}
+	});
+})`
+
+// Clone creates a new cell with a copy of the global state of the cell, which
+// allows to evaluate code speculatively without affecting the cell. The clone
+// has its own event loop and in-memory localStorage, and is otherwise configured
+// the same way the cell was.
+//
+// Otto can't copy its state, so globals are copied through JSON: functions,
+// closures and prototypes don't survive, as well as globals which can't be
+// serialized, and built-in globals are not overwritten. Changes made in either
+// cell afterwards are not visible in the other one.
+func (c *Cell) Clone(newID string) (*Cell, error) {
+	snapshot, err := c.jsvm.Call(snapshotGlobalsJS, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	config := c.config
+	config.Storage = nil
+
+	clone, err := NewCellWithConfig(newID, config)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := clone.restoreGlobals(snapshot); err != nil {
+		clone.Stop() // nolint: errcheck
+		return nil, err
+	}
+
+	return clone, nil
+}
+
+func (c *Cell) restoreGlobals(snapshot otto.Value) error {
+	// snapshot belongs to another VM, so only its string value is passed on
+	_, err := c.jsvm.Call(restoreGlobalsJS, nil, snapshot.String())
+	return err
+}
@@ -0,0 +1,179 @@
+package jail
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/robertkrimen/otto"
+)
+
+// resetGlobalsJS removes globals, which are not in the keep set, from the global object.
+// Globals which can't be deleted, like the ones declared with var, are set to undefined.
+const resetGlobalsJS = `(function(keep) {
+	var global = this;
+	Object.getOwnPropertyNames(global).forEach(function(name) {
+		if (keep[name] !== true && !delete global[name]) {
+			global[name] = undefined;
+		}
+	});
+})`
+
+// CellPool keeps a number of pre-warmed cells, so that short-lived
+// cells don't have to be created from scratch every time.
+type CellPool struct {
+	mu    sync.Mutex
+	size  int
+	cells []*Cell
+	count int64
+}
+
+// NewCellPool creates a new pool with the given number of pre-warmed cells.
+func NewCellPool(size int) (*CellPool, error) {
+	p := &CellPool{
+		size:  size,
+		cells: make([]*Cell, 0, size),
+	}
+
+	for i := 0; i < size; i++ {
+		c, err := p.newCell()
+		if err != nil {
+			p.Close() // nolint: errcheck
+			return nil, err
+		}
+		p.cells = append(p.cells, c)
+	}
+
+	return p, nil
+}
+
+// Get returns a cell from the pool, creating a new one if the pool is empty.
+func (p *CellPool) Get() (*Cell, error) {
+	p.mu.Lock()
+	if n := len(p.cells); n > 0 {
+		c := p.cells[n-1]
+		p.cells = p.cells[:n-1]
+		p.mu.Unlock()
+		return c, nil
+	}
+	p.mu.Unlock()
+
+	return p.newCell()
+}
+
+// Put returns the cell to the pool, resetting globals defined by its user.
+// Cells which still have pending timers or requests, as well as cells
+// exceeding the pool size, are stopped instead.
+func (p *CellPool) Put(c *Cell) error {
+	if c.Stats().PendingTasks > 0 {
+		return c.Stop()
+	}
+
+	if err := c.resetGlobals(); err != nil {
+		c.Stop() // nolint: errcheck
+		return err
+	}
+
+	if _, err := c.jsvm.Run(`localStorage.clear()`); err != nil {
+		c.Stop() // nolint: errcheck
+		return err
+	}
+
+	p.mu.Lock()
+	if len(p.cells) < p.size {
+		p.cells = append(p.cells, c)
+		p.mu.Unlock()
+		return nil
+	}
+	p.mu.Unlock()
+
+	return c.Stop()
+}
+
+// Close stops all the cells in the pool.
+func (p *CellPool) Close() error {
+	p.mu.Lock()
+	cells := p.cells
+	p.cells = nil
+	p.mu.Unlock()
+
+	var lastErr error
+	for _, c := range cells {
+		if err := c.Stop(); err != nil {
+			lastErr = err
+		}
+	}
+
+	return lastErr
+}
+
+func (p *CellPool) newCell() (*Cell, error) {
+	id := fmt.Sprintf("pooled-cell-%d", atomic.AddInt64(&p.count, 1))
+	return NewCell(id)
+}
+
+// snapshotGlobals remembers globals defined in the cell, so that they
+// can be restored by resetGlobals.
+func (c *Cell) snapshotGlobals() error {
+	names, err := c.globalNames()
+	if err != nil {
+		return err
+	}
+
+	c.globals = make(map[string]otto.Value, len(names))
+	for _, name := range names {
+		v, err := c.jsvm.Get(name)
+		if err != nil {
+			return err
+		}
+		c.globals[name] = v
+	}
+
+	return nil
+}
+
+// resetGlobals removes globals defined since snapshotGlobals was called
+// and restores overwritten ones.
+func (c *Cell) resetGlobals() error {
+	keep := make(map[string]bool, len(c.globals))
+	for name := range c.globals {
+		keep[name] = true
+	}
+
+	if _, err := c.jsvm.Call(resetGlobalsJS, nil, keep); err != nil {
+		return err
+	}
+
+	for name, v := range c.globals {
+		if err := c.jsvm.Set(name, v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *Cell) globalNames() ([]string, error) {
+	v, err := c.jsvm.Run(`Object.getOwnPropertyNames(this)`)
+	if err != nil {
+		return nil, err
+	}
+
+	exported, err := v.Export()
+	if err != nil {
+		return nil, err
+	}
+
+	switch names := exported.(type) {
+	case []string:
+		return names, nil
+	case []interface{}:
+		result := make([]string, 0, len(names))
+		for _, name := range names {
+			result = append(result, fmt.Sprint(name))
+		}
+		return result, nil
+	}
+
+	return nil, fmt.Errorf("unexpected type of global names: %T", exported)
+}
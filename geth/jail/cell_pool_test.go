@@ -0,0 +1,117 @@
+package jail
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+func TestCellPoolTestSuite(t *testing.T) {
+	suite.Run(t, new(CellPoolTestSuite))
+}
+
+type CellPoolTestSuite struct {
+	suite.Suite
+	pool *CellPool
+}
+
+func (s *CellPoolTestSuite) SetupTest() {
+	pool, err := NewCellPool(1)
+	s.NoError(err)
+	s.pool = pool
+}
+
+func (s *CellPoolTestSuite) TearDownTest() {
+	s.NoError(s.pool.Close())
+}
+
+func (s *CellPoolTestSuite) TestGetAfterPutHasNoLeftovers() {
+	cell, err := s.pool.Get()
+	s.NoError(err)
+
+	_, err = cell.Run(`
+		var declared = 1;
+		assigned = 2;
+		this.property = 3;
+		setTimeout = function() {};
+		localStorage.setItem("key", "value");
+	`)
+	s.NoError(err)
+	s.NoError(s.pool.Put(cell))
+
+	reused, err := s.pool.Get()
+	s.NoError(err)
+	s.Equal(cell, reused)
+	defer s.pool.Put(reused) //nolint: errcheck
+
+	value, err := reused.Run(`[typeof declared, typeof assigned, typeof property].join(",")`)
+	s.NoError(err)
+	s.Equal("undefined,undefined,undefined", value.Value().String())
+
+	value, err = reused.Run(`localStorage.getItem("key")`)
+	s.NoError(err)
+	s.True(value.Value().IsNull())
+
+	// built-in handlers are kept
+	value, err = reused.Run(`[typeof setTimeout, typeof fetch, typeof Promise].join(",")`)
+	s.NoError(err)
+	s.Equal("function,function,function", value.Value().String())
+
+	_, err = reused.Run(`setTimeout(function() {}, 10)`)
+	s.NoError(err)
+	s.Equal(1, reused.Stats().ActiveTimers)
+}
+
+func (s *CellPoolTestSuite) TestPutBusyCellStopsIt() {
+	cell, err := s.pool.Get()
+	s.NoError(err)
+
+	_, err = cell.Run(`setInterval(function() {}, 1000)`)
+	s.NoError(err)
+	s.NoError(s.pool.Put(cell))
+
+	// cell has been stopped, so it's not reused
+	_, err = cell.Run(`1`)
+	s.NoError(err)
+	s.Error(cell.Set("key", "value"))
+
+	other, err := s.pool.Get()
+	s.NoError(err)
+	s.NotEqual(cell, other)
+	s.NoError(other.Stop())
+}
+
+func BenchmarkNewCell(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		cell, err := NewCell("benchmarkCell")
+		if err != nil {
+			b.Fatal(err)
+		}
+		if _, err := cell.Run(`var x = 1`); err != nil {
+			b.Fatal(err)
+		}
+		cell.Stop() //nolint: errcheck
+	}
+}
+
+func BenchmarkCellPool(b *testing.B) {
+	pool, err := NewCellPool(1)
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer pool.Close() //nolint: errcheck
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		cell, err := pool.Get()
+		if err != nil {
+			b.Fatal(err)
+		}
+		if _, err := cell.Run(`var x = 1`); err != nil {
+			b.Fatal(err)
+		}
+		if err := pool.Put(cell); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
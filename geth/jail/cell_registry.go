@@ -0,0 +1,93 @@
+package jail
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+)
+
+// CellRegistry tracks IDs of cells managed centrally, e.g. by a host running
+// multiple dapps, so that two cells never share the same ID.
+type CellRegistry struct {
+	mu     sync.Mutex
+	prefix string
+	count  uint64
+	ids    map[string]struct{}
+}
+
+// NewCellRegistry creates a new registry. IDs generated by GenerateCellID
+// are made of the given prefix followed by a sequence number.
+func NewCellRegistry(prefix string) *CellRegistry {
+	return &CellRegistry{
+		prefix: prefix,
+		ids:    make(map[string]struct{}),
+	}
+}
+
+// Register reserves the ID. It returns an error if the ID is already registered.
+func (r *CellRegistry) Register(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.ids[id]; ok {
+		return newCellExistsError(id)
+	}
+	r.ids[id] = struct{}{}
+
+	return nil
+}
+
+// Release frees the ID, so that it can be registered again. IDs of cells
+// created with NewCell are released automatically once the cell is stopped.
+func (r *CellRegistry) Release(id string) {
+	r.mu.Lock()
+	delete(r.ids, id)
+	r.mu.Unlock()
+}
+
+// Registered checks whether the ID is registered.
+func (r *CellRegistry) Registered(id string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, ok := r.ids[id]
+	return ok
+}
+
+// GenerateCellID registers and returns a new unique ID. IDs are generated
+// in a deterministic sequence, skipping the ones registered explicitly.
+func (r *CellRegistry) GenerateCellID() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for {
+		r.count++
+		id := r.prefix + strconv.FormatUint(r.count, 10)
+		if _, ok := r.ids[id]; !ok {
+			r.ids[id] = struct{}{}
+			return id
+		}
+	}
+}
+
+// NewCell registers the ID and creates a new cell with the provided options.
+// It returns an error if the ID is already registered. The ID is released
+// when the cell is stopped.
+func (r *CellRegistry) NewCell(id string, config CellConfig) (*Cell, error) {
+	if err := r.Register(id); err != nil {
+		return nil, err
+	}
+
+	cell, err := NewCellWithConfig(id, config)
+	if err != nil {
+		r.Release(id)
+		return nil, err
+	}
+	cell.onStop(func() { r.Release(id) })
+
+	return cell, nil
+}
+
+func newCellExistsError(id string) error {
+	return fmt.Errorf("cell with id '%s' already exists", id)
+}
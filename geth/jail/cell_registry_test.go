@@ -0,0 +1,45 @@
+package jail
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+func TestCellRegistryTestSuite(t *testing.T) {
+	suite.Run(t, new(CellRegistryTestSuite))
+}
+
+type CellRegistryTestSuite struct {
+	suite.Suite
+	registry *CellRegistry
+}
+
+func (s *CellRegistryTestSuite) SetupTest() {
+	s.registry = NewCellRegistry("cell-")
+}
+
+func (s *CellRegistryTestSuite) TestNewCellWithRegisteredID() {
+	cell, err := s.registry.NewCell("dapp", CellConfig{})
+	s.NoError(err)
+
+	_, err = s.registry.NewCell("dapp", CellConfig{})
+	s.EqualError(err, "cell with id 'dapp' already exists")
+
+	// ID is released once the cell is stopped
+	s.NoError(cell.Stop())
+	s.False(s.registry.Registered("dapp"))
+
+	cell, err = s.registry.NewCell("dapp", CellConfig{})
+	s.NoError(err)
+	s.NoError(cell.Stop())
+}
+
+func (s *CellRegistryTestSuite) TestGenerateCellID() {
+	s.NoError(s.registry.Register("cell-2"))
+
+	s.Equal("cell-1", s.registry.GenerateCellID())
+	s.Equal("cell-3", s.registry.GenerateCellID())
+	s.True(s.registry.Registered("cell-3"))
+	s.EqualError(s.registry.Register("cell-1"), "cell with id 'cell-1' already exists")
+}
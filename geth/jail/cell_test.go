@@ -1,14 +1,22 @@
 package jail
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io/ioutil"
 	"net"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/robertkrimen/otto"
+	"github.com/status-im/status-go/geth/jail/internal/localstorage"
+	"github.com/status-im/status-go/geth/jail/internal/loop"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -245,6 +253,861 @@ func (s *CellTestSuite) TestCellCallAsync() {
 	s.Equal("success", <-datac)
 }
 
+func (s *CellTestSuite) TestCellCallAsyncNonBlocking() {
+	cell, err := NewCellWithConfig("testCell2", CellConfig{ReadyQueueSize: 1})
+	s.Require().NoError(err)
+	defer cell.Stop() //nolint: errcheck
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	s.NoError(cell.Set("__block", func(call otto.FunctionCall) otto.Value {
+		close(started)
+		<-release
+		return otto.UndefinedValue()
+	}))
+	calls := make(chan int, 10)
+	s.NoError(cell.Set("__record", func(call otto.FunctionCall) otto.Value {
+		n, _ := call.Argument(0).ToInteger()
+		calls <- int(n)
+		return otto.UndefinedValue()
+	}))
+
+	block, err := cell.Get("__block")
+	s.NoError(err)
+	record, err := cell.Get("__record")
+	s.NoError(err)
+
+	// keep the loop busy
+	s.True(cell.CallAsyncNonBlocking(block.Value()))
+	select {
+	case <-started:
+	case <-time.After(time.Second):
+		s.FailNow("loop didn't run the blocking call")
+	}
+
+	// one call fits into the ready queue, the next one is dropped without blocking
+	s.True(cell.CallAsyncNonBlocking(record.Value(), 1))
+	start := time.Now()
+	s.False(cell.CallAsyncNonBlocking(record.Value(), 2))
+	s.True(time.Since(start) < 100*time.Millisecond)
+
+	close(release)
+	select {
+	case n := <-calls:
+		s.Equal(1, n)
+	case <-time.After(time.Second):
+		s.Fail("queued call not executed")
+	}
+	select {
+	case n := <-calls:
+		s.Fail("dropped call executed", "call %d", n)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	s.NoError(cell.Stop())
+	s.False(cell.CallAsyncNonBlocking(record.Value(), 3))
+}
+
+func (s *CellTestSuite) TestCellCallAsyncWithError() {
+	_, err := s.cell.Run(`
+		function fail() { throw new Error("intended failure"); }
+		function ok() { called = true; }
+	`)
+	s.NoError(err)
+
+	errc := make(chan error, 1)
+	onErr := func(err error) {
+		errc <- err
+	}
+
+	fn, err := s.cell.Get("fail")
+	s.NoError(err)
+	err = s.cell.CallAsyncWithError(fn.Value(), onErr)
+	s.NoError(err)
+
+	select {
+	case err := <-errc:
+		s.Contains(err.Error(), "intended failure")
+		s.Contains(err.Error(), "at fail")
+	case <-time.After(time.Second):
+		s.Fail("onErr hasn't been called")
+	}
+
+	// loop must still be running
+	fn, err = s.cell.Get("ok")
+	s.NoError(err)
+	value, err := s.cell.CallSync(fn.Value(), time.Second)
+	s.NoError(err)
+	s.True(value.IsUndefined())
+
+	called, err := s.cell.Get("called")
+	s.NoError(err)
+	s.Equal("true", called.Value().String())
+
+	select {
+	case err := <-errc:
+		s.Fail("unexpected error", err)
+	default:
+	}
+}
+
+func (s *CellTestSuite) TestCellCallSync() {
+	_, err := s.cell.Run(`
+		function sum(a, b) { return a + b; }
+		function fail() { throw new Error("intended failure"); }
+		function busy() { var start = Date.now(); while (Date.now() - start < 300) {} }
+	`)
+	s.NoError(err)
+
+	fn, err := s.cell.Get("sum")
+	s.NoError(err)
+	value, err := s.cell.CallSync(fn.Value(), time.Second, 2, 3)
+	s.NoError(err)
+	s.Equal("5", value.String())
+
+	fn, err = s.cell.Get("fail")
+	s.NoError(err)
+	_, err = s.cell.CallSync(fn.Value(), time.Second)
+	s.Error(err)
+	s.Contains(err.Error(), "intended failure")
+
+	fn, err = s.cell.Get("busy")
+	s.NoError(err)
+	_, err = s.cell.CallSync(fn.Value(), 50*time.Millisecond)
+	s.Equal(ErrCallTimeout, err)
+
+	// loop keeps working after an exception and a timeout
+	fn, err = s.cell.Get("sum")
+	s.NoError(err)
+	value, err = s.cell.CallSync(fn.Value(), time.Second, "a", "b")
+	s.NoError(err)
+	s.Equal("ab", value.String())
+}
+
+func (s *CellTestSuite) TestCellSetWhileTimerIsActive() {
+	_, err := s.cell.Run(`
+		var ticks = 0;
+		setInterval(function() {
+			ticks++;
+			counter = (typeof counter === 'undefined' ? 0 : counter) + 1;
+		}, 10);
+	`)
+	s.NoError(err)
+
+	for i := 0; i < 20; i++ {
+		err = s.cell.Set("counter", i*1000)
+		s.NoError(err)
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	err = s.cell.Set("greeting", "hello")
+	s.NoError(err)
+
+	value, err := s.cell.Run(`greeting`)
+	s.NoError(err)
+	s.Equal("hello", value.Value().String())
+
+	value, err = s.cell.Run(`ticks > 0 && counter >= 19000`)
+	s.NoError(err)
+	s.Equal("true", value.Value().String())
+}
+
+func (s *CellTestSuite) TestCellSetAfterStop() {
+	err := s.cell.Stop()
+	s.NoError(err)
+
+	err = s.cell.Set("greeting", "hello")
+	s.Equal(loop.ErrClosed, err)
+}
+
+func (s *CellTestSuite) TestCellGlobals() {
+	globals, err := s.cell.Globals()
+	s.NoError(err)
+	s.Empty(globals)
+
+	_, err = s.cell.Run(`var counter = 1; function greet() { return "hi" }`)
+	s.NoError(err)
+	s.NoError(s.cell.Set("config", map[string]string{"network": "test"}))
+
+	globals, err = s.cell.Globals()
+	s.NoError(err)
+	s.Equal([]string{"config", "counter", "greet"}, globals)
+	s.NotContains(globals, "fetch")
+	s.NotContains(globals, "setTimeout")
+
+	// overwritten built-ins are not reported
+	_, err = s.cell.Run(`setTimeout = function() {}`)
+	s.NoError(err)
+	globals, err = s.cell.Globals()
+	s.NoError(err)
+	s.Equal([]string{"config", "counter", "greet"}, globals)
+}
+
+func (s *CellTestSuite) TestCellPing() {
+	s.NoError(s.cell.Ping(time.Second))
+
+	// busy loop keeps the event loop stuck, as an infinite one would
+	_, err := s.cell.Run(`function busy() { var end = Date.now() + 500; while (Date.now() < end) {} }`)
+	s.NoError(err)
+	busy, err := s.cell.Get("busy")
+	s.NoError(err)
+	s.NoError(s.cell.CallAsync(busy.Value()))
+
+	s.Equal(ErrPingTimeout, s.cell.Ping(100*time.Millisecond))
+
+	// loop is responsive once the script finishes
+	s.NoError(s.cell.Ping(time.Second))
+
+	s.NoError(s.cell.Stop())
+	s.Equal(loop.ErrClosed, s.cell.Ping(time.Second))
+}
+
+func (s *CellTestSuite) TestCellCallAsyncAfterStop() {
+	called := make(chan struct{}, 2)
+	err := s.cell.Set("callback", func(otto.FunctionCall) otto.Value {
+		called <- struct{}{}
+		return otto.UndefinedValue()
+	})
+	s.NoError(err)
+	fn, err := s.cell.Get("callback")
+	s.NoError(err)
+
+	s.NoError(s.cell.Stop())
+
+	s.NotPanics(func() {
+		err = s.cell.CallAsync(fn.Value())
+		s.Equal(loop.ErrClosed, err)
+
+		_, err = s.cell.CallSync(fn.Value(), time.Second)
+		s.Equal(loop.ErrClosed, err)
+	})
+
+	select {
+	case <-called:
+		s.Fail("function is called after the cell is stopped")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func (s *CellTestSuite) TestCellLocalStorage() {
+	store := localstorage.NewMemoryStore()
+
+	cell, err := NewCellWithStorage("testCellStorage1", store)
+	s.NoError(err)
+	_, err = cell.Run(`localStorage.setItem("greeting", "hello")`)
+	s.NoError(err)
+	s.NoError(cell.Stop())
+
+	cell, err = NewCellWithStorage("testCellStorage2", store)
+	s.NoError(err)
+	defer cell.Stop() //nolint: errcheck
+
+	value, err := cell.Run(`localStorage.getItem("greeting")`)
+	s.NoError(err)
+	s.Equal("hello", value.Value().String())
+}
+
+func (s *CellTestSuite) TestCellFetchTimeout() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer server.Close()
+
+	cell, err := NewCellWithConfig("testCellFetchTimeout", CellConfig{FetchTimeout: 50 * time.Millisecond})
+	s.NoError(err)
+	defer cell.Stop() //nolint: errcheck
+
+	errc := make(chan string, 1)
+	err = cell.Set("__captureError", func(call otto.FunctionCall) otto.Value {
+		errc <- call.Argument(0).String()
+		return otto.UndefinedValue()
+	})
+	s.NoError(err)
+
+	_, err = cell.Run(`fetch("` + server.URL + `").catch(function(e) { __captureError(e.name) })`)
+	s.NoError(err)
+
+	select {
+	case name := <-errc:
+		s.Equal("AbortError", name)
+	case <-time.After(500 * time.Millisecond):
+		s.Fail("fetch hasn't been aborted")
+	}
+}
+
+func (s *CellTestSuite) TestCellStats() {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.Write([]byte("hello")) //nolint: errcheck
+	}))
+	defer server.Close()
+
+	stats := s.cell.Stats()
+	s.Equal(CellStats{}, stats)
+
+	_, err := s.cell.Run(`
+		setTimeout(function() {}, 100);
+		setTimeout(function() {}, 100);
+		fetch("` + server.URL + `");
+		fetch("` + server.URL + `");
+		fetch("` + server.URL + `");
+	`)
+	s.NoError(err)
+
+	stats = s.cell.Stats()
+	s.Equal(2, stats.ActiveTimers)
+	s.Equal(3, stats.InFlightFetches)
+	s.Equal(5, stats.PendingTasks)
+
+	close(release)
+	time.Sleep(300 * time.Millisecond)
+
+	stats = s.cell.Stats()
+	s.Equal(0, stats.ActiveTimers)
+	s.Equal(0, stats.InFlightFetches)
+	s.Equal(0, stats.PendingTasks)
+	s.True(stats.ExecutedTasks >= 5)
+}
+
+func (s *CellTestSuite) TestCellExecutionTimeout() {
+	s.cell.SetExecutionTimeout(100 * time.Millisecond)
+
+	start := time.Now()
+	_, err := s.cell.Run(`while(true){}`)
+	s.Equal(ErrExecutionTimeout, err)
+	s.True(time.Since(start) < time.Second)
+
+	// cell is still usable
+	value, err := s.cell.Run(`1 + 2`)
+	s.NoError(err)
+	s.Equal("3", value.Value().String())
+
+	// exceptions can't stop the interruption
+	_, err = s.cell.Run(`while(true){ try { while(true){} } catch (e) {} }`)
+	s.Equal(ErrExecutionTimeout, err)
+
+	// calls scheduled within the loop are interrupted too
+	_, err = s.cell.Run(`function spin() { while(true){} }`)
+	s.NoError(err)
+	fn, err := s.cell.Get("spin")
+	s.NoError(err)
+	_, err = s.cell.CallSync(fn.Value(), time.Second)
+	s.Equal(ErrExecutionTimeout, err)
+
+	_, err = s.cell.Run(`setTimeout(spin, 0)`)
+	s.NoError(err)
+	time.Sleep(50 * time.Millisecond)
+	s.NoError(s.cell.Stop())
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func (s *CellTestSuite) TestCellHTTPClient() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	requested := make(chan string, 1)
+	client := &http.Client{
+		Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			requested <- req.URL.String()
+			return http.DefaultTransport.RoundTrip(req)
+		}),
+	}
+
+	cell, err := NewCellWithConfig("testCellHTTPClient", CellConfig{HTTPClient: client})
+	s.NoError(err)
+	defer cell.Stop() //nolint: errcheck
+
+	_, err = cell.Run(`fetch("` + server.URL + `/dapp")`)
+	s.NoError(err)
+
+	select {
+	case url := <-requested:
+		s.Equal(server.URL+"/dapp", url)
+	case <-time.After(time.Second):
+		s.Fail("request hasn't been made with the provided client")
+	}
+}
+
+// headerInterceptor adds a header to every fetch request.
+type headerInterceptor struct {
+	name, value string
+}
+
+func (i headerInterceptor) BeforeRequest(req *http.Request) error {
+	req.Header.Set(i.name, i.value)
+	return nil
+}
+
+func (headerInterceptor) AfterResponse(*http.Request, *http.Response, error, time.Duration) {}
+
+func (s *CellTestSuite) TestCellFetchInterceptors() {
+	headers := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers <- r.Header.Get("Authorization")
+	}))
+	defer server.Close()
+
+	cell, err := NewCellWithConfig("testCellFetchInterceptors", CellConfig{
+		FetchInterceptors: []FetchInterceptor{headerInterceptor{"Authorization", "Bearer token"}},
+	})
+	s.NoError(err)
+	defer cell.Stop() //nolint: errcheck
+
+	_, err = cell.Run(`fetch("` + server.URL + `/dapp")`)
+	s.NoError(err)
+
+	select {
+	case header := <-headers:
+		s.Equal("Bearer token", header)
+	case <-time.After(time.Second):
+		s.Fail("request hasn't been made")
+	}
+}
+
+// recordingTimerObserver records runs of timer callbacks.
+type recordingTimerObserver struct {
+	mu       sync.Mutex
+	started  []int64
+	finished []int64
+}
+
+func (o *recordingTimerObserver) TimerStarted(id int64) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.started = append(o.started, id)
+}
+
+func (o *recordingTimerObserver) TimerFinished(id int64, duration time.Duration) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.finished = append(o.finished, id)
+}
+
+func (o *recordingTimerObserver) runs() ([]int64, []int64) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return append([]int64(nil), o.started...), append([]int64(nil), o.finished...)
+}
+
+func (s *CellTestSuite) TestCellTimerObserver() {
+	observer := &recordingTimerObserver{}
+	cell, err := NewCellWithConfig("testCellTimerObserver", CellConfig{TimerObserver: observer})
+	s.NoError(err)
+	defer cell.Stop() //nolint: errcheck
+
+	// interval clears itself after the third run, cleared timeout never runs
+	_, err = cell.Run(`
+		var runs = 0;
+		var iv = setInterval(function() {
+			if (++runs === 3) {
+				clearInterval(iv);
+			}
+		}, 10);
+		clearTimeout(setTimeout(function() {}, 10));
+	`)
+	s.NoError(err)
+
+	deadline := time.After(time.Second)
+	for {
+		started, finished := observer.runs()
+		if len(finished) == 3 {
+			s.Equal([]int64{1, 1, 1}, started)
+			s.Equal([]int64{1, 1, 1}, finished)
+			break
+		}
+
+		select {
+		case <-deadline:
+			s.FailNow("interval hasn't run 3 times", "started: %v, finished: %v", started, finished)
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	started, _ := observer.runs()
+	s.Len(started, 3)
+}
+
+func (s *CellTestSuite) TestCellMemoryLimit() {
+	cell, err := NewCellWithConfig("testCellMemoryLimit", CellConfig{MemoryLimit: 4 << 20})
+	s.NoError(err)
+	defer cell.Stop() //nolint: errcheck
+
+	// small allocations are fine
+	_, err = cell.Run(`var small = []; for (var i = 0; i < 100; i++) { small.push("item" + i) }`)
+	s.NoError(err)
+
+	// the deadline only keeps the test from spinning forever if the limit is not enforced
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	_, err = cell.RunWithContext(ctx, `var large = []; while (true) { large.push("item" + large.length) }`)
+	s.Equal(ErrMemoryLimitExceeded, err)
+
+	// cell is still usable
+	_, err = cell.Run(`large = null`)
+	s.NoError(err)
+	value, err := cell.Run(`small.length`)
+	s.NoError(err)
+	s.Equal("100", value.Value().String())
+}
+
+func (s *CellTestSuite) TestCellMemoryLimitConcurrentCells() {
+	allocating, err := NewCellWithConfig("testCellMemoryLimitAllocating", CellConfig{MemoryLimit: 4 << 20})
+	s.NoError(err)
+	defer allocating.Stop() //nolint: errcheck
+
+	idle, err := NewCellWithConfig("testCellMemoryLimitIdle", CellConfig{MemoryLimit: 4 << 20})
+	s.NoError(err)
+	defer idle.Stop() //nolint: errcheck
+
+	// idle cell spins until the allocating one is aborted
+	aborted := make(chan struct{})
+	s.NoError(idle.Set("isAborted", func(call otto.FunctionCall) otto.Value {
+		select {
+		case <-aborted:
+			return otto.TrueValue()
+		default:
+			return otto.FalseValue()
+		}
+	}))
+
+	idleErr := make(chan error, 1)
+	go func() {
+		_, err := idle.Run(`var spins = 0; while (!isAborted()) { spins++ }`)
+		idleErr <- err
+	}()
+
+	_, err = allocating.Run(`var large = []; while (true) { large.push("item" + large.length) }`)
+	s.Equal(ErrMemoryLimitExceeded, err)
+	close(aborted)
+
+	select {
+	case err := <-idleErr:
+		s.NoError(err)
+	case <-time.After(5 * time.Second):
+		s.Fail("idle cell is not done")
+	}
+}
+
+func (s *CellTestSuite) TestCellRunWithContext() {
+	value, err := s.cell.RunWithContext(context.Background(), `1 + 2`)
+	s.NoError(err)
+	s.Equal("3", value.String())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, err = s.cell.RunWithContext(ctx, `while(true){}`)
+	s.Equal(context.Canceled, err)
+	s.True(time.Since(start) < time.Second)
+
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = s.cell.RunWithContext(ctx, `while(true){}`)
+	s.Equal(context.DeadlineExceeded, err)
+
+	// cell is still usable
+	value, err = s.cell.RunWithContext(context.Background(), `"still alive"`)
+	s.NoError(err)
+	s.Equal("still alive", value.String())
+}
+
+func (s *CellTestSuite) TestCellExecFile() {
+	dir, err := ioutil.TempDir("", "jail-exec-file")
+	s.Require().NoError(err)
+	defer os.RemoveAll(dir) //nolint: errcheck
+
+	writeFile := func(name, src string) string {
+		path := filepath.Join(dir, name)
+		s.Require().NoError(ioutil.WriteFile(path, []byte(src), 0600))
+		return path
+	}
+
+	path := writeFile("bundle.js", "function add(a, b) {\n  return a + b;\n}\n'loaded';\n")
+	value, err := s.cell.ExecFile(path)
+	s.NoError(err)
+	s.Equal("loaded", value.String())
+
+	// function defined by the file is available afterwards
+	result, err := s.cell.Call("add", nil, 1, 2)
+	s.NoError(err)
+	s.Equal("3", result.Value().String())
+
+	// errors are attributed with file name and line
+	path = writeFile("throw.js", "var x = 1;\nundefinedFunction();\n")
+	_, err = s.cell.ExecFile(path)
+	s.Error(err)
+	s.Contains(err.Error(), "ReferenceError")
+	s.Contains(err.Error(), path+":2:")
+
+	path = writeFile("syntax.js", "var x = 1;\nvar = ;\n")
+	_, err = s.cell.ExecFile(path)
+	s.Error(err)
+	s.Contains(err.Error(), path)
+	s.Contains(err.Error(), "Line 2")
+
+	_, err = s.cell.ExecFile(filepath.Join(dir, "missing.js"))
+	s.True(os.IsNotExist(err))
+
+	// panic of a Go function called by the script is reported, instead of a closed loop
+	err = s.cell.Set("fail", func(call otto.FunctionCall) otto.Value {
+		panic("script failure")
+	})
+	s.NoError(err)
+	_, err = s.cell.ExecFile(writeFile("panic.js", "fail();\n"))
+	s.Require().IsType(&PanicError{}, err)
+	s.Equal(ErrTaskPanicked, err.(*PanicError).Cause())
+	s.Contains(err.Error(), "script failure")
+
+	// cell is still usable
+	value, err = s.cell.ExecFile(writeFile("alive.js", "add('still ', 'alive')"))
+	s.NoError(err)
+	s.Equal("still alive", value.String())
+}
+
+func (s *CellTestSuite) TestCellExecFileAfterStop() {
+	path := filepath.Join(os.TempDir(), "jail-exec-file-after-stop.js")
+	s.Require().NoError(ioutil.WriteFile(path, []byte("1"), 0600))
+	defer os.Remove(path) //nolint: errcheck
+
+	s.NoError(s.cell.Stop())
+
+	_, err := s.cell.ExecFile(path)
+	s.Equal(loop.ErrClosed, err)
+}
+
+func (s *CellTestSuite) TestCellTextEncoding() {
+	value, err := s.cell.Run(`new TextDecoder().decode(new TextEncoder().encode("привет"))`)
+	s.NoError(err)
+	s.Equal("привет", value.Value().String())
+}
+
+func (s *CellTestSuite) TestCellWithHandlers() {
+	double := CellHandlerFunc(func(vm *VM, lo *Loop) error {
+		return vm.Set("double", func(call otto.FunctionCall) otto.Value {
+			n, _ := call.Argument(0).ToInteger()
+			v, _ := call.Otto.ToValue(n * 2)
+			return v
+		})
+	})
+	// handlers are registered after the built-ins
+	wrapFetch := CellHandlerFunc(func(vm *VM, lo *Loop) error {
+		_, err := vm.Run(`var originalFetch = fetch;`)
+		return err
+	})
+
+	cell, err := NewCellWithHandlers("testCellHandlers", double, wrapFetch)
+	s.NoError(err)
+	defer cell.Stop() //nolint: errcheck
+
+	value, err := cell.Run(`double(21)`)
+	s.NoError(err)
+	s.Equal("42", value.Value().String())
+
+	value, err = cell.Run(`typeof originalFetch`)
+	s.NoError(err)
+	s.Equal("function", value.Value().String())
+
+	failing := CellHandlerFunc(func(vm *VM, lo *Loop) error {
+		return errors.New("failed to define")
+	})
+	_, err = NewCellWithHandlers("testCellFailingHandler", failing)
+	s.EqualError(err, "failed to define")
+}
+
+func (s *CellTestSuite) TestCellStopGracefully() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		w.Write([]byte("hello")) //nolint: errcheck
+	}))
+	defer server.Close()
+
+	received := make(chan string, 1)
+	err := s.cell.Set("__capture", func(call otto.FunctionCall) otto.Value {
+		received <- call.Argument(0).String()
+		return otto.UndefinedValue()
+	})
+	s.NoError(err)
+
+	_, err = s.cell.Run(`fetch("` + server.URL + `").then(function(r) {
+		return r.text();
+	}).then(__capture)`)
+	s.NoError(err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	s.NoError(s.cell.StopGracefully(ctx))
+
+	select {
+	case data := <-received:
+		s.Equal("hello", data)
+	default:
+		s.Fail("cell stopped before fetch callback has run")
+	}
+}
+
+func (s *CellTestSuite) TestCellStopGracefullyDeadline() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer server.Close()
+
+	_, err := s.cell.Run(`fetch("` + server.URL + `")`)
+	s.NoError(err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	s.Equal(context.DeadlineExceeded, s.cell.StopGracefully(ctx))
+}
+
+func (s *CellTestSuite) TestCellStopTimeout() {
+	cell, err := NewCell("testCellStopTimeout")
+	s.NoError(err)
+
+	_, err = cell.Run(`function busy() { var start = Date.now(); while (Date.now() - start < 1500) {} }`)
+	s.NoError(err)
+	fn, err := cell.Get("busy")
+	s.NoError(err)
+	s.NoError(cell.CallAsync(fn.Value()))
+
+	// let the loop pick the call up
+	time.Sleep(100 * time.Millisecond)
+
+	err = cell.Stop()
+	s.Equal(ErrCellStopTimeout, err)
+}
+
+func (s *CellTestSuite) TestCellRecoversFromTaskPanic() {
+	cell, err := NewCell("testCellRecoversFromTaskPanic")
+	s.NoError(err)
+	defer cell.Stop() // nolint: errcheck
+
+	// Go function panicking with a non-JS error can't be recovered by the VM
+	err = cell.Set("fail", func(call otto.FunctionCall) otto.Value {
+		panic("loop failure")
+	})
+	s.NoError(err)
+	fn, err := cell.Get("fail")
+	s.NoError(err)
+
+	// panic is reported to the caller instead of timing out
+	_, err = cell.CallSync(fn.Value(), time.Second)
+	s.Require().IsType(&PanicError{}, err)
+	s.Equal(ErrTaskPanicked, err.(*PanicError).Cause())
+
+	errc := make(chan error, 1)
+	s.NoError(cell.CallAsyncWithError(fn.Value(), func(err error) {
+		errc <- err
+	}))
+	select {
+	case err := <-errc:
+		s.Require().IsType(&PanicError{}, err)
+		s.Equal(ErrTaskPanicked, err.(*PanicError).Cause())
+	case <-time.After(time.Second):
+		s.Fail("panic is expected to be passed to onErr")
+	}
+
+	// subsequent tasks are still executed by the loop
+	_, err = cell.Run(`function add(a, b) { return a + b }`)
+	s.NoError(err)
+	add, err := cell.Get("add")
+	s.NoError(err)
+	value, err := cell.CallSync(add.Value(), time.Second, 1, 1)
+	s.NoError(err)
+	s.Equal("2", value.String())
+
+	done := make(chan struct{})
+	err = cell.Set("done", func(call otto.FunctionCall) otto.Value {
+		close(done)
+		return otto.UndefinedValue()
+	})
+	s.NoError(err)
+	doneFn, err := cell.Get("done")
+	s.NoError(err)
+	s.NoError(cell.CallAsync(doneFn.Value()))
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		s.Fail("call is expected to be executed")
+	}
+
+	select {
+	case <-cell.Done():
+		s.Fail("loop is not expected to be stopped")
+	default:
+	}
+	s.NoError(cell.Err())
+}
+
+// cancelPanicTask is a loop task failing on execution and panicking on cancellation.
+type cancelPanicTask struct {
+	id int64
+}
+
+func (t *cancelPanicTask) SetID(id int64) { t.id = id }
+func (t *cancelPanicTask) GetID() int64   { return t.id }
+func (t *cancelPanicTask) Cancel()        { panic("loop failure") }
+func (t *cancelPanicTask) Execute(*VM, *Loop) error {
+	return errors.New("task failure")
+}
+
+func (s *CellTestSuite) TestCellDoneOnLoopError() {
+	var lo *Loop
+	cell, err := NewCellWithHandlers("testCellDoneOnLoopError", CellHandlerFunc(func(_ *VM, l *Loop) error {
+		lo = l
+		return nil
+	}))
+	s.NoError(err)
+
+	s.NoError(cell.Err())
+	select {
+	case <-cell.Done():
+		s.Fail("loop is not expected to be stopped")
+	default:
+	}
+
+	// panics of tasks are recovered, panics outside of them stop the loop
+	s.NoError(lo.AddAndExecute(&cancelPanicTask{}))
+
+	select {
+	case <-cell.Done():
+	case <-time.After(time.Second):
+		s.FailNow("loop is expected to be stopped")
+	}
+
+	err = cell.Err()
+	s.Require().IsType(&CellError{}, err)
+	s.Equal("testCellDoneOnLoopError", err.(*CellError).CellID)
+	s.Require().IsType(&PanicError{}, err.(*CellError).Cause())
+	s.Equal(ErrLoopPanicked, err.(*CellError).Cause().(*PanicError).Cause())
+	s.Contains(err.Error(), "loop failure")
+	s.Equal(err, cell.Stop())
+}
+
+func (s *CellTestSuite) TestCellDoneOnStop() {
+	s.NoError(s.cell.Stop())
+
+	select {
+	case <-s.cell.Done():
+	default:
+		s.Fail("loop is expected to be stopped")
+	}
+	s.NoError(s.cell.Err())
+}
+
 func (s *CellTestSuite) TestCellCallStopMultipleTimes() {
 	s.NotPanics(func() {
 		err := s.cell.Stop()
@@ -253,3 +1116,130 @@ func (s *CellTestSuite) TestCellCallStopMultipleTimes() {
 		s.NoError(err)
 	})
 }
+
+func (s *CellTestSuite) TestCellClone() {
+	_, err := s.cell.Run(`
+		var counter = 1;
+		var state = {name: "parent", items: [1, 2]};
+		var circular = {};
+		circular.self = circular;
+		function helper() { return 42; }
+	`)
+	s.NoError(err)
+
+	clone, err := s.cell.Clone("testCell1Clone")
+	s.NoError(err)
+	defer clone.Stop() //nolint: errcheck
+
+	value, err := clone.Run(`JSON.stringify([counter, state, typeof circular, typeof helper, typeof fetch])`)
+	s.NoError(err)
+	s.Equal(`[1,{"items":[1,2],"name":"parent"},"undefined","undefined","function"]`, value.Value().String())
+
+	// changes don't propagate in either direction
+	_, err = clone.Run(`counter = 2; state.name = "clone"`)
+	s.NoError(err)
+	_, err = s.cell.Run(`counter = 3`)
+	s.NoError(err)
+
+	value, err = s.cell.Run(`counter + "," + state.name`)
+	s.NoError(err)
+	s.Equal("3,parent", value.Value().String())
+
+	value, err = clone.Run(`counter + "," + state.name`)
+	s.NoError(err)
+	s.Equal("2,clone", value.Value().String())
+}
+
+func (s *CellTestSuite) TestCellIdleTimeout() {
+	s.cell.SetIdleTimeout(50 * time.Millisecond)
+
+	select {
+	case <-s.cell.Done():
+		s.NoError(s.cell.Err())
+	case <-time.After(time.Second):
+		s.Fail("idle cell is not stopped")
+	}
+}
+
+func (s *CellTestSuite) TestCellIdleTimeoutResetByActivity() {
+	s.cell.SetIdleTimeout(100 * time.Millisecond)
+
+	// calls keep the cell alive
+	for i := 0; i < 10; i++ {
+		_, err := s.cell.Run(`1 + 1`)
+		s.NoError(err)
+		time.Sleep(30 * time.Millisecond)
+	}
+	select {
+	case <-s.cell.Done():
+		s.Fail("active cell is stopped")
+	default:
+	}
+
+	// pending timers keep the cell alive
+	_, err := s.cell.Run(`setTimeout(function() {}, 300)`)
+	s.NoError(err)
+	time.Sleep(200 * time.Millisecond)
+	select {
+	case <-s.cell.Done():
+		s.Fail("cell with pending timer is stopped")
+	default:
+	}
+
+	select {
+	case <-s.cell.Done():
+	case <-time.After(time.Second):
+		s.Fail("idle cell is not stopped")
+	}
+}
+
+func (s *CellTestSuite) TestCellIdleTimeoutDisabled() {
+	s.cell.SetIdleTimeout(20 * time.Millisecond)
+	s.cell.SetIdleTimeout(0)
+
+	select {
+	case <-s.cell.Done():
+		s.Fail("cell is stopped with idle timeout disabled")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func (s *CellTestSuite) TestCellReset() {
+	ticks := make(chan struct{}, 100)
+	err := s.cell.Set("tick", func(otto.FunctionCall) otto.Value {
+		ticks <- struct{}{}
+		return otto.UndefinedValue()
+	})
+	s.NoError(err)
+
+	_, err = s.cell.Run(`
+		var counter = 1;
+		setInterval(function() { tick(); }, 10);
+	`)
+	s.NoError(err)
+
+	select {
+	case <-ticks:
+	case <-time.After(time.Second):
+		s.Fail("interval didn't fire")
+	}
+
+	s.NoError(s.cell.Reset())
+	s.Equal(0, s.cell.Stats().PendingTasks)
+
+	value, err := s.cell.Run(`typeof counter + "," + typeof tick`)
+	s.NoError(err)
+	s.Equal("undefined,undefined", value.Value().String())
+
+	// drain ticks which could be sent right before the reset
+	for len(ticks) > 0 {
+		<-ticks
+	}
+	time.Sleep(100 * time.Millisecond)
+	s.Len(ticks, 0, "interval fired after reset")
+
+	// handlers are still available
+	value, err = s.cell.Run(`typeof setTimeout + "," + typeof fetch + "," + typeof localStorage`)
+	s.NoError(err)
+	s.Equal("function,function,object", value.Value().String())
+}
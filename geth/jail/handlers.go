@@ -1,11 +1,13 @@
 package jail
 
 import (
+	"fmt"
 	"os"
 
 	"github.com/robertkrimen/otto"
 	"github.com/status-im/status-go/geth/jail/console"
 	"github.com/status-im/status-go/geth/signal"
+	"github.com/status-im/status-go/geth/transactions"
 )
 
 const (
@@ -13,10 +15,63 @@ const (
 	EventSignal = "jail.signal"
 	// eventConsoleLog defines the event type for the console.log call.
 	eventConsoleLog = "vm.console.log"
+
+	// providerErrorUserRejected is an EIP-1193 error code of requests rejected by the user.
+	providerErrorUserRejected = 4001
+	// providerErrorUnauthorized is an EIP-1193 error code of requests for methods
+	// which are not authorized, e.g. rejected by RPCMethodFilter.
+	providerErrorUnauthorized = 4100
+	// providerErrorInternal is an EIP-1193 error code of requests failed for any other reason.
+	providerErrorInternal = -32603
+
+	// rpcErrorParse is a code of JSON-RPC parse error, which rpc.Client also uses
+	// for errors of locally handled methods.
+	rpcErrorParse = -32700
 )
 
+// providerRequestJS defines jeth.request(), an EIP-1193 request method built on
+// top of jeth.sendAsync(). Returned promise is resolved with the result, or rejected
+// with an error having code and data properties.
+var providerRequestJS = fmt.Sprintf(`(function(jeth) {
+	var id = 0;
+
+	function providerError(code, message, data) {
+		var err = new Error(message);
+		err.code = code;
+		if (data !== undefined) {
+			err.data = data;
+		}
+		return err;
+	}
+
+	jeth.request = function(args) {
+		return new Promise(function(resolve, reject) {
+			if (!args || typeof args.method !== 'string') {
+				throw providerError(%[2]d, 'method is required');
+			}
+
+			var payload = {jsonrpc: '2.0', id: ++id, method: args.method, params: args.params || []};
+			jeth.sendAsync(payload, function(err, response) {
+				if (err) {
+					reject(providerError(%[2]d, err.message));
+				} else if (response.error) {
+					var code = response.error.code;
+					if (response.error.message === %[3]q) {
+						code = %[1]d;
+					} else if (typeof code !== 'number' || code === %[4]d) {
+						code = %[2]d;
+					}
+					reject(providerError(code, response.error.message, response.error.data));
+				} else {
+					resolve(response.result);
+				}
+			});
+		});
+	};
+})(jeth)`, providerErrorUserRejected, providerErrorInternal, transactions.ErrQueuedTxDiscarded.Error(), rpcErrorParse)
+
 // registerWeb3Provider creates an object called "jeth",
-// which is a web3.js provider.
+// which is a web3.js provider, as well as an EIP-1193 one.
 func registerWeb3Provider(jail *Jail, cell *Cell) error {
 	jeth := map[string]interface{}{
 		"console": map[string]interface{}{
@@ -29,7 +84,12 @@ func registerWeb3Provider(jail *Jail, cell *Cell) error {
 		"isConnected": createIsConnectedHandler(jail),
 	}
 
-	return cell.jsvm.Set("jeth", jeth)
+	if err := cell.jsvm.Set("jeth", jeth); err != nil {
+		return err
+	}
+
+	_, err := cell.jsvm.Run(providerRequestJS)
+	return err
 }
 
 // registerStatusSignals creates an object called "statusSignals".
@@ -42,7 +102,9 @@ func registerStatusSignals(cell *Cell) error {
 	return cell.jsvm.Set("statusSignals", statusSignals)
 }
 
-// createSendHandler returns jeth.send().
+// createSendHandler returns jeth.send(). Payload may be a single JSON-RPC request
+// or a batch (array) of them, in which case an array of responses is returned,
+// in the order of requests, including the ones failed.
 func createSendHandler(jail *Jail, cell *Cell) func(call otto.FunctionCall) otto.Value {
 	return func(call otto.FunctionCall) otto.Value {
 		// As it's a sync call, it's called already from a thread-safe context,
@@ -69,7 +131,8 @@ func createSendHandler(jail *Jail, cell *Cell) func(call otto.FunctionCall) otto
 	}
 }
 
-// createSendAsyncHandler returns jeth.sendAsync() handler.
+// createSendAsyncHandler returns jeth.sendAsync() handler. Batch payloads are
+// handled the same way jeth.send() handles them.
 func createSendAsyncHandler(jail *Jail, cell *Cell) func(call otto.FunctionCall) otto.Value {
 	return func(call otto.FunctionCall) otto.Value {
 		// As it's a sync call, it's called already from a thread-safe context,
@@ -1,6 +1,8 @@
 package jail
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -15,6 +17,7 @@ import (
 	"github.com/status-im/status-go/geth/params"
 	"github.com/status-im/status-go/geth/rpc"
 	"github.com/status-im/status-go/geth/signal"
+	"github.com/status-im/status-go/geth/transactions"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -99,6 +102,160 @@ func (s *HandlersTestSuite) TestWeb3SendAsyncHandlerSuccess() {
 	s.Equal(`true`, <-resultc)
 }
 
+func (s *HandlersTestSuite) TestWeb3CurrentProviderBlockNumber() {
+	s.responseFixture = `{"jsonrpc":"2.0","id":1,"result":"0x2a"}`
+
+	client, err := rpc.NewClient(s.client, params.UpstreamRPCConfig{})
+	s.NoError(err)
+
+	jail := New(&testRPCClientProvider{client})
+
+	cell, _, err := jail.createAndInitCell("cell1")
+	s.NoError(err)
+
+	value, err := cell.Run(`web3.currentProvider === jeth`)
+	s.NoError(err)
+	s.Equal("true", value.Value().String())
+
+	resultc := make(chan string)
+	err = cell.Set("__blockNumberCallback", func(call otto.FunctionCall) otto.Value {
+		resultc <- call.Argument(0).String() + "," + call.Argument(1).String()
+		return otto.UndefinedValue()
+	})
+	s.NoError(err)
+
+	_, err = cell.Run(`web3.eth.getBlockNumber(__blockNumberCallback)`)
+	s.NoError(err)
+
+	select {
+	case result := <-resultc:
+		s.Equal("null,42", result)
+	case <-time.After(time.Second):
+		s.Fail("test timed out")
+	}
+	s.Equal(int32(1), atomic.LoadInt32(&s.tsCalls))
+}
+
+func (s *HandlersTestSuite) TestProviderRequest() {
+	client, err := rpc.NewClient(s.client, params.UpstreamRPCConfig{})
+	s.NoError(err)
+
+	jail := New(&testRPCClientProvider{client})
+
+	cell, _, err := jail.createAndInitCell("cell1")
+	s.NoError(err)
+
+	resultc := make(chan string)
+	err = cell.Set("__capture", func(call otto.FunctionCall) otto.Value {
+		resultc <- call.Argument(0).String()
+		return otto.UndefinedValue()
+	})
+	s.NoError(err)
+
+	request := func() string {
+		_, err := cell.Run(`jeth.request({method: 'eth_blockNumber'}).then(function(result) {
+			__capture('result:' + result);
+		}, function(err) {
+			__capture('error:' + err.code + ':' + err.message);
+		})`)
+		s.NoError(err)
+
+		select {
+		case result := <-resultc:
+			return result
+		case <-time.After(time.Second):
+			s.Fail("test timed out")
+			return ""
+		}
+	}
+
+	s.responseFixture = `{"jsonrpc":"2.0","id":1,"result":"0x2a"}`
+	s.Equal("result:0x2a", request())
+
+	s.responseFixture = `{"jsonrpc":"2.0","id":1,"error":{"code":-32000,"message":"header not found"}}`
+	s.Equal("error:-32000:header not found", request())
+
+	s.responseFixture = `{"jsonrpc":"2.0","id":1,"error":{"code":-32700,"message":"` +
+		transactions.ErrQueuedTxDiscarded.Error() + `"}}`
+	s.Equal(fmt.Sprintf("error:%d:%s", providerErrorUserRejected, transactions.ErrQueuedTxDiscarded), request())
+
+	s.responseFixture = `{"jsonrpc":"2.0","id":1,"error":{"code":-32700,"message":"local failure"}}`
+	s.Equal(fmt.Sprintf("error:%d:local failure", providerErrorInternal), request())
+}
+
+func (s *HandlersTestSuite) TestProviderRequestFailure() {
+	jail := New(nil)
+
+	cell, _, err := jail.createAndInitCell("cell1")
+	s.NoError(err)
+
+	resultc := make(chan string)
+	err = cell.Set("__capture", func(call otto.FunctionCall) otto.Value {
+		resultc <- call.Argument(0).String()
+		return otto.UndefinedValue()
+	})
+	s.NoError(err)
+
+	_, err = cell.Run(`jeth.request({method: 'eth_blockNumber'}).catch(function(err) {
+		__capture(err.code + ':' + err.message);
+	})`)
+	s.NoError(err)
+
+	select {
+	case result := <-resultc:
+		s.Equal(fmt.Sprintf("%d:%s", providerErrorInternal, ErrNoRPCClient), result)
+	case <-time.After(time.Second):
+		s.Fail("test timed out")
+	}
+}
+
+func (s *HandlersTestSuite) TestWeb3SendBatch() {
+	s.responseFixture = `{"jsonrpc":"2.0","id":1,"result":"0x2a"}`
+
+	client, err := rpc.NewClient(s.client, params.UpstreamRPCConfig{})
+	s.NoError(err)
+	client.RegisterHandler("eth_failing", func(context.Context, ...interface{}) (interface{}, error) {
+		return nil, errors.New("failed")
+	})
+
+	jail := New(&testRPCClientProvider{client})
+
+	cell, _, err := jail.createAndInitCell("cell1")
+	s.NoError(err)
+
+	batch := `[
+		{"jsonrpc": "2.0", "id": 1, "method": "eth_failing", "params": []},
+		{"jsonrpc": "2.0", "id": 2, "method": "eth_blockNumber", "params": []}
+	]`
+	expected := `[{"error":{"code":-32700,"message":"failed"},"id":1,"jsonrpc":"2.0"},` +
+		`{"id":2,"jsonrpc":"2.0","result":"0x2a"}]`
+
+	// sync
+	value, err := cell.Run(`JSON.stringify(jeth.send(` + batch + `))`)
+	s.NoError(err)
+	s.Equal(expected, value.Value().String())
+
+	// async
+	resultc := make(chan string)
+	err = cell.Set("__capture", func(call otto.FunctionCall) otto.Value {
+		resultc <- call.Argument(0).String()
+		return otto.UndefinedValue()
+	})
+	s.NoError(err)
+
+	_, err = cell.Run(`jeth.sendAsync(` + batch + `, function(err, response) {
+		__capture(err ? err.message : JSON.stringify(response));
+	})`)
+	s.NoError(err)
+
+	select {
+	case result := <-resultc:
+		s.Equal(expected, result)
+	case <-time.After(time.Second):
+		s.Fail("test timed out")
+	}
+}
+
 func (s *HandlersTestSuite) TestWeb3SendAsyncHandlerWithoutCallbackSuccess() {
 	client, err := rpc.NewClient(s.client, params.UpstreamRPCConfig{})
 	s.NoError(err)
@@ -186,3 +343,64 @@ func (s *HandlersTestSuite) TestSendSignalHandler() {
 	s.NoError(err)
 	s.True(resultBool)
 }
+
+func (s *HandlersTestSuite) TestRPCMethodFilter() {
+	s.responseFixture = `{"jsonrpc":"2.0","id":1,"result":"0x2a"}`
+
+	client, err := rpc.NewClient(s.client, params.UpstreamRPCConfig{})
+	s.NoError(err)
+
+	jail := New(&testRPCClientProvider{client})
+	jail.SetRPCMethodFilter(RPCMethodFilter{
+		Allow: []string{"eth_", "net_version"},
+		Block: []string{"eth_sendTransaction"},
+	})
+
+	cell, _, err := jail.createAndInitCell("cell1")
+	s.NoError(err)
+
+	resultc := make(chan string)
+	err = cell.Set("__capture", func(call otto.FunctionCall) otto.Value {
+		resultc <- call.Argument(0).String()
+		return otto.UndefinedValue()
+	})
+	s.NoError(err)
+
+	request := func(method string) string {
+		_, err := cell.Run(`jeth.request({method: '` + method + `'}).then(function(result) {
+			__capture('result:' + result);
+		}, function(err) {
+			__capture('error:' + err.code + ':' + err.message);
+		})`)
+		s.NoError(err)
+
+		select {
+		case result := <-resultc:
+			return result
+		case <-time.After(time.Second):
+			s.Fail("test timed out")
+			return ""
+		}
+	}
+
+	// allowlisted method passes through
+	s.Equal("result:0x2a", request("eth_blockNumber"))
+	s.Equal(int32(1), atomic.LoadInt32(&s.tsCalls))
+
+	// blocklisted and not allowlisted methods are rejected without hitting the node
+	s.Equal(fmt.Sprintf("error:%d:method is not allowed: eth_sendTransaction", providerErrorUnauthorized),
+		request("eth_sendTransaction"))
+	s.Equal(fmt.Sprintf("error:%d:method is not allowed: admin_peers", providerErrorUnauthorized),
+		request("admin_peers"))
+	s.Equal(int32(1), atomic.LoadInt32(&s.tsCalls))
+
+	// rejected requests of a batch are replaced in place
+	value, err := cell.Run(`JSON.stringify(jeth.send([
+		{"jsonrpc": "2.0", "id": 1, "method": "admin_peers", "params": []},
+		{"jsonrpc": "2.0", "id": 2, "method": "eth_blockNumber", "params": []}
+	]))`)
+	s.NoError(err)
+	s.Equal(fmt.Sprintf(`[{"error":{"code":%d,"message":"method is not allowed: admin_peers"},"id":1,"jsonrpc":"2.0"},`+
+		`{"id":2,"jsonrpc":"2.0","result":"0x2a"}]`, providerErrorUnauthorized), value.Value().String())
+	s.Equal(int32(2), atomic.LoadInt32(&s.tsCalls))
+}
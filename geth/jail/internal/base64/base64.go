@@ -0,0 +1,87 @@
+package base64
+
+import (
+	b64 "encoding/base64"
+	"strings"
+
+	"github.com/robertkrimen/otto"
+
+	"github.com/status-im/status-go/geth/jail/internal/vm"
+)
+
+// Define jail btoa and atob functions
+func Define(vm *vm.VM) error {
+	if v, err := vm.Get("btoa"); err != nil {
+		return err
+	} else if !v.IsUndefined() {
+		return nil
+	}
+
+	if err := vm.Set("btoa", btoa); err != nil {
+		return err
+	}
+
+	return vm.Set("atob", atob)
+}
+
+// btoa encodes a string, each character of which represents a byte, to base64.
+func btoa(call otto.FunctionCall) otto.Value {
+	if len(call.ArgumentList) == 0 {
+		panic(call.Otto.MakeTypeError("Failed to execute 'btoa': 1 argument required, but only 0 present."))
+	}
+
+	input := call.Argument(0).String()
+	data := make([]byte, 0, len(input))
+	for _, r := range input {
+		if r > 0xff {
+			panic(call.Otto.MakeCustomError("InvalidCharacterError",
+				"Failed to execute 'btoa': The string to be encoded contains characters outside of the Latin1 range."))
+		}
+		data = append(data, byte(r))
+	}
+
+	return toValue(call, b64.StdEncoding.EncodeToString(data))
+}
+
+// atob decodes base64 encoded string to a string, each character of which represents a byte.
+func atob(call otto.FunctionCall) otto.Value {
+	if len(call.ArgumentList) == 0 {
+		panic(call.Otto.MakeTypeError("Failed to execute 'atob': 1 argument required, but only 0 present."))
+	}
+
+	// ASCII whitespace is ignored
+	input := strings.Map(func(r rune) rune {
+		switch r {
+		case '\t', '\n', '\f', '\r', ' ':
+			return -1
+		}
+		return r
+	}, call.Argument(0).String())
+
+	if len(input)%4 == 0 {
+		input = strings.TrimSuffix(input, "=")
+		input = strings.TrimSuffix(input, "=")
+	}
+
+	data, err := b64.RawStdEncoding.DecodeString(input)
+	if err != nil || len(input)%4 == 1 {
+		panic(call.Otto.MakeCustomError("InvalidCharacterError",
+			"Failed to execute 'atob': The string to be decoded is not correctly encoded."))
+	}
+
+	runes := make([]rune, len(data))
+	for i, b := range data {
+		runes[i] = rune(b)
+	}
+
+	return toValue(call, string(runes))
+}
+
+func toValue(call otto.FunctionCall, s string) otto.Value {
+	v, err := call.Otto.ToValue(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return v
+}
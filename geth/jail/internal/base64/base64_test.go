@@ -0,0 +1,73 @@
+package base64_test
+
+import (
+	"testing"
+
+	"github.com/status-im/status-go/geth/jail/internal/base64"
+	"github.com/status-im/status-go/geth/jail/internal/vm"
+	"github.com/stretchr/testify/suite"
+)
+
+func (s *Base64Suite) TestRoundTrip() {
+	v, err := s.vm.Run(`btoa("Hello, world!")`)
+	s.NoError(err)
+	s.Equal("SGVsbG8sIHdvcmxkIQ==", v.String())
+
+	v, err = s.vm.Run(`atob(btoa("Hello, world!"))`)
+	s.NoError(err)
+	s.Equal("Hello, world!", v.String())
+
+	// binary strings
+	v, err = s.vm.Run(`atob(btoa("\x00\xff\xe9")) === "\x00\xff\xe9"`)
+	s.NoError(err)
+	s.Equal("true", v.String())
+}
+
+func (s *Base64Suite) TestBtoaOutOfRange() {
+	v, err := s.vm.Run(`
+		try {
+			btoa("Ā");
+		} catch (e) {
+			e.name
+		}
+	`)
+	s.NoError(err)
+	s.Equal("InvalidCharacterError", v.String())
+}
+
+func (s *Base64Suite) TestAtobLenient() {
+	v, err := s.vm.Run(`[atob("SGk"), atob(" SG k= \n")].join(",")`)
+	s.NoError(err)
+	s.Equal("Hi,Hi", v.String())
+}
+
+func (s *Base64Suite) TestAtobMalformed() {
+	for _, input := range []string{`"SGk*"`, `"S"`, `"SGk=="`, `"=SGk"`} {
+		v, err := s.vm.Run(`
+			try {
+				atob(` + input + `);
+			} catch (e) {
+				e.name
+			}
+		`)
+		s.NoError(err)
+		s.Equal("InvalidCharacterError", v.String(), input)
+	}
+}
+
+type Base64Suite struct {
+	suite.Suite
+
+	vm *vm.VM
+}
+
+func (s *Base64Suite) SetupTest() {
+	s.vm = vm.New()
+
+	err := base64.Define(s.vm)
+	s.NoError(err)
+}
+
+func TestBase64Suite(t *testing.T) {
+	suite.Run(t, new(Base64Suite))
+}
@@ -0,0 +1,122 @@
+package fetch
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/robertkrimen/otto"
+)
+
+// binarySrc adds arrayBuffer and blob methods to Response. As there's no ArrayBuffer
+// in the VM, arrayBuffer resolves with Uint8Array of the body bytes, which has no buffer
+// and subarray, see typedarray.Define.
+// Blob data is a binary string, holding a byte in every character, it's made from
+// the bytes only when blob is called.
+//
+// Request bodies which are typed arrays or arrays of byte values are sent as raw bytes,
+// see encodeBinaryBody.
+const binarySrc = `'use strict';
+
+// __fetch_binary_format returns the format of elements of an array-like body (a typed
+// array or an array of byte values), or null if the body is not array-like.
+function __fetch_binary_format(body) {
+  if (body === null || typeof body !== 'object' || typeof body.length !== 'number' ||
+      body instanceof FormData) {
+    return null;
+  }
+
+  return {
+    size: body.BYTES_PER_ELEMENT || 1,
+    float: body instanceof Float32Array || body instanceof Float64Array
+  };
+}
+
+Response.prototype.__binary = function() {
+  if (typeof this.__binaryBody === 'function') {
+    return this.__binaryBody();
+  }
+
+  var data = String(this._body === null || this._body === undefined ? '' : this._body);
+  var bytes = new Uint8Array(data.length);
+  for (var i = 0; i < data.length; i++) {
+    bytes[i] = data.charCodeAt(i) & 0xff;
+  }
+  return bytes;
+};
+
+Response.prototype.arrayBuffer = function() {
+  var self = this;
+  return new Promise(function(resolve) {
+    resolve(self.__binary());
+  });
+};
+
+Response.prototype.blob = function() {
+  var self = this;
+  return new Promise(function(resolve) {
+    var bytes = self.__binary();
+    var data = '';
+    // characters are made from chunks of bytes, to keep arguments of fromCharCode short
+    for (var i = 0; i < bytes.length; i += 4096) {
+      data += String.fromCharCode.apply(null, Array.prototype.slice.call(bytes, i, i + 4096));
+    }
+    resolve(new Blob([data], {type: self.headers.get('content-type') || ''}));
+  });
+};
+`
+
+// encodeBinaryBody encodes elements of an array-like body the way they're laid out in
+// memory by typed arrays: size bytes per element, in little-endian order. Float elements
+// are encoded as IEEE 754 numbers, other ones are truncated to integers.
+func encodeBinaryBody(body, format *otto.Object) ([]byte, error) {
+	size, err := mustValue(format.Get("size")).ToInteger()
+	if err != nil {
+		return nil, err
+	}
+	float, err := mustValue(format.Get("float")).ToBoolean()
+	if err != nil {
+		return nil, err
+	}
+	if size != 1 && size != 2 && size != 4 && size != 8 {
+		return nil, fmt.Errorf("invalid size of body elements: %d", size)
+	}
+
+	length, err := mustValue(body.Get("length")).ToInteger()
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]byte, 0, length*size)
+	element := make([]byte, 8)
+	for i := int64(0); i < length; i++ {
+		v, err := mustValue(body.Get(strconv.FormatInt(i, 10))).ToFloat()
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case float && size == 4:
+			binary.LittleEndian.PutUint32(element, math.Float32bits(float32(v)))
+		case float:
+			binary.LittleEndian.PutUint64(element, math.Float64bits(v))
+		case math.IsNaN(v) || math.IsInf(v, 0):
+			binary.LittleEndian.PutUint64(element, 0)
+		default:
+			binary.LittleEndian.PutUint64(element, uint64(int64(v)))
+		}
+		data = append(data, element[:size]...)
+	}
+
+	return data, nil
+}
+
+// binaryBodyFunc returns a function passing binary body of a response to JS on demand,
+// as Uint8Array made from the bytes, so that the body isn't copied unless arrayBuffer
+// or blob is called.
+func binaryBodyFunc(body []byte) func(otto.FunctionCall) otto.Value {
+	return func(c otto.FunctionCall) otto.Value {
+		return mustValue(c.Otto.Call("new Uint8Array", nil, body))
+	}
+}
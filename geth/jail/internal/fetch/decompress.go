@@ -0,0 +1,73 @@
+package fetch
+
+import (
+	"bufio"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// acceptEncoding is sent with every request. It's set explicitly, so that the
+// client doesn't decompress response on its own, see Options.DisableDecompression.
+const acceptEncoding = "gzip, deflate"
+
+// decompressedBody reads decompressed response body, and closes the original one.
+type decompressedBody struct {
+	io.Reader
+	body io.Closer
+}
+
+func (b *decompressedBody) Close() error {
+	return b.body.Close()
+}
+
+// decompressResponse replaces gzip or deflate encoded response body with
+// a decoded one, and removes headers describing the encoded body.
+// Responses with other encodings are left as is.
+func decompressResponse(res *http.Response) error {
+	var (
+		r   io.Reader
+		err error
+	)
+
+	switch strings.ToLower(strings.TrimSpace(res.Header.Get("Content-Encoding"))) {
+	case "gzip", "x-gzip":
+		r, err = gzip.NewReader(res.Body)
+	case "deflate":
+		r, err = newDeflateReader(res.Body)
+	default:
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	res.Body = &decompressedBody{Reader: r, body: res.Body}
+	res.Header.Del("Content-Encoding")
+	res.Header.Del("Content-Length")
+	res.ContentLength = -1
+	res.Uncompressed = true
+
+	return nil
+}
+
+// newDeflateReader decodes deflate encoded body. HTTP defines deflate as zlib
+// format, but some servers send raw deflate data, which browsers accept as well.
+func newDeflateReader(body io.Reader) (io.Reader, error) {
+	br := bufio.NewReader(body)
+
+	header, err := br.Peek(2)
+	if err != nil {
+		return nil, err
+	}
+
+	// zlib header: compression method 8 (deflate), and checksum of the two bytes
+	if header[0]&0x0f == 8 && (uint16(header[0])<<8|uint16(header[1]))%31 == 0 {
+		return zlib.NewReader(br)
+	}
+
+	return flate.NewReader(br), nil
+}
@@ -69,7 +69,7 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var _distFetchBundleJs = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x5a\xeb\x73\xdb\xb8\x11\xff\x6c\xfd\x15\xeb\xb4\x13\x92\x3e\x1d\xe9\x24\xf7\x1a\x33\xec\x4d\xce\xe3\x36\xb9\xcb\x25\x99\xd4\xd7\x7e\xd0\x79\x38\x30\x09\x49\x8c\x29\x92\x01\x40\x3f\x46\x51\xff\xf6\x0e\x5e\x24\x40\x52\xf4\x23\xf6\x34\xd7\x39\x7d\x11\x85\x7d\xfd\x76\x17\x58\x2c\x40\x05\x7b\xe2\x13\x80\x3b\xaf\x8b\x84\x65\x65\xe1\xae\xca\xb4\xce\x31\xf5\x60\x0d\x41\x00\x17\xf8\xb4\x42\xc9\xd9\x4f\x65\xc9\x28\x23\xa8\x9a\x34\x12\x3b\x41\x00\xc7\x4b\x0c\x92\x1f\x12\x94\x2c\xb1\x41\x3d\x47\x04\xb2\x82\x32\x94\xe7\x38\xfd\x55\xea\x84\x08\xd6\x9b\xb0\x61\xea\xeb\x22\xf8\x63\x9d\x11\x0c\x1a\x8c\xc1\xa1\x87\x20\x8e\x15\xa6\x58\x71\xc7\xb1\xc2\xfc\x2a\xf5\x60\x3d\xa4\x9d\xab\x3f\x5c\xe2\xe4\x0c\xb2\xb9\xc6\x9b\x51\xc8\x8a\x1e\xea\x9d\x6c\xee\x76\x51\xcf\xb4\xf6\x13\xcf\xe4\xdc\x21\x98\xd5\xa4\xe8\x39\xd9\xb2\xfb\xf8\xb2\x2a\x09\xa3\x83\x1e\x0b\x4c\x04\x23\x86\x01\x41\x81\x2f\x34\x2e\x17\x15\x29\x54\x35\x83\x8c\x41\x56\xb0\x12\xd8\x52\x05\xd7\xb2\xce\xc3\xab\x24\xa2\x11\x08\x3c\xe2\x16\x68\x05\xe9\x00\xd6\x9b\xa9\x45\xc8\xd2\x03\xd0\x62\x36\x25\x2f\x51\x8a\xd3\x03\x98\xa3\x9c\x5a\xb1\x1a\x4e\x25\x77\xec\xe8\x12\x27\x35\xc3\x02\xbb\x42\x39\x90\xd2\x9d\x55\x2f\x62\x09\xca\x73\x95\x4d\x1d\xbd\xa9\xd2\xa0\xbf\xdb\xf1\x81\x99\xe0\x6d\x85\xf4\xf7\x1c\x2d\x4c\x3c\x88\x82\x74\xac\x0f\xc8\x97\x04\x88\x80\x91\x1a\x6f\xd5\xf8\x5e\xe6\x9f\xeb\x54\x90\xa0\x9c\x1b\x26\x4c\x76\x35\x57\x6c\x0f\x42\x83\x63\xd3\xb7\x62\x2f\x0f\x2e\x43\xcd\x88\x52\x28\x4f\x3f\xe0\x84\x81\xdb\xc6\x41\x51\xe2\xd8\x9c\x2b\x03\x61\xf2\x57\x10\x69\x35\xdb\xd6\x63\xcf\x60\x6f\xb1\x0c\x29\x4e\x06\xa6\xe3\x36\x0b\xad\x7c\x55\x9f\xe6\x59\x12\x57\x88\x2d\xe3\xf8\x1a\x0b\x15\x44\xf0\xe8\xd1\x36\x9d\xaf\x4b\x94\x02\x2e\x18\xb9\x6a\x12\x5d\xa4\xa0\xc2\xaf\xe2\x6e\x48\x28\xc2\x50\x4d\xd9\x37\xe6\x12\x6c\x9a\x80\x7e\xfe\xc7\x40\xec\xce\x26\xc1\x1e\xec\x83\x18\xdb\xed\xb3\xee\xee\xfd\x3e\x01\xe0\x04\xf0\x83\xac\x48\xf1\xa5\xff\x81\x02\x27\x4c\x00\x7e\xdf\xa6\x3a\x80\x4e\x31\x9f\xc2\xe8\x9a\x81\xf5\x64\xb2\xe3\xd4\x14\x03\x65\x24\x4b\x98\x13\x4e\x76\x26\x43\xb1\x77\x83\xbd\x5d\x35\x2f\x7e\x9c\x63\x96\x2c\x77\xfd\x40\x7c\xc3\x5e\x00\x4f\xbc\xf0\x5a\xa1\x97\x18\xa5\x98\xd0\x5d\x3f\x58\xca\x27\x2e\xf8\xdd\x0d\x04\xdf\xe3\x8f\x35\xa6\x6c\xd7\x0f\x88\x7c\xe2\x82\xdf\xdf\x48\x90\x56\x65\x41\xb1\x90\x94\x8f\x5c\xf4\x07\x2f\x9c\xa8\x58\x89\x3a\x08\x4f\xb6\xe6\x60\xdb\xc7\xca\xcd\x7f\x02\x69\xef\x6b\x51\x3a\x88\x1d\x9d\xf1\x9c\x8d\x4f\x93\x3b\xe5\x32\xd8\x83\x7f\x1f\xfd\xf4\xee\xc5\xe1\x2f\xf0\xaf\x17\xef\xe1\xd5\x9b\x9f\x8f\x0e\x8f\x5f\xbd\x7d\x03\x7b\x41\xbb\xcf\x2f\xf2\xf2\x14\xe5\x1e\xac\xed\xa2\x04\x11\x48\xca\xec\x91\x00\xff\x88\xef\x21\xdb\x82\xfc\xf5\x2e\x77\xfd\x14\x9d\xe2\x5c\x7b\x4e\x19\x5a\xe0\x68\xdf\xf2\x3d\x80\xa7\x3c\x55\x23\xb0\x36\xb2\xf2\x37\xae\xb5\x30\x79\x1f\x42\x74\xa5\xcd\x68\x08\x1b\xd7\xf3\x3c\xcf\xce\xde\xd3\x5b\x67\xaf\x9b\xbe\x6b\x7d\xb8\x75\xfe\xee\x75\x31\xbe\x15\xa5\xde\x4f\xf1\x3c\x2b\xf0\x3b\x52\x56\x98\xb0\xab\x36\x5c\x4e\x1c\x63\x2a\xab\xad\x33\x85\xf5\x64\x07\xe0\x1c\xe5\x35\x3e\x10\x3b\xd8\x64\x67\xc3\xc3\xaf\xb8\x67\x4e\x8a\xe7\xa8\xce\x99\xc3\x33\x2b\x1c\x0c\x27\xa2\x9f\x50\x8b\x6c\x24\xdf\xd6\xf2\x7b\xe6\x35\x72\x6a\x61\x8d\x0b\xb6\xab\xef\x5b\x4f\x38\xd5\xb4\x74\x02\x84\x9b\x15\x55\xcd\xa6\x90\x15\x19\xf3\xb4\x0f\x84\xb7\x84\x10\x89\x06\x49\xc1\xb3\xf8\xc2\x96\x8d\x36\x6c\xd2\x90\x2b\x8d\x80\x9e\x3d\x9c\xf6\x8e\x94\xab\x8c\xe2\x66\x76\x81\x4b\x30\x2d\xf3\x73\x3c\x05\x82\x79\x84\x95\xe1\x46\x28\x8e\x2b\x92\x9d\x23\x86\xe3\x58\x80\x8c\xb1\xec\x6c\x5c\x82\x3f\x72\x19\x3a\x85\x56\x17\x26\xa4\x91\x07\xde\x6a\x76\x46\x1a\xad\xd2\x96\xa0\x86\x9a\xb8\x91\x60\x2d\x2e\x01\x8d\x43\xd4\x6c\x1b\xf9\x20\xbe\x84\x40\x6f\xed\xf6\x93\xdc\x29\x74\xcf\x6e\xbf\x54\x6e\xba\x58\xd4\xdc\xb8\xe3\x72\xf9\xf2\x16\x8c\x31\x43\xe3\x24\x47\x94\x1e\xa2\x3c\x17\xe7\x08\x79\x46\x28\x12\x3c\x85\xc3\xb2\xa0\x8c\xd4\x09\x2b\x79\xaa\x45\xd6\x77\x1b\x32\xe8\x87\x72\x6e\x31\x72\x4e\xb6\x24\xe5\x85\x98\x95\xc7\x57\x15\x3e\x22\xa4\x24\xae\x73\x88\x8a\xa2\x64\xc0\x8b\x21\x20\x10\x46\x79\xa7\x8a\x9a\x98\x38\x5e\x08\x1b\x39\x59\xf8\xb4\x57\x1b\xea\xe8\xca\x33\xb6\xda\x6f\xa4\x5b\xf6\x62\x6f\x9c\xb4\x16\x98\xb1\x04\x63\x82\xe7\x10\x01\x22\x8b\x7a\x85\x0b\x46\xfd\x1c\x17\x0b\xb6\x84\xe7\x11\x3c\x81\x4f\x9f\x5a\xc2\xec\xc9\x09\x44\x51\x04\x75\x21\xe3\x9e\xc2\x8f\xb0\xde\xc0\x81\xc5\xa1\x56\xa5\x38\xc0\x60\xb6\x2c\x79\x9f\xcd\x2d\xf8\xf2\x57\xb3\xa2\x97\xad\x6b\x9c\xaa\x7e\x1a\x0b\x3e\xcd\x08\x6f\x7f\x15\x5d\xff\x6e\x18\x4e\xcb\xf4\x4a\x13\xf9\xb3\xb2\xdb\xcd\x24\xdf\x54\xa6\xda\x75\x5d\x32\xf8\xa0\xdf\xa0\x73\xfe\x71\x74\xec\x84\xcd\x78\x0b\x8c\x27\x4f\x65\xc0\x5d\xab\xb5\x29\x58\x0c\x70\xce\x0a\x15\x35\xca\x0d\x79\x05\xac\xa8\xf3\x5c\x99\xe3\x93\x46\x04\xdd\x9c\x2f\x1a\x92\xae\x1f\x42\xb6\x26\xb9\x68\xb0\xab\x9a\xf1\xe7\xd0\x20\x35\x70\x25\xd5\x88\xe6\x28\x6e\xc9\xad\x68\x9e\xc9\x6f\x38\x21\x99\xac\x10\x6f\x00\xe7\x14\x6f\x03\x27\x59\x5a\xef\x24\x1a\xdb\x97\x06\xb0\x01\xd5\x10\xd1\x90\x2c\x99\x61\x1f\x2c\xf4\x86\x0a\x0d\xd8\xd6\x61\xf8\x65\x7b\xd4\x0a\xf2\x14\xd9\x42\x2a\x69\x6a\x22\x09\xe6\x8d\xc8\xde\xe0\xae\xaa\x72\x17\xde\xa5\x40\x7f\xf3\x70\x05\x5a\xc5\xe9\xc1\x0a\xf4\x83\xd6\x62\x51\x89\x12\x71\x63\x72\x28\xea\x62\x04\xc6\x3e\xce\x2b\x6a\xf3\xcb\xb2\x94\x61\xea\x32\x44\x16\x98\x4d\xa1\x22\x65\x25\x6e\xb6\xe6\x25\x01\x57\x5c\x51\x41\x04\xfb\x21\x64\xf0\x5c\x12\x55\x6d\x0b\x21\xfb\xea\x2b\xce\xc8\x79\x52\x4c\x13\x92\x55\xac\x24\x10\x49\xae\x59\x76\x12\x1a\xc3\x3e\x2e\xea\x15\x26\xe8\x54\xdc\xc6\x0c\x8f\x7f\xfa\x24\x2f\x50\x2c\xb9\xa4\x2c\xe6\xd9\xa2\xd6\x92\xe2\xba\x41\x4c\x40\x47\x44\xc0\x81\xac\x30\xd8\x3d\x53\xf4\x82\x64\xcc\x12\x1b\x8e\xb2\xf6\xdc\x90\x3c\xc3\x57\xe6\x6f\xb9\xa1\xe8\xa6\xa3\x8d\xa8\xb1\x5f\x89\xc0\xb1\x92\x2b\xa5\x53\xa0\x0c\xb1\x2c\x79\xa7\x43\xc9\xe1\xb6\x64\xaf\x1f\x7c\x43\x91\x2f\x18\xd9\x55\x85\x4d\x95\x9e\xf4\xd9\xd2\x3b\xa6\xc5\x86\x10\x6a\xe8\x06\x47\x08\x9b\x10\x36\x9e\xfb\x87\xdb\xc5\xed\x19\xcd\x97\x42\x33\xd0\x16\xec\xac\x2d\x68\x62\x51\xf0\x02\xc5\xa7\x01\x3f\x1e\xe9\x2e\x72\x78\x9b\x7b\xd9\x16\x4a\xa3\xb6\xc5\x6d\x55\x5d\x6f\x1a\x92\xdc\x94\x32\x6b\x4f\x7a\xd9\xa9\xc9\x20\xba\x70\x51\xf4\x33\xd6\xe8\xd1\xcd\xaa\xa9\x89\xe7\xbc\x9c\x2b\xf6\x28\x02\x47\x5e\x5e\x39\xf0\xf8\xb1\x1c\xdc\x8d\xe4\x96\x68\xe8\x6e\x16\xe9\x19\x5f\x07\x96\xdf\xda\xf7\x73\x65\x7b\x76\x76\x12\xb6\x14\x91\xbe\x17\x84\xa0\x2b\x3f\xa3\xe2\xdb\x3d\xf7\x2c\x59\x10\x92\xb3\x73\x53\xca\xe8\xc0\x01\xce\xfd\x79\x49\x8e\x50\xb2\x34\x32\x82\x3b\x2a\xf4\x19\x41\x04\x11\x55\x15\x2e\x52\xf7\x6c\x0a\xd8\x33\x95\x5a\x1d\x7e\xf3\xb5\xd1\xcd\x48\x5b\xcf\x5c\x15\xdc\x29\xcc\x94\x95\x33\x7c\x75\x00\x8e\x54\xec\x4c\x75\xc2\x45\x65\x6c\x40\x29\xb3\x05\x5a\xe1\xa9\x24\x1a\x20\x79\x84\x8a\x92\xac\x50\x9e\x51\x9c\xbe\x41\x2b\x5e\x2d\x94\x19\xbf\x21\xf0\x71\xa1\xa0\x9d\x16\x2a\x84\xaa\xa4\x2c\x11\x7d\x7b\x51\xe8\x92\x22\x4f\xea\xd6\xc4\x99\x76\xac\xd8\xb1\xb6\x58\x67\x36\x27\xdf\x2c\x67\x27\x43\x87\xa0\x51\x29\xbf\xaa\xe9\xd2\x95\xee\x86\x66\x58\xa7\x60\xc5\x2e\xc5\x39\x66\x78\x5b\xec\x62\x49\x96\xbe\xb7\x88\xe5\x68\x07\xc0\x48\xd4\x4e\xc6\x10\x2c\x30\xdb\x66\x7e\x81\x59\xd7\xf4\x67\x26\x6c\x34\x66\x43\x07\xd1\x51\x81\xd9\xfe\xc9\xf0\xdc\xed\xbb\xf8\x22\xcf\x47\xbc\x7c\x91\xe7\x5d\x47\x07\xed\x8f\xc5\x98\xef\x9f\xb3\xd1\x48\x2f\x11\xdd\x86\x61\x89\xe8\x3d\x46\x5a\x61\xb7\xeb\xcb\x78\xe8\xc7\x70\xd3\xed\x33\x84\xaa\x19\x72\xaf\x2b\xfb\xda\xe5\x28\x6c\xd9\xa1\x3e\xe9\x16\x25\xcb\xc4\x36\xf8\x03\x38\x7a\x33\x80\x8f\xfa\xac\x7c\x5d\x5e\x60\x72\x88\xd4\xad\x8d\x61\xb8\x73\x89\xf3\xb2\xd9\x61\x9a\xdd\x7d\xb0\x01\x6f\xf9\x6e\xdf\x80\x7f\x7b\x97\x06\xfc\xc6\x57\x24\xf2\x72\xea\xce\x2d\xf8\x17\x78\x49\xf2\x67\x63\xfe\x67\x63\xfe\x7f\xdf\x98\xdf\xfe\x7a\xad\xb9\x13\x1f\xe9\xe9\x9b\xbb\x6a\xeb\xc2\xe1\xc1\xee\xdc\x5a\xd5\x7f\xe5\x89\xaa\x9b\xcb\x35\xf9\x2b\x6c\x79\x2c\x72\xc3\xdc\x31\xf3\x74\x7f\x1f\x0e\x4c\x8e\x70\xd0\xc8\x31\xbe\x64\xb6\x21\x3e\xd2\x33\x66\xb0\x59\x82\x1d\xa3\xce\xdb\x5f\x1c\xdb\x6a\x47\x9b\xa0\x8c\x5c\x1e\x0e\xdd\x2e\xb6\xfc\x43\x91\x34\x39\xae\x39\x60\xe9\x84\x76\x4e\x58\x3c\xbd\xbf\x51\xe3\x2f\x05\xc6\xe1\xab\x7f\x1d\x78\xf3\xbb\x2e\xc5\x59\x9e\x41\xa4\x53\xf6\xb7\x48\xe4\xe5\xf1\x63\x3d\xf0\x1c\x9e\xed\xef\x9b\xdc\x4d\x6e\xad\xa4\x19\x24\x95\x89\x5e\x74\x05\x0b\xaf\x11\xea\xb0\xa9\x11\xfa\xbc\x93\x75\x92\xb2\x60\xb8\x60\x5f\x73\x06\xc7\xba\x8a\xb3\x4e\x39\x3a\x42\xdd\x8e\x82\xe1\xcb\xad\x7d\x10\xa7\xb9\x9d\xee\x67\xf0\xd4\x7b\xb3\x97\x3d\xc3\xaf\x64\xe4\xcb\x96\xb8\x4d\x8a\x71\x72\x1b\x6d\xe0\x3e\xd0\xb2\xd8\x86\x9c\xd3\xdc\x2d\x7d\xaf\xf4\xca\x67\x4b\x5c\x18\x18\xd3\x21\x74\x3f\xff\xf3\xed\x1b\xbf\x42\x84\x62\x37\xdd\x0a\xab\xdb\x2b\xe9\x48\x5f\xdb\x2c\x19\x8c\xb7\xef\x96\xbe\xbb\x8f\x6e\xc9\x7e\x79\xde\xfb\x97\xc0\xff\xa4\x5b\xba\xa7\x97\xe8\xca\x99\xbb\xbf\x46\x37\x63\xa0\xb6\x98\x87\x7b\x91\xfe\xfd\xfd\x67\xb3\xf7\xd7\x8d\x3f\x72\x36\x95\x33\x77\xcf\xa6\x19\x03\xf5\x0a\xfd\xe1\xb2\xf9\xc3\x9d\xb2\x79\x6d\x3e\x7b\xff\xa8\xb9\x7b\x46\xbf\x8c\x9c\x4a\x2f\x3e\x27\xa9\x46\x1c\xd4\xdf\x1b\x1e\x24\xab\xed\x3f\xc7\x78\xb1\x0f\x82\xbf\x00\x2d\x6b\x92\xe0\x5f\x51\x55\x65\xc5\xe2\xb7\xf7\xaf\xa3\xd3\xba\x48\x73\x8e\xc4\x5f\xa1\xea\xbf\x01\x00\x00\xff\xff\xdc\x5a\xa9\xc1\xdf\x2b\x00\x00")
+var _distFetchBundleJs = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x3c\x6b\x73\xdb\x38\x92\x9f\xa5\x5f\xd1\xce\x6d\x45\x94\x57\xa1\xec\xcc\x3e\xad\xd1\x4d\x39\xbe\xcc\x5d\x76\xf3\xaa\x24\x73\xf7\xc1\xe3\x62\xc1\x22\x24\x31\xa6\x49\x05\x00\xed\xb8\x1c\xcd\x6f\xbf\x6a\xa0\x41\x02\x14\x29\xcb\xb2\x7d\x93\xdb\xb2\x27\x55\x36\xd1\xef\x07\x1a\x0d\x10\x9c\xe1\xae\xfe\x19\x42\x30\x2d\xb2\x89\x4a\xf2\x2c\x38\xcf\xe3\x22\xe5\xb2\x0f\xd7\x30\x1c\xc2\x25\x3f\x5d\xb0\xc9\xd9\x8b\x3c\x57\x52\x09\xb6\xe8\x96\x14\x9d\xe1\x10\x3e\xcd\x39\x18\x7c\x98\xb0\xc9\x9c\x3b\xd0\x0b\x26\x20\xc9\xa4\x62\x69\xca\xe3\x37\x1a\x47\xc2\x18\xae\x97\xa3\x12\x69\x95\x97\xe0\x5f\x8a\x44\x70\xb0\xca\x38\x18\x76\x08\xa2\x88\x74\x8a\x08\x3b\x8a\x48\xe7\x57\x71\x1f\xae\x4b\x12\x87\x16\xd9\x1f\xcd\xf9\xe4\x0c\x92\xa9\xd5\x37\x91\x90\x64\x2b\x5a\x77\x92\x69\x50\xd7\xfa\xd8\x72\x3f\xe9\xbb\x98\x1d\xc1\x55\x21\x32\x68\x47\x0f\xf9\xd7\x45\x2e\x94\x6c\xb4\x58\xeb\x24\x38\x53\x1c\x18\x64\xfc\xd2\xea\x15\xb0\x2c\x86\x45\xa1\x20\x51\x90\x64\x2a\x07\x35\x27\xe7\x7a\xd2\xd1\xbd\x44\x31\x5e\xa3\x02\x7a\xdc\x25\xeb\x90\x4a\x07\x70\xbd\x1c\x78\x80\x24\x3e\x20\x86\xaf\x62\x1f\x92\xe6\x2c\xe6\xf1\x01\x4c\x59\x2a\x3d\x5f\x35\x87\x12\x0d\x7b\xf9\x95\x4f\x0a\xc5\xb5\xee\xa4\x65\x43\x48\x3b\xe7\x75\x75\xc3\x09\x4b\x53\x8a\xa6\xf5\xde\x80\xd4\x1a\x40\x7d\xbc\x21\x13\xfa\xad\x2a\xfd\x9c\xb2\x99\xab\x0f\x93\x60\x0c\x73\xf1\x48\x82\x01\xc0\x18\x94\x28\x78\x2b\xc7\x0f\x26\xfe\xc8\x93\x54\x82\x7c\xea\x88\x70\xd1\x29\x57\x7c\x0b\x2a\xce\xd0\x59\x96\x7f\xaf\xfe\x01\xe8\x52\x14\x21\x5d\x8f\x4a\xc8\x4f\x3f\xf3\x89\x82\xa0\xf2\x03\x41\xa2\xc8\xcd\x95\x06\x37\x85\xe7\x30\xb6\x6c\x46\x9b\x0a\x5c\x99\x2c\x4d\x8c\x27\x0d\xe9\xd8\x26\xa1\xa2\x5f\x14\xa7\x69\x32\x89\x16\x4c\xcd\xa3\xe8\x06\x09\x0b\x18\xc3\x93\x27\x6d\x3c\x5f\xe7\x2c\x06\x9e\x29\x71\x65\xb5\xc6\xf9\x44\xee\x27\xbf\x3b\x14\x04\x68\x90\x13\xec\x39\xb9\x04\xcb\xd2\xa1\x77\xff\xcf\xd1\x38\x38\xee\x0e\x77\x61\x0f\xb4\x15\x3b\x16\xa1\xfa\xd9\xd9\xfd\xb5\x0b\x80\x00\x08\x87\x49\x16\xf3\xaf\xe1\x67\x09\xbb\xbb\xbb\x3b\x5d\x80\x5f\x2d\x56\xf5\x83\x6c\x50\x5d\x3b\xd9\x68\x2a\x0d\x60\xed\x9c\x81\xeb\x6e\xb7\xd3\x2b\x24\x07\xa9\x44\x32\x51\xbd\x51\xb7\xd3\x6d\xf2\x7d\x30\xdc\xdd\xa1\xbc\xf8\x69\xca\xd5\x64\xbe\x13\x0e\xf5\x6f\xd8\x1d\xc2\x7e\x7f\x74\x23\xd1\x7f\x71\x16\x73\x21\x77\xc2\xe1\xdc\xfc\x85\x84\x7f\xd9\x80\xf0\x03\xff\x52\x70\xa9\x76\xc2\x21\x82\xb9\x54\x48\xf8\xd7\x8d\x08\xe5\x22\xcf\x24\xd7\x94\xe6\x4f\x24\xfd\xdb\x06\xa4\x87\xa7\xb9\x50\x47\x79\xa6\x44\x9e\xa6\x5c\xec\x84\x43\x86\x23\xcf\x26\xe5\x10\x72\xfa\xfb\xa6\x9c\x3e\x26\xb3\x8c\xa5\x25\x17\xa9\x1f\x91\xc3\xfe\xf3\xfe\xa8\x4b\x81\xd3\x45\x19\xf6\x5b\x13\xa2\xed\x3f\x2f\x51\x7e\x1b\x1a\xb9\xcf\x74\x1d\x13\x7e\xa8\xd6\x27\x50\xdb\xcf\x1d\x12\x6b\xb8\x0b\xff\xf3\xf2\xc5\xfb\xc3\xa3\x7f\xc2\x7f\x1f\x7e\x80\x57\x6f\xff\xf1\xf2\xe8\xd3\xab\x77\x6f\x61\x77\x58\x35\x1d\xb3\x34\x3f\x65\x69\x1f\xae\xfd\x0a\x09\x63\x30\x90\xe3\x27\xda\x88\x27\x27\x30\x6e\x92\xa4\x13\xf3\xd9\x4e\x38\xfc\x6d\x78\xca\x4e\x79\x6a\x2d\x97\x8a\xcd\xf8\x78\xcf\xb3\x7d\x08\xe8\xef\x75\x6a\x2d\xcd\x32\x54\x9a\x56\xa9\x89\x4d\x11\x95\x0c\x35\x4f\xe4\x08\x96\x41\xbf\xdf\xef\xfb\xd1\x7b\x7e\xeb\xe8\xd5\xc3\x77\xa3\x0d\xb7\x8e\xdf\xbd\x56\x86\x77\x7a\xdd\x09\x63\x3e\x4d\x32\xfe\x5e\xe4\x0b\x2e\xd4\x55\xe5\xae\x5e\x14\x71\xf9\x46\xc7\xb1\x37\x80\xeb\x6e\x07\xe0\x82\xa5\x05\x3f\xd0\xcb\x69\xb7\xb3\x44\xf7\x13\xf6\x71\x2f\xe6\x53\x56\xa4\xaa\x87\x91\xd5\x31\x1e\x75\x75\x73\x43\x33\x7e\x4d\xbc\xbd\x5a\xf0\x43\xbf\xa4\xa3\x59\xbe\x9e\xb0\x2a\x05\x7f\x46\xca\x2e\xae\x1d\x56\x24\xa9\x24\x81\xba\xaf\x73\x96\x15\x2c\x05\xc1\xe3\x44\xe0\x82\x7b\x9e\xc7\x7c\x00\x97\xf3\x04\xdb\x9a\x3c\x4d\xf3\x4b\x48\xa4\xc6\x24\x4a\xcd\x2d\xcf\x38\xb0\xc9\x24\x17\x71\x92\xcd\x2c\xab\x9f\xd1\x42\x90\x8a\x65\x31\x13\xf1\x00\x64\x0e\x89\xea\x49\x60\x8b\x45\x9a\xf0\x18\x8a\x2c\xe5\x52\x02\xcb\x72\x35\xe7\xba\xc3\xd3\x9d\xaa\xe4\x2a\xec\x76\x6c\xec\x4a\x4d\xde\xe4\x31\x0f\x92\x6c\x51\xa8\x01\x24\x59\xa2\x30\x5e\x1d\xc0\x26\x37\xc0\x47\x78\xfa\x54\x0f\x87\x16\x9f\xe0\x60\x73\xd8\x03\x8e\x30\x50\xcb\x6e\xa7\xe2\xa0\xbb\x50\x6c\x2d\xb3\x09\xcf\xa7\xa5\x7b\x34\xd7\x45\x51\x51\xc2\x78\x3c\x86\x1e\x17\x22\x17\xbd\x55\x11\x8b\xa2\x59\x06\x21\xf4\x8c\x07\x71\xcd\x59\x52\x1c\x74\x1a\x80\x2c\x16\x3a\x45\x60\xc2\xb2\x09\x4f\x53\xa6\x4d\xcf\xa7\x40\x51\x97\x70\x91\x30\xed\x75\x2a\xa5\xf9\x02\x31\x06\x7a\xc1\x97\x4a\x70\x76\x9e\x64\x33\x13\x8a\x29\x94\x11\x3f\xcd\xe3\xab\x8a\x52\xa3\x11\x25\x04\x92\x53\x84\x5e\xe4\xf1\xd5\x47\x0d\xeb\x87\xdd\x8e\xe3\x79\xad\x5a\x83\xcb\x31\xf3\x04\xff\x02\x63\xdd\xca\x93\xa7\x3c\x3c\x6d\xba\xe0\x5f\x1c\xaf\xb5\xc7\x71\x64\x83\x80\x04\x5a\x63\x27\x0e\x3f\xe7\xe2\xfc\x3f\x98\x62\x8e\xab\xbf\x84\x53\x1a\x84\x31\x58\x9a\x51\x05\xd5\x2c\xc6\x90\x15\x69\x6a\x43\x80\x93\x52\xc0\x69\x92\x31\x71\x85\x1c\x19\x2a\x14\x45\xda\xc0\xc8\x0c\x47\xc8\x94\xa9\xc0\x72\xa8\xd4\xf2\xc8\x76\xc6\x86\xb3\xa7\x8f\xc1\x40\x3f\xb6\x68\xe4\x0b\x76\x19\x6e\xa2\xb7\xe0\xb2\xf4\xb5\x09\x6c\x60\xb4\x43\x20\xe5\xc3\x18\xbc\x49\x60\x46\x2b\x24\x1d\xde\x15\x24\x33\xfa\x93\x0e\x63\x2d\x15\x82\x3e\x1c\x58\x55\x9c\x04\x46\xcc\xf7\x22\x3f\x4f\x24\x2f\x17\x0a\x08\x04\x97\x79\x7a\xc1\x07\x20\xf8\x67\x77\xe6\xa1\xf7\x48\xbf\xa7\x4f\x49\xd3\x50\xf7\x15\x3c\x2e\x91\x4a\xde\x86\x38\x88\xa2\x85\x48\x2e\x98\xe2\x36\x3e\x9a\x20\xd2\x33\x2e\xe8\xf7\xc9\x61\x34\xaf\x8c\x0f\xf2\xec\x68\x5e\x64\x67\x30\x86\x22\x33\x85\x3a\x26\x34\xad\x81\x36\xc8\x91\x57\xa1\x57\x26\x70\x21\x06\x30\x41\x2e\x03\x88\xf3\x8c\x3b\xe8\x75\x3b\x02\x2e\x04\x7c\xfb\x66\xd0\x3c\x3c\xb0\x36\x0a\x7e\x9e\x5f\xf0\x97\x17\x3c\x53\xaf\x13\xa9\x78\xc6\x45\xd0\xd3\x76\xf4\x06\xa0\x7f\x5b\x3b\x3c\x5b\xf0\x9f\x99\xcc\x61\x14\x2d\x0a\x39\x5f\x55\xab\x24\x5b\x36\x39\x42\xb3\xd6\xa9\x5d\xf3\x21\x37\x7b\x54\xcc\x6e\x8c\x92\x1c\xf8\xa6\x3b\x56\x34\xdb\xba\x63\xd4\xf2\xcd\xdd\xc2\x58\xc7\xd4\x64\x5a\x17\x5d\xcf\x04\x84\xb6\x51\x92\x3e\x3e\xb1\xb4\x73\xc8\x40\x47\xab\xb0\x5f\xa4\xde\xea\xea\xdd\x7d\x0d\xac\xf8\x57\xe5\xa5\x84\xa7\x59\xa9\x5b\x19\x1f\xc1\x59\x7c\x98\xa6\x34\x15\x49\xc9\x1a\x4f\x26\x04\xbb\x7a\x51\x4c\xa7\x5c\xe8\xca\x20\xc3\xd3\x34\x3f\xdd\x40\x0a\xcd\xb1\x90\x3c\x81\xd3\xee\xd3\xd5\x82\xbf\xd4\x93\xa0\x67\x0a\x08\x68\x63\xf3\x29\x30\xb2\x97\xc7\x55\xe5\x4f\x24\x64\xb9\xb2\xeb\x0a\x8f\x7b\xfd\x66\x3d\x1d\xb7\x92\x68\x9a\xcc\x38\xa9\x2d\xc9\x72\x60\xa7\x58\x9f\xca\x41\x4b\x10\x4a\xe7\x98\x65\x0c\xc6\x26\xd9\x2d\x1b\x8f\x56\x27\x8f\x4b\x4b\xe5\x21\x8e\x37\x49\xa5\x25\xfe\xd2\xcd\x95\xb6\x60\xa5\x93\x5e\x6d\xb9\x6a\xdb\x8e\x1f\x6e\xdf\xb8\x6e\xda\xba\xd2\x9a\xbd\x65\xf3\xfa\xfd\xb5\xaf\x4e\x4b\x10\x4d\x52\x26\xe5\x11\x4b\x53\x7d\xc4\x18\xd8\x95\x7a\x00\x47\x79\x26\x95\x28\x26\x2a\xc7\x29\xad\xd3\x63\xa7\x04\xbb\x9d\x95\x8b\x88\x98\x6a\x2e\xf2\x4b\xa8\x65\xf8\x11\xcb\x30\x7d\x71\x6b\x02\x0c\xb4\x50\x60\x12\x58\xe9\x93\x5e\x7f\x04\x4b\x93\x51\x58\xf8\x68\xaf\xbd\xb6\x81\x76\x76\xe1\x7f\x32\x66\xf9\xad\x77\x69\xa4\xd7\xd1\x50\x86\x22\x6a\x24\xf8\x14\x53\x5a\xcc\x8a\x73\x9e\x29\x19\xa6\x3c\x9b\xa9\x39\xfc\x38\x86\x7d\x2c\x92\x25\xe0\x78\xff\x44\xb7\x89\xe5\x6a\x04\x3f\xc1\xf5\x12\x0e\x3c\x0c\x9a\x49\xc8\xf8\x9c\xab\x79\x8e\x75\x09\x25\x84\xe6\xa9\x5c\xbb\xad\xda\x04\xa5\xc7\x12\xec\x74\x58\x1a\x6e\x9f\x4b\x04\x2a\x89\x1a\x48\x7d\x09\x82\xea\x91\xc4\x2d\xde\xc0\x7a\xc3\x4e\x73\x1c\x24\x7d\x60\x0c\xbd\xff\x7c\xf9\xa9\x37\x2a\xc7\x2b\xc5\x30\x78\x14\x81\xe0\x7a\xd9\xaf\x50\x1c\xe5\x7a\x66\x63\xe1\xd0\xfb\xfd\xce\x4d\x9d\x78\x59\x29\x34\x6d\x21\x52\xdd\xcd\x60\xbf\x5d\x88\x94\x8a\x82\xaf\xae\xe6\x44\x8f\x2e\x42\xb3\xde\x06\x9b\x60\xb6\xca\xd4\x8d\x68\x6a\xf0\x81\xa7\x92\xb7\x29\x67\x1b\x39\x6b\x9d\xd1\xc6\xb7\xa5\x54\xd8\x51\xd5\x21\xb1\x2a\x79\x34\xcd\x36\x58\xd4\x3a\x0b\xab\xb0\xcf\xa3\xa1\x37\xaf\x13\x62\x88\x7c\x22\x0a\x5a\xd9\xe0\x2e\xbb\x1d\xec\x44\x5a\xf6\xb8\x14\xbb\xd1\x36\x05\xfa\x4f\x0f\x57\xa0\xc9\x4f\x0f\x56\xa0\x1f\xb4\x16\x63\xc1\x88\x26\xfa\x65\xca\x11\x4e\x61\x18\x83\xd3\x8a\x63\x45\x2d\x9f\x3c\x49\x09\x97\x81\x62\x62\xc6\xd5\x00\x16\x22\x5f\x60\x46\xc1\x34\x17\x10\x20\xc7\x04\xc6\xb0\x37\x82\x04\x7e\x34\x40\xaa\x6d\x23\x48\xfe\xf8\x47\x44\x44\x9c\x98\xcb\x89\x48\x16\x2a\xc7\x66\x46\x63\x1d\x27\x27\x23\x67\x38\xe4\x59\x71\xce\x05\x3b\xd5\x2f\x6a\x9a\xc7\xbf\x7d\x33\xef\x56\x3c\xba\x49\x9e\x4d\x93\x59\x61\x29\xd1\xde\x91\x4e\xfe\x9e\x5e\x8d\x7a\xf8\xf6\xaa\x42\xef\xbb\xa4\x97\x22\x51\x1e\x59\xb3\x97\xad\xe5\x0e\xe5\x19\xbf\x72\x9f\xcd\x82\x62\x1b\xb0\xca\xa3\xce\x7a\xa5\x1d\xa7\x72\x74\xa8\x1c\xe0\xc1\x86\x4a\x26\xef\xad\x2b\x51\xdd\x0a\xdc\x07\x4f\x01\x74\xbe\xc3\x28\xd4\x88\xea\x6a\xc1\x5d\x96\xfd\x11\x75\x55\x0e\xdf\x75\x5c\x7c\x15\x46\x56\x75\x07\x63\x04\xcb\x11\x2c\xfb\xc1\xff\xbb\x55\xdc\xcf\x68\x2c\x47\xe5\x80\x2d\x76\xce\x81\x04\x2d\xcf\x58\x19\x31\x0d\xf0\xb0\xd2\xb6\x9a\x75\x53\x11\x67\x60\x05\x55\xdd\x2c\x0e\x87\x11\xd5\x05\x7a\x73\x4b\xa0\xf2\x80\xc9\xb1\xde\xd2\x5b\xf1\xa0\x37\xd4\xb4\xbf\x2e\xf9\xd0\x22\x42\xf5\xd4\x54\x54\x8c\x79\x3e\x25\x74\x3c\x49\x32\xef\xb5\x7a\x76\x53\xbe\x7a\xc0\x00\xd5\x24\x3d\xc3\x79\xe0\xd9\x6d\x6d\xbf\x20\xd9\xc7\x67\x27\x24\xd5\x0a\xdc\x39\xc4\x3d\x48\x98\x48\xfd\x3b\xb8\xa8\xef\x58\x91\xf2\xf8\xc2\xa5\x2a\xf5\xc5\x7f\x17\x78\xd8\xf2\x92\x4d\xe6\x4e\x44\x78\xf3\x9e\x25\xd2\x4e\x64\x8b\x05\xcf\xe2\xe0\x6c\x00\xce\x66\x95\x5a\xf5\x92\x7f\xf9\x8b\x64\xb9\xf5\x2c\x20\xe7\x0e\xe0\x98\xa4\x9c\xf1\xab\x03\xe8\x19\xc6\xbd\x81\x0d\xb8\xee\x52\x4b\xa5\x48\x6c\xc6\xce\xf9\xc0\x94\x4d\x47\x49\xf4\x50\x86\xa7\x2f\x69\x22\x79\xfc\x96\x9d\x63\x91\x21\x31\x61\x09\xc0\x71\xcd\xa0\x4a\x0b\x72\x21\x95\x94\x39\x93\xef\x2e\x33\x9a\x8b\x57\xe6\xdc\xdc\x4b\x9c\x41\x4d\x8a\xef\x6b\x0f\xf5\xd8\xc7\xc4\x03\xe1\xe3\x2a\x08\x4e\x08\xd6\x52\x85\xfa\x90\xe0\xa3\x12\x49\x36\x0b\x8c\xd5\xd6\xcf\xc6\xbd\x03\xf0\x7c\x18\xf3\x94\x2b\xde\xe6\xc3\xc8\x80\x8d\x0f\x2a\xcd\xcd\x68\x4d\xfd\x35\xde\x3b\x59\xa7\xc1\x8c\xab\x36\xf1\x33\xae\xea\xa2\xef\x1a\x38\x3f\xf7\xd7\xba\xd2\x8f\x15\xa5\x34\xf5\xa6\x2b\x41\x21\xf0\x5a\x86\xe1\xe7\x3c\xc9\x82\xde\x00\x7a\x6b\x43\x32\xe3\xea\x30\x4d\xd7\xf8\x04\xcf\x18\x6a\x6e\x69\x14\xbf\x2e\x22\xb8\x3b\x39\x3e\x59\xa7\xc6\x9c\xc9\x36\x1d\xe6\x4c\xde\x63\x5c\x48\xf7\xdb\x44\x66\x9d\xde\xb2\x3d\x9f\x24\x57\xf7\x5f\x0f\xd6\x6a\x8a\x93\xd8\x9b\x8c\x6b\x5d\x4e\x85\xb5\x4d\x7d\x5b\x77\xb1\xcc\x9c\xb2\xc9\xd9\x40\xc7\xfb\x50\xcc\x6a\x86\xe8\xb2\xfb\xbc\xbe\xee\x91\xa6\x67\xfc\x4a\x06\xfd\x86\x1a\x5e\x0b\x68\x19\x17\x2b\xad\xaa\x6e\x87\x62\x36\x20\x21\x61\x39\x45\x07\x80\xbf\xec\xb8\x8d\x90\x53\xe6\x1b\x2d\x46\x6d\xda\xcc\x45\x98\x7b\x18\x46\xfa\x50\xe9\xd5\x50\xcf\xf7\xfd\x50\xe6\x42\x95\xa7\x6f\x46\xde\x49\x7d\xd1\x28\x03\x84\xf1\x69\x13\xed\x21\xb5\xcc\x35\x1c\x0d\x55\xfe\x3a\xbf\xe4\xe2\x88\x49\x5e\x17\x6c\x73\x84\xd0\x29\x99\xf0\x78\xca\x76\x5f\xab\x1b\x9e\x2a\xe9\xb6\xda\x20\xfd\x79\x9b\x0d\xd2\xc6\x47\x58\xe6\x1c\x71\xeb\x2d\xd2\x77\x78\x88\xf5\xb8\x71\x7a\xdc\x38\xfd\xcb\x6f\x9c\x6e\x7f\xfc\x49\x2f\x0c\xd6\xee\xb9\xca\xf7\x8e\xde\x81\xd0\x83\x9d\x89\x56\xac\xff\x80\x81\x2a\xca\xc3\x4f\xf3\x44\x95\x17\x71\x3c\x70\x89\x5c\x13\xf3\x7c\x6f\x0f\x0e\x5c\x0c\x87\x81\x33\xfa\xc9\xbc\x00\x72\x04\xe1\xc8\x8a\x30\x07\xcd\x23\xac\x09\xed\xbd\xfb\x67\xcf\x97\x5a\xe3\x86\x72\xfe\x60\x83\x42\x62\xe9\xd1\xc1\xf2\x11\x2a\xfc\x9a\x34\x7d\xba\xec\x62\xdc\xb0\x01\xb6\x01\xad\x5a\x9b\xf2\x74\x8f\x5e\x91\xe9\x9a\xe0\x6e\x8e\x57\x8f\x6b\x09\xb2\xc1\x59\x24\x61\xe6\xf8\xce\x95\x82\xf4\xef\x63\x1d\x17\x7c\x2f\x6c\x06\x7e\x84\x1f\xf6\xf6\x5c\x6c\x1a\xb7\x14\xab\x20\x8a\xc4\x8a\x77\xb5\x2c\xac\x11\x30\xf6\x4e\x7c\x75\xfb\xd2\xc3\x1b\x6d\x3c\x53\xcf\x10\x81\x5a\xf3\xa6\x5d\xa8\xf5\x50\xbd\xa3\xc0\xd7\x84\x6d\x8d\x04\xc2\x82\xa6\xf6\x6c\xb5\x3b\xdb\xe4\x7d\xba\xc3\x69\xe5\xed\x5c\x54\x05\x65\xd3\xee\xeb\xb3\xcc\xb3\x36\xcd\x11\xe6\x6a\x4e\xd2\xb4\x10\x63\x55\xa8\xe6\x3c\x73\x74\x8c\x9b\xb4\xfb\xc7\xc7\x77\x6f\xc3\x05\x13\x92\x07\x71\xab\x5a\xf5\x5e\xc9\x7a\xfa\xc6\x66\xc9\x41\xbc\x7d\xb7\xf4\x97\xfb\xe8\x96\xfc\xab\x86\x2b\x17\x3c\x7f\x97\x6e\xe9\x9e\xae\x1c\x92\x31\xdb\x5f\x3a\x74\x7d\x40\x4b\xcc\x1a\xd5\xee\x78\xed\xf0\xaf\xf7\x1f\x4d\x7a\x57\x71\x0f\xef\x6f\xbf\x83\x68\x92\x31\xdb\x47\xd3\xf5\x01\x5d\x38\x7c\xb8\x68\xfe\x6d\xab\x68\xde\x18\xcf\x95\xcb\xd0\xdb\x47\xf4\x7b\x98\xa1\xd6\xa0\xbb\x04\xd5\xf1\x03\x5d\x06\x7d\xb8\xa8\xfe\x7d\xcb\xa8\xde\x26\xc8\x37\x5f\x5b\xbf\x4b\xcc\xbf\xbb\x14\xa8\x99\xbb\x7d\x26\x34\x7a\x69\x08\xfb\x7b\x0f\x9b\x12\xfb\xed\x5f\x7f\x6c\x91\x0a\xb7\xb1\xed\x4e\x19\xf0\x78\x96\xf1\x78\x96\xf1\x78\x96\xf1\x7f\x74\x96\xe1\x7c\x3f\xb4\xa6\xba\xd9\x69\x4e\x57\x55\xf1\xcb\xa2\xfd\x6a\x6a\xd4\xea\xe4\xfa\xc3\x8d\x1a\x72\xb5\x0d\x6a\xde\x3a\xd7\xd0\x6b\x3b\x68\xd2\xc7\xec\x85\x1d\x53\x82\xf6\x9d\x66\x8d\x61\x7d\xc3\x49\xd7\x1f\x9b\xf7\x6d\x1a\x58\x69\xec\x29\x11\x46\x11\x81\xd7\x6e\xbf\x6a\xe2\x6f\xdc\x85\xad\xe2\xaf\x2c\x57\x37\x6e\xc6\xf6\x6f\xff\xd1\xd7\x36\x6b\x00\xf9\xe1\x4e\xf5\xff\xb1\xf6\x3f\xd6\xfe\xc7\xda\xff\xbb\xd4\xfe\x9b\x6a\xb6\xad\xac\x1b\xd4\x6b\x83\x5a\xab\xd5\xf4\x25\x4a\xed\x7b\x00\x0d\xca\x33\xfb\x29\x85\x73\x05\x40\x43\xa2\x28\xa5\x9b\xe9\xb2\xba\xb3\xd1\x5a\xd6\x8d\xdc\x95\x92\x5e\xbb\xe3\xde\x5a\xdd\xeb\x77\xe1\x4d\xc2\x58\x05\x4a\xc3\xab\xab\x45\xfa\x60\x98\x96\x0c\xbc\x52\x44\xd7\x8d\x2c\x85\x01\x5b\xfe\xd5\xf7\x6b\x4d\xd6\x99\xbb\x25\xf6\xd1\x39\xcd\x73\x17\x93\x01\x78\x86\x35\x7c\x0a\xd2\x66\x5b\xd3\x57\x23\x37\x9b\xb7\x53\x99\xe7\x29\x6f\x32\xdf\xd5\xb1\xdb\x69\x36\x0b\xc6\x2b\x43\xe1\x34\x49\x15\x17\x4e\xba\xa5\x0d\xcc\x21\xd5\x77\xb3\x2c\x55\xdb\xe9\x66\xcd\x23\xb4\x06\xb7\x79\xa1\x5c\xa2\x2b\x79\x3a\x94\x4e\x7a\xde\x60\x27\x40\x43\x3e\xeb\x42\x58\xf9\x00\x6b\x37\xc7\x8f\x2a\xf0\x66\x1b\xa0\x97\xab\xbe\x02\x4c\x61\x3c\xd0\x5e\x71\x3e\x0c\xb1\x2e\xcf\xa7\xb5\x19\x71\x43\x06\x11\x5e\xa0\xe5\x79\x59\xd3\x16\x91\x50\xa6\xc9\x84\x37\xde\x52\xb0\x38\x0d\x3e\x28\xd3\x64\x45\xd2\x06\xed\xce\x47\xfb\x79\xdc\x06\xad\x4e\x89\xbb\x45\x9b\xb3\xc5\xd7\xd1\x6b\x7a\x9d\x86\xa3\x8f\xc6\xef\xec\xef\xe1\xc8\xe3\x0e\x5d\xcf\x7d\x1e\x75\x18\xf3\xee\x7a\xcc\xe1\x78\xc5\x6e\x13\x1e\xe4\x88\xa3\xfa\x5f\x5d\x60\xd2\x0d\x87\xff\x06\x32\x2f\xc4\x84\xbf\x61\x8b\x45\x92\xcd\x7e\xf9\xf0\x7a\x7c\x5a\x64\x71\xca\xc3\xcf\x32\x3c\x67\x8b\xff\x1d\x00\x5c\x4e\x5e\x3c\x90\x48\x00\x00")
 
 func distFetchBundleJsBytes() ([]byte, error) {
 	return bindataRead(
@@ -84,12 +84,12 @@ func distFetchBundleJs() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "dist-fetch/bundle.js", size: 11231, mode: os.FileMode(420), modTime: time.Unix(1505683992, 0)}
+	info := bindataFileInfo{name: "dist-fetch/bundle.js", size: 18576, mode: os.FileMode(420), modTime: time.Unix(1792125562, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _distFetchBundleJsMap = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xd4\x5a\x7d\x57\xdb\xb6\x1a\xff\x2a\xc2\x7f\x8c\xc0\xf5\xe2\xac\x69\xcb\x8a\x6f\xd6\x23\x9b\x40\x03\x65\x29\xb4\x8c\x71\x09\x27\xc7\xd8\x4a\x62\x70\xec\xd4\x52\x80\x96\xe5\x7e\xf6\x7b\xf4\x2e\x39\x4e\x4a\x37\xee\x1f\xcd\xce\x21\x8a\xf4\xbc\xfe\x9e\x17\x3d\xf6\xfa\xe8\xdc\xa1\x12\xa7\x45\xee\xec\xb6\x5d\x07\x17\xf3\x32\x46\xd8\xd9\xbd\x74\xee\xd1\xf5\x2c\x8a\x6f\x77\x3d\xcf\x13\x4b\xef\xba\x28\x08\x26\x65\x34\x03\xad\x57\xed\x17\x3b\xed\x76\x3b\x79\xfd\xe6\xe5\xab\xa4\xdd\x7a\xf5\xeb\xcb\xd7\x8e\x6b\xf2\x34\xbd\x34\x4f\xd0\x43\xf3\x06\x57\xf7\x47\x88\xc4\x93\xe6\x0d\x7e\xfb\x3a\xbe\x6e\xaf\x3a\xac\xee\x97\xe8\xf3\x1c\x61\x52\x73\x32\x41\x51\x82\x4a\x5c\xcb\x83\x67\x45\x8e\xd1\x5a\xa6\xb7\x6f\x76\x46\x2f\x57\x6b\x7b\xfb\xe2\xcd\xe8\x7a\x8d\xe0\xb7\xaf\x63\xf4\xc2\xb9\x72\x9d\x3c\x9a\x32\xdc\xae\x5c\x67\x1a\xcd\x66\x69\x3e\xc6\xce\xae\xe3\x43\x08\xa1\x0f\x61\x08\x7d\x1f\xc2\xee\xf2\xb2\xee\xb7\x3b\x0f\x20\x44\x74\x39\xaa\x27\x5a\x27\xca\x87\xf0\xe0\xa9\x1c\xc6\x07\x86\x24\x84\x6e\x11\x40\xd8\x77\x43\x08\x43\xf7\x75\x00\x21\x0e\xf8\x9a\xfe\xa1\x1c\x93\xd0\xa4\x48\x43\x08\x5b\x15\x8a\xd9\x77\x52\x4c\x43\x08\x5f\x4a\x0a\xc3\x98\x77\x65\xe8\xe2\x43\xdf\xfe\xe0\x20\x3c\xb8\x0f\xdc\x23\x08\x8f\x7c\x08\xdf\xed\xb0\xe5\xb1\xdb\xa7\xd2\x0e\x20\x3c\x70\xa7\x5a\xf0\x6d\x00\xe1\xb9\xad\xb9\x0c\x39\xfd\x09\x84\x27\x4b\xf4\x74\x79\x61\xd0\x53\x94\x1e\x02\xf7\x0c\xc2\x8f\x4c\x21\x3f\x62\xab\x2e\x84\x5d\xb7\x07\x61\x8f\xad\xa8\xe4\xaf\x21\x35\xe2\x98\x0b\x65\x7f\xd8\x71\x5f\x49\xaf\xf0\x55\xcd\xaa\x63\x66\x56\x32\x16\x65\x4f\x9b\xd9\xd3\xaf\x0a\x3f\xa3\x7f\xd8\x4f\x46\x7e\x4c\x85\x75\x39\x46\x21\x09\xdd\x0b\x7a\x72\x1f\x40\x78\x2f\x70\x66\x3a\x18\xa9\x5e\x45\x62\xd5\xa3\x4c\x5e\xe8\x9e\x53\x15\xea\x98\x6e\x7e\x70\xc7\x0c\x2d\x26\x5e\xcb\xd1\xae\x9c\xc0\x70\x16\x30\x3b\xf7\xdc\x98\x52\x6a\x0b\x2b\x94\xc7\x30\x2c\xcd\xac\xe8\xc1\xf0\xd0\xf8\xd9\x85\x61\x35\xf2\x3c\x29\x26\xc1\xde\x77\x44\xdc\x3f\x82\xdd\x49\x20\x69\xc3\x91\xfb\x11\xee\x9d\x48\xa3\xc2\x44\xc5\xe4\x3e\xf4\x8b\x2e\x84\x87\xdc\x53\xbf\x0f\x61\x1e\x72\x2f\x4f\x20\xfc\x9d\xad\xe8\x66\x97\xb3\x9e\x40\xf8\x81\xad\xf8\x1e\x8b\xd2\x09\x84\xa7\x6c\xc5\xf7\x64\xf8\x0e\xd9\xca\xf7\xbf\x04\x70\x6f\xc2\x23\x24\xd3\xaa\xaf\xb2\xe0\x58\x85\x5e\x65\x19\xab\x12\x93\x46\xbb\x5b\x89\xbc\x4a\x0f\xc5\xe7\x99\x7c\x3a\xd1\xd9\x4a\xd2\x7c\x35\x69\x7a\x2a\xd0\x2a\x33\x99\x91\x37\x82\x88\x59\x75\x41\x6b\x43\x65\x18\x95\xf1\x2a\x70\x3f\x2a\x19\x3a\x73\x75\xa5\xa8\x90\xb3\x14\x37\xa9\x6b\x3c\xd6\x49\x45\xa9\xdb\x26\xf5\x4a\xdf\x35\xb3\xde\x93\x12\x7e\x09\x0d\x09\x1a\x05\xcd\xa2\xf1\xe8\xb1\xae\xc6\x82\x0c\x61\xf8\x7e\xbd\x57\x94\x3a\x93\x39\xce\xe0\x91\xc5\xc6\x40\xf9\xa3\xd6\x4b\xed\x5b\x0f\x86\xc4\xe2\xb6\x20\xfd\xf3\x29\x5e\x57\x7c\xed\xd1\xf6\x61\x4a\x54\x5d\x83\x4a\xfc\x4f\x2d\x0a\x96\xef\xbf\x58\xf6\x58\x4d\xed\xd4\xe0\xae\xcb\x12\x8e\x45\x17\x86\xfb\xb4\x33\x43\x2f\xb8\x0d\x44\x8e\x2f\x7f\x8e\xc2\x7d\x51\x35\x10\x1e\x8c\xdc\x0b\xf8\x4e\x55\xe2\x41\x62\xa8\xf5\xfd\x17\x01\x7c\x27\x6b\xe5\x8c\xda\x67\x58\xce\x49\x20\x3c\x10\x50\x31\xbe\xe5\xdc\x6c\x07\x46\xfb\xea\xd9\x08\xc8\xfe\xa3\xe2\xf0\x51\xe1\xdb\x53\xe9\xce\x28\x7b\x4a\x03\xdb\xb3\xa0\xd9\x61\x5d\xf5\xc8\x10\xde\x5b\x82\x2f\x0b\x68\x53\x35\x73\xc9\x6e\xfd\x95\x9b\xd5\xa4\x5e\x95\xda\xba\xe8\x45\x51\x5d\x2c\x37\x57\x43\xec\x19\x0c\x3f\x09\x2f\xcf\xd4\x89\x96\x78\x4e\x69\x68\xd3\x64\x59\x7c\x64\x34\xf6\xba\xde\x72\x06\x21\xb4\x9b\x7d\xce\x80\xdc\xa7\x79\xb0\xef\xfb\xe3\x00\x66\x3a\x03\x2e\x60\x11\xcc\x02\x76\x63\x84\x1a\x16\x7d\x0d\x0a\x80\xce\xa9\xca\x18\xc2\x98\x3b\xa1\x6d\x8b\x20\x8c\x0c\x60\xed\x6b\x39\xdf\xe3\xf1\xb5\x3b\x07\x97\x53\xe1\xa9\x44\x9f\x19\xa1\xe9\xa4\x25\x6f\xf6\x0c\xf0\x55\x9a\x18\x84\xfa\x9e\x54\x37\x9d\xac\xba\xf3\xb5\x8c\x15\x23\x74\x60\x65\x1e\xb6\xe9\xd0\x73\x01\xbb\x47\x6e\x6a\x63\xc5\x3b\x42\xac\x12\xb3\xa2\xe2\xdb\x50\x29\x15\xf9\x1e\x57\xc1\x2e\xf9\xb0\x5a\xe3\xff\x2c\x04\x75\xa6\x2d\xe3\x7b\x13\x8a\xd9\x61\x3d\x79\x5d\x0e\x9f\xd0\xe1\x8a\xe7\x99\xc0\x69\xfc\xdc\x38\xe9\xf4\x94\x88\x7d\xfd\xff\x20\xc6\x8c\xac\xab\xed\x27\xc2\xc2\xe6\xa6\x15\xa6\xd9\xa5\x35\x7a\x8e\xca\x5a\x59\x08\x2b\x52\xf9\xab\x48\xe5\x69\xc0\x9e\x5f\x2a\xb8\x7d\x0d\xaa\x41\x3a\xa7\xe3\x9a\x85\x7b\x3b\xf0\x69\xd3\x7f\xb5\xa7\x6f\x12\x1c\xa8\x79\xab\x76\x24\x84\xaf\xbe\xe7\x21\x40\x8c\x84\x6c\x54\x83\xf0\x68\x42\x3b\xe8\xfb\x13\xe9\xe9\xd1\x48\x99\x3c\xde\xf3\xb1\x31\x13\xbe\x0c\x20\x6c\x8b\x29\xe1\xa3\x1c\x0a\xdd\x7f\x05\x12\x12\x7f\x1c\x52\x3f\xce\x20\x3c\xa3\xe7\x7f\xb0\x15\x7b\xb6\xe1\x28\xf8\xf4\x61\x8a\x0f\x90\xf4\x9c\x3b\xe4\x8e\x75\x91\xd0\x6b\xef\xfd\x1d\xc7\x9c\xdd\x7b\x6d\xf3\xde\x63\x22\xce\x60\x77\xc4\x51\xd7\x7b\xf4\x2e\xfc\xe3\x6f\x8c\x0d\xfc\x31\x4c\xf3\xe9\x87\x01\xe6\x58\xe5\xb6\x3f\xb6\x9f\x4e\xa4\x84\x87\xf0\x1b\xe3\x4e\x75\xf0\x63\xa0\x30\x1a\xb6\x92\x34\x3b\xc1\xfa\x41\xa3\xf6\x39\xc2\x2e\x0f\x7a\x07\xbd\x09\xd9\x25\xf4\xe7\xad\x88\xaf\x7f\x01\xa3\x3b\x7e\x05\x1d\xb0\xa7\xe2\xf0\xbd\x91\x81\x5a\x26\xed\xbb\x7d\xca\x69\xed\xb2\x1b\x51\xcf\x7d\x27\xf6\xed\x77\x0c\xc3\x99\x28\xc4\x03\xa1\xa1\x22\xbf\xa6\xb3\xd8\xa9\x1f\x0a\x95\xab\x2e\x87\x55\x7a\x77\x42\x56\x23\x79\xa0\xfc\x64\x35\x72\x2a\x7f\xa8\xca\xd8\xdf\x09\xdc\xd6\xa1\x55\x2b\x70\x79\xe3\xe5\xa1\xe3\x3a\xa3\x34\x43\xce\xae\x73\x3d\xcf\x93\x4c\xbc\x2c\x11\xef\x81\xc2\x22\x27\x28\x27\xce\xee\xa5\x03\x06\xc4\xf3\xc0\xa7\x09\x02\xd3\x22\x99\x67\x08\xc4\x51\x3c\x41\x83\x1c\x0c\xc8\x5d\x54\x82\x34\xc7\x24\xca\x32\x94\x1c\xb3\x53\x0c\x3a\xe0\x71\xe1\x0f\x72\x46\x20\x18\x4b\xf4\x79\x9e\x96\x08\x8c\xe6\x79\x4c\xd2\x82\x9f\xc9\x1f\x60\x38\x14\xef\x58\x86\x82\x6e\x38\x6c\x70\x55\xbd\x64\x0b\x3c\x0a\x51\x4c\x58\x38\x41\xf1\x2d\x48\x47\xd2\x94\x14\x83\x34\x37\x0c\x1a\x90\x74\xd4\xa8\x5a\x74\x29\x85\x5d\x6d\x09\xa2\x01\x29\x11\x99\x97\xf9\x92\xf1\x9a\xb4\x89\x1e\x66\x45\x49\xb0\x6f\xa9\x2f\x51\x44\x10\x88\x40\x8e\xee\xa5\x09\x8d\x28\x4f\xc0\x6c\x4e\x40\x4a\x40\x9a\x93\x02\x90\x89\x80\x48\x6a\xa3\x28\x09\xe2\xce\x1a\x8d\x14\x38\x65\x9f\xd0\xbe\x0b\x1e\x17\xae\xda\x4c\x93\x5d\x20\xc9\xf5\x6e\x56\x44\x09\x4a\x76\xc1\x28\xca\xb0\x44\x61\x61\x99\xdd\x7d\x40\xf1\x9c\x20\x66\x99\x30\xc4\x8a\xc4\x80\x4c\x97\xdc\x8f\xa3\x2c\x13\x51\x90\x50\xb8\x82\x59\x7e\xeb\xfd\x9a\x08\x6e\x59\x16\xec\x67\xd1\xd8\x54\x1f\x61\xc0\xcd\xb6\xf4\x37\xf9\x1e\xe8\x00\x52\xce\x91\x25\xe0\x94\xc7\x8b\x8a\x10\x5a\x41\x31\x32\x24\x0a\x4a\x11\x56\xdb\x3e\x9f\x1d\x2e\xa8\x38\x99\x94\xf4\x04\x9b\x80\x60\x50\x5c\xdf\xa0\x98\x80\x86\xf6\x45\x9c\x0c\x87\x3c\x90\x35\x4e\x36\xa7\xa0\x23\x05\xf8\x6b\xa4\x1b\x19\x5a\x27\x25\xae\xc9\x0b\x43\x9c\x66\x99\xcd\xaf\xb3\x34\x1e\xce\x22\x32\x19\x0e\x57\x8a\x9b\x81\x0e\x18\x38\x03\xc7\x10\xf1\xbe\x88\x12\x80\x72\x52\x7e\x51\x11\xc8\x13\x20\xd0\x12\x30\x31\x5a\xb1\x55\x57\x92\x2d\x1e\x52\xfa\x9f\xb7\xbd\x0d\xce\xbb\xc1\x07\x18\x1e\x81\xfd\x7e\xff\x53\xf7\x14\x6c\x6f\x0f\x72\xb0\xbd\x0d\x9e\xf6\xc6\x98\xd1\x7a\x8e\xeb\x08\xf1\x8d\x4d\x8e\xd9\x5b\xf6\x16\x78\x43\xbc\x0d\xde\xa4\x1a\xab\x14\xef\xf8\x0b\xdc\x0d\xf5\x2a\xb7\x96\xea\x94\xbf\xc7\xdd\x50\x6f\x74\x57\x50\xf1\xd7\xb9\x1b\xfa\xcd\xee\xe6\x93\xbc\xd4\xef\xb8\x95\x27\x76\xce\x81\x0e\x18\x67\xc5\x75\x94\x5d\x0e\x1c\xe6\xcb\xc0\xa1\x05\x2e\x0d\x18\x38\x3f\x6f\x78\x67\x18\x95\xd8\x8b\x8b\xbc\x8c\x12\xef\xbc\x28\x6f\xbd\x71\xe1\xe1\x32\xf6\x46\xb7\x39\x2e\x71\xf3\x3a\xfd\xea\xcd\xbc\x82\x90\x02\x3d\x10\x8e\x88\x77\x83\xbd\xbc\x48\x90\xcc\x4d\xef\x3a\xba\x46\xd9\xcf\xac\x6e\x4a\x65\xd3\x5b\x4c\xa2\x31\xea\xb4\xfe\xb6\x0a\xf9\x2e\x7e\xe0\x48\x34\xbc\xed\xea\x87\x03\x61\xe3\x23\xc1\xf9\xaf\xc7\x01\x16\x86\xd9\x51\x95\xa0\xa9\x1e\x4e\x0b\xfe\x17\x6b\x2b\x9e\xcc\xf3\x5b\x8a\x61\x4b\xc1\x1b\x17\x39\x26\x40\x84\xd5\x40\x72\xd3\x8e\xb0\x24\xe3\xc1\xac\xd2\x59\x21\xe6\x91\x02\x09\x1a\x45\xf3\x8c\xa8\x8e\x08\x98\x91\x8d\x34\x9f\xcd\x89\x0b\xd2\x3c\x25\xec\x0e\x02\x80\x8b\x2e\xd1\x67\xd0\x61\xfd\x5f\xd8\x62\x51\xfa\x26\x21\x56\x84\x5c\x6f\x43\xf4\x44\x59\x77\xf4\xec\x43\x59\x4c\x53\x8c\x1a\x8d\x12\xe1\x22\xbb\x43\x2e\x28\x11\xed\x43\x5b\xa0\xf3\x1b\x57\xab\xc8\x87\xc3\x59\x99\xde\x45\x04\x0d\x87\xcc\xc4\x21\xe2\x6d\xbd\x51\xa2\xcf\x94\x0d\xbb\x00\x95\xa5\xc1\x07\xe8\x45\xd9\x40\x65\xb9\xa5\x77\x94\x34\xae\x86\x9d\xfa\xf2\x70\xc1\xcd\xb3\xa8\x98\x55\xd4\x3a\x49\xb6\xe0\x0b\xf6\xb5\x78\x52\xa9\x58\x51\xd7\xb1\x14\x85\x6c\xc7\xc8\xac\xe9\xa5\x10\xc5\x59\x84\xb1\xca\x01\x1d\x94\x72\x1e\x93\xa2\x94\x81\x78\x9c\x22\x32\x29\x12\x17\x08\x59\x14\x9b\x24\x2d\x51\x4c\x5c\x70\x5d\x24\x5f\x16\x9d\xc7\x85\x42\x84\x4c\x52\xdc\xe4\x0c\xa0\x03\x36\x0f\xba\x9f\x36\x7d\xe3\x64\xa2\x6c\xa4\xc1\x12\x16\x37\x1e\x17\x5b\x26\x91\x14\x4f\x05\x4c\xa3\x7c\x1e\x65\x96\x0c\xaa\x93\x0a\x98\x67\x99\x2f\x01\xa6\x81\x61\xe6\xf2\xbe\x9f\xc7\xa8\x18\x49\xc7\x8c\x68\x31\xfe\x79\x99\xb1\xfb\x61\x36\x27\x74\xed\x5b\x87\xca\x74\x7e\xce\x7f\xda\x24\xf5\x3e\x70\x7a\x71\xb6\x65\x73\x18\x0e\x71\x32\xb9\x21\x53\x00\xa0\x0c\xa3\xd5\x66\x4a\x32\xd3\x5b\x6e\x59\xd5\x37\x65\xbe\x65\xb8\xc5\x28\x4d\xac\x70\xd6\x7b\x55\xf1\xc7\x12\x24\x9d\xa8\x4a\x32\xbc\xad\xfa\x69\xb2\xd3\x30\x56\x59\x45\x68\xe9\x97\x66\x61\x7f\x16\x4f\xa8\x0b\xfd\xff\x1a\x55\x65\xd4\x26\xbc\x2c\x14\xa6\x7b\xa8\x1d\x97\x63\x76\xa5\x08\x52\xed\x21\x4f\xb3\xd4\xca\xb2\x77\x4b\x78\x32\x8a\x0e\xfb\x6a\x4a\xf1\x75\x08\x90\x2f\x33\x2a\x80\x93\x77\x3a\x60\x93\x8f\x4c\x9b\xe0\xa7\x9f\xf8\xe6\x46\x87\xa7\xb9\x21\x7b\x54\x94\xa0\x41\x07\xe0\x5b\x3a\xa8\x5b\xc6\xd1\x0f\x3d\xb9\x13\xba\x2f\x6f\xaf\x7c\x7d\x42\x15\x6e\xc0\xb2\x8c\xbe\x34\x53\xcc\xbe\x1b\x77\x5b\x16\x2f\x60\x9c\x97\x77\x26\x97\xd1\xc1\x00\xb8\x6b\x8e\x8a\xb2\x1b\xc5\x93\x06\xa2\x5d\x91\x45\x2c\x9a\xcd\x50\x9e\x34\x6e\x5d\x80\xb6\xac\xc6\x67\x06\x8f\x7e\x09\xc2\x3c\x9a\x22\x17\xdc\x45\xd9\x1c\x29\xe5\xbc\x7d\xe5\x45\x39\x8d\xb2\x14\xa3\xe4\xf7\x68\x4a\x6f\x1a\x01\x6c\x53\x1d\xd0\x7d\x26\x60\xcb\xaa\xfb\x8d\x3e\x83\xad\x39\x89\x70\xff\x3e\xff\x50\x16\x33\x54\x92\x2f\x7c\xe0\x66\x36\x0e\x55\xe3\xb2\x75\x6c\x55\xd3\x4f\x12\x5e\xda\x74\x74\xd0\xb8\xbc\xaa\x46\x70\x2d\x47\x73\x36\xc7\x93\x06\x77\xd3\x37\x50\x48\x50\x86\x88\x70\x42\x2a\xe7\x7b\x15\x79\x6b\x9c\xbf\x32\x05\x8e\x11\xb1\xa5\xfd\x43\x30\xd7\x7a\x65\xe0\x55\xca\xe7\x87\x35\xe4\x97\xad\x2b\x7f\x39\x11\xc6\x88\xc0\x2c\xb3\x8d\xae\x95\xb6\x0e\x02\xf0\xd7\x5f\x32\x24\x42\xec\x24\xc2\xcf\x06\x84\x30\xc7\xae\x96\xf5\xc8\x98\xa6\x60\x11\x93\x67\xcc\xf3\x6f\xa6\x27\xd3\x64\x01\x82\x49\x44\xd2\x18\xd4\x48\xad\xa0\x4e\xf7\x9a\xa4\x78\x5f\xdc\xa3\x32\x8c\xc4\x58\x65\x36\xdd\x6f\xb4\x5d\xfd\x2f\x40\x9e\x6d\x20\x11\xd3\x26\xb3\x93\x5e\x06\x67\xb8\xf2\x14\x0b\x86\xcb\x53\x80\xd9\xb5\xe9\xa9\x0b\x1e\x29\x06\x73\xdc\x79\xd1\x6a\xb9\x80\xaf\x3f\xa1\x07\xd2\xd9\xec\x1f\x6d\xaa\x71\xa6\xf3\xb8\x58\x9e\x60\x9e\x74\x1b\x32\xca\xe2\x16\x74\x84\x6c\xf0\x5b\x07\xbc\x68\xb5\x68\xfb\x16\x1b\xff\x06\xed\x56\xcb\xa4\x16\xfb\x92\x63\xf9\x88\xda\xa7\x8e\xe9\x0f\x93\x84\x5e\x18\x14\x06\xc3\xc2\x26\x6d\x00\x9b\x31\x7f\xbb\xf4\x33\x25\xd8\xb4\x72\x91\xa0\x07\xd2\x58\x8a\xb8\x31\x28\x8b\x89\x94\x36\x74\x39\x9c\xf2\x64\x63\xf7\xb3\x25\xeb\x06\x17\xf9\x92\x2c\x6e\x18\xd3\xd2\x24\x13\x94\x37\x12\x2a\xea\xf0\x63\xff\xf7\xe6\x2c\x2a\x31\x6a\x24\x5b\xdf\x99\x4f\xc6\xbf\x09\x7a\xc2\xc3\xa0\x88\xcd\x0f\xf5\x38\x68\x54\xcc\x33\x3d\x10\x2e\x3d\xc4\xd7\x3e\x14\xbe\xfe\xd6\x43\xe1\x6a\x98\xc5\x2c\xfd\x43\xc1\x6c\xcc\x83\xcf\x04\xf3\xd2\x5b\x90\x5a\x98\x77\xfe\x09\xcc\x3c\xf7\x7f\x30\x9c\x75\xc1\x3e\x1b\xd0\x4b\x2f\x92\x6a\xa1\xfe\x75\x3d\xd4\x57\xf2\xfd\xfb\x69\x51\x10\x67\xd7\x71\x16\xff\x0b\x00\x00\xff\xff\x38\x09\x2e\x6d\xa5\x29\x00\x00")
+var _distFetchBundleJsMap = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xd4\x5a\x6d\x57\xdb\xba\x93\xff\x2a\x22\x2f\x1a\xa7\xeb\x3a\xb4\xf4\xe1\x5f\xbc\xb9\x1c\xd9\x0d\x2c\xa5\x2d\xa5\xb4\xb7\xb7\x4b\x38\x39\x8e\xad\x24\x06\xc7\x4e\x25\x85\xc2\xe5\x66\x3f\xfb\x9e\xd1\x83\x2d\x39\x4e\xa0\xbd\xbc\xa9\xe1\x24\xb6\x34\x33\x9a\xf9\xcd\x68\x34\x92\x73\xdb\xba\x22\x94\xa5\x45\xde\xda\xdd\x71\x5b\xac\x58\xd0\x98\xb0\xd6\xee\x59\xeb\x07\x19\xcd\xa3\xf8\x72\xb7\xdb\xed\xaa\xdb\xee\xa8\x28\x38\xe3\x34\x9a\xa3\xed\x17\x3b\xcf\x5e\xed\xec\xec\x24\x2f\x5f\x3f\x7f\x91\xec\x6c\xbf\xf8\xcf\xf3\x97\x2d\xd7\xe4\xf1\xba\x69\x9e\x90\x6b\xef\x82\xd5\xdb\xc7\x84\xc7\x53\xef\x82\xed\xbd\x8c\x47\x3b\xeb\x3a\xeb\xed\x94\x7c\x5f\x10\xc6\x1b\x7a\xa6\x24\x4a\x08\x65\x8d\x3c\x6c\x5e\xe4\x8c\x6c\x64\xda\x7b\xfd\x6a\xfc\x7c\xfd\x68\x7b\xcf\x5e\x8f\x47\x1b\x04\xef\xbd\x8c\xc9\xb3\x7a\x7f\x34\x2a\x28\x7f\x12\x17\x39\xa7\x45\x96\x11\x0a\x74\xaf\x5f\x3d\x27\xf7\xa0\x6b\x26\x61\xe9\x24\x8f\xb2\xbb\xbb\xf7\x9e\x3e\x4d\x5e\xb4\xce\xdd\x56\x1e\xcd\x84\x17\xcf\xdd\xd6\x2c\x9a\xcf\xd3\x7c\xc2\x5a\xbb\x2d\x1f\x63\x8c\x7d\x8c\x43\xec\xfb\x18\xf7\x57\x6f\x9b\x9e\xdd\x45\x80\x31\x81\xe7\x71\x33\x51\xf3\xad\x7c\xf6\x31\x3e\x58\xed\x5b\x73\xbb\xee\x0f\xf7\x79\x88\x5d\xf6\x56\x3f\xab\x2b\x0c\xd3\x00\xfb\x21\x3e\xe8\xfb\x21\xfe\x26\xf8\x43\x90\x73\x00\x46\x1e\xc1\x47\x79\x85\xf0\x24\x3a\xe5\xed\x81\xf1\x71\x19\xf4\xfd\xa3\x8a\x45\x74\x19\x0d\x46\xeb\x01\x0e\xfb\x92\xcb\x3f\xc0\xdf\x80\xeb\x8b\xd6\xe9\x14\x5a\xbf\x42\x3f\x3c\x29\xfe\x63\xf8\x38\xb5\x14\x31\x2f\xd1\x6d\x92\x36\x5e\x38\x7c\xd1\xff\xe0\x1e\x61\xfc\xde\x3d\xc6\xf8\xd8\x3d\xc0\xf8\xc0\x9d\x05\x70\x1b\x62\x1c\xba\x97\x01\xc6\x5f\xe5\x2d\x7c\x80\xbc\xfe\x34\xd0\xb4\xe1\xd8\x3d\xc5\x6f\x4e\xe4\x63\x88\xc3\x04\x24\x1d\xb9\x7d\x8c\x7f\x84\x7e\xd1\xc7\xf8\x2d\xdc\xf7\x85\x32\x79\xe8\xbe\x87\x61\x4e\x30\xfe\x20\xee\xa0\xb1\x2f\x59\x4f\x30\xfe\x28\xee\x64\xdb\x09\xc6\x27\xee\x09\xc6\x9f\xe0\xe3\x44\xb6\x1d\x62\x7c\x08\x8f\x6f\xc5\x9d\xef\xdf\x04\xf8\xcd\x34\x94\x4c\xe0\xf0\x6b\xa1\xd4\xa1\x54\x15\xc4\x4b\x53\x84\x3e\xd0\x06\x41\x30\x37\x69\x2a\x73\x41\xa0\x36\x01\x87\x42\x63\xc3\x62\x8c\xc3\xae\xc9\x07\x2a\x49\x3e\x71\xa7\x69\xfe\x36\x69\x84\xc4\x4a\xb6\xa0\x01\x25\x2f\x14\x91\xd0\xea\x1b\xc6\xdf\xe0\xf1\x58\x8c\x08\x32\x5e\x04\xee\x69\x29\x43\xb0\xdb\x36\xc8\x61\xf5\x88\xd4\xa4\x6e\xb0\x58\x80\x5c\x52\xef\x98\xd4\x6b\x6d\xaf\x98\xab\x36\x2d\xe1\x69\xe8\x9e\x36\xa0\x50\xb1\x54\x78\x1c\xe2\x70\x2a\x75\x02\xc6\x77\x9b\xad\xf2\x0f\x71\x98\x05\x62\xaa\xbe\x01\x55\x0e\x05\x63\x09\xca\x9f\x8d\x56\x56\xb6\x1d\xe2\x90\x5b\xdc\x16\xa4\x7f\xdd\xc7\xea\x9a\xad\x87\x38\xa4\xa1\x29\x51\x18\xa6\x25\xfe\x6f\x23\x0a\x96\xed\x4f\x2d\x7d\x04\xc4\x9a\xfb\x93\x7b\xba\x31\x4a\x24\x16\x7d\x1c\xee\xfb\x3e\x0b\x70\x37\xb8\x0c\x54\x8c\x57\x57\x18\x7e\x0f\xae\x83\x32\xf1\x88\xef\x13\x95\x70\xd4\x84\x37\x3f\xab\xc9\x7f\x8a\xdf\x8b\x34\x70\x8a\x17\xc1\xbe\x96\xe6\x1f\x41\x5e\x39\xc6\xc7\x55\x8b\xcd\x57\x13\x72\x57\x7f\xed\xd1\xf7\x9b\x12\x95\x22\x2a\x13\x5a\xd3\x1f\x0e\x69\xff\xed\xcf\xa7\x26\xf0\x84\x8f\xf1\xd1\x34\x70\xbf\xe1\x77\x27\x3a\x2a\x8f\xc6\xa5\x23\x26\x6f\x7c\x66\xe4\xa6\xe7\x01\xc6\x3b\x2a\x5a\x4f\x75\x72\x72\xff\x2b\xd0\xe1\xea\x4f\x42\xc8\x08\x5f\x30\xfe\xe2\x9f\x62\xfc\xa7\xb8\x73\x67\xa5\x0b\xfd\x97\x81\x4e\x64\xd0\x2f\x13\x99\x3b\x29\x73\x89\xef\x3f\x0b\xf0\xbb\x2b\x39\x3f\x7c\xff\x0b\x0e\x77\x02\x23\x72\x40\x2b\xff\x0b\xee\x8f\xc1\xd4\x23\xa3\x0d\x16\xb7\x3f\x7f\x21\x7c\x21\xd1\x99\x53\x15\x74\x30\x26\x4d\x2d\xea\xaa\x29\x65\x25\x97\xeb\xf0\x8e\x69\x57\x4f\x40\x02\x14\x21\x42\xdc\x69\x9a\x57\xc1\xe6\x80\x37\x94\x2f\xc7\x77\x63\x8c\x63\xc3\xa0\x43\x1c\xbe\x0e\x7d\x7f\x12\xe0\xbf\x2e\x95\x7f\xfd\x6f\x38\xba\x0a\xdc\x49\x80\xf1\x01\xac\x62\x90\x64\x62\x90\x54\x83\x26\x85\x95\x0c\x38\xad\xd6\xf7\x00\x74\x95\x7f\x4e\x30\xc6\xc6\x70\xef\x71\x38\x7f\xe3\xfb\xfe\x37\xdc\x3f\x50\x23\xd4\xe4\x57\xc6\x54\x6b\x45\xd5\xf1\x15\x64\x4e\x82\x72\xea\xd7\x12\x6b\x35\xd0\xea\xb8\xaf\x42\x1f\x02\x24\x0f\x4a\x3b\x21\x09\xe8\x75\xb0\xbc\x70\xb8\xff\x2a\x70\xb7\x8d\x8a\x05\x87\x18\x5b\x0d\x61\x28\x4a\x12\x8c\x37\xb4\x54\x7f\x61\x78\x78\x68\x4c\x4c\x79\x73\xb0\xbf\x69\x86\x86\xe1\x2c\xa8\x44\x1d\x41\xf1\x73\x84\x0f\x71\x2d\x05\x94\xb3\xbe\x94\xdb\x90\x0c\x54\xb6\x50\x8d\x4d\x04\xea\x0a\xc3\x69\xf8\x56\x58\xd5\x72\x5b\xe3\x34\x23\xad\xdd\xd6\x68\x91\x27\x99\xaa\xc1\xd5\xf6\x22\x2c\x72\x4e\x72\x0e\xbb\x0c\x34\xe0\xdd\x2e\xfa\x3c\x25\x68\x56\x24\x8b\x8c\xa0\x38\x8a\xa7\x64\x90\xa3\x01\xbf\x8a\x28\x4a\x73\xc6\xa3\x2c\x23\xc9\x7b\xd1\xcb\x50\x0f\xdd\x2e\xfd\x41\x3e\xc8\x2b\x46\x28\xd6\x53\x4a\xd0\x78\x91\xc7\x3c\x2d\x64\x9f\x7e\x40\xc3\xa1\x2a\x98\x87\x8a\x6e\x38\x74\x66\x42\xd8\x61\xd2\x41\xb7\x4a\x94\xd0\x22\x9c\x92\xf8\x12\xa5\x63\xad\x4a\xca\x50\x9a\x1b\x0a\x0d\x78\x3a\x76\xea\x1a\x9d\x69\x61\xe7\x1d\x45\x34\xe0\x94\xf0\x05\xcd\xd1\x7a\x52\x8f\x5c\xcf\x0b\xca\x99\xb6\x44\x0e\x4f\x49\xc4\x09\x8a\x50\x4e\x7e\x68\x15\x9c\x28\x4f\xd0\x7c\xc1\x51\xca\x51\x9a\xf3\x02\xf1\xa9\x82\x48\x8f\x06\x28\x29\xe2\xde\x86\x11\x01\x38\xc5\x31\xe0\x6a\xf4\x5d\x74\xbb\x74\xcb\xc6\x34\xd9\x55\x82\x0e\x93\xaa\x35\x2b\xa2\x84\x24\xbb\x68\x1c\x65\x4c\xa3\x50\x3a\x40\xa8\xdd\xbf\x26\xf1\x82\x13\xa1\x99\x52\xc4\xf2\xc4\x80\xcf\x56\xcc\x8f\xa3\x2c\x53\x5e\xd0\x50\xb8\x6a\x70\x17\xd5\xdb\x1b\x3c\xd8\xb1\x34\xd8\xcf\xa2\x89\x39\x7c\xc4\x90\x54\xdb\x1a\xdf\x93\x6d\xa8\x87\x38\x5d\x10\x4b\xc0\x27\xe9\x2f\x10\xa1\x46\x45\xc5\xd8\x90\xa8\x28\x95\x5b\x6d\xfd\x7c\xd1\xb9\x1c\xe4\x55\x50\x82\x0c\x66\x02\xc2\x50\x31\xba\x20\x31\x47\x4e\x65\x8b\xea\x19\x0e\xa5\x23\x1b\x8c\xf4\x66\xa8\xa7\x05\xf8\x1b\xa4\x1b\x11\xda\x24\x25\x6e\x88\x0b\x43\x5c\xc5\x32\x5f\x8c\xb2\x34\x1e\xce\x23\x3e\x1d\x0e\xd7\x8a\x9b\xa3\x1e\x1a\xb4\x06\x2d\x43\xc4\xbb\x22\x4a\x10\xc9\x39\xbd\xd1\x1a\x41\xd4\x2a\xb4\x14\x4c\x42\x9e\x6a\x6a\x10\xeb\x6c\x4b\x97\xc2\x5f\xf7\xf1\x63\xf4\xb5\x1f\x7c\xc4\xe1\x11\xda\x3f\x3e\xfe\xdc\xff\x84\x1e\x3f\x1e\xe4\xe8\xf1\x63\x74\xbf\x83\x08\x41\xdb\x6d\xb9\x2d\x25\xde\x69\x83\x12\x8c\xec\x89\xc3\x85\x2d\x75\xc8\xd0\x86\x11\xeb\x14\xff\x23\xcf\x05\xb6\xca\x13\x82\x46\xaa\x4f\xf2\x30\x62\xab\x3c\x28\x58\x43\x25\x4f\x09\xb6\xaa\x03\x83\x46\x3a\x0c\x7b\xf8\xb0\x3c\x04\xd8\x5a\x3d\x17\x58\xcf\x76\x2a\x76\xfe\x25\x8b\x3c\x27\x68\xdf\x0b\xcb\xea\x80\xa6\xc4\xcb\x8e\x6c\xd4\x43\x93\xac\x18\x45\xd9\xd9\xa0\x25\x10\x1b\xb4\xce\x51\x4f\xa7\x5b\x67\xd0\x7a\xb2\xd5\xfd\xc2\x08\x65\xdd\xb8\xc8\x69\x94\x74\xbf\x16\xf4\xb2\x3b\x29\xba\x8c\xc6\xdd\xf1\x65\xce\x28\xf3\x46\xe9\xdf\xdd\x79\xb7\xe0\xbc\x20\xd7\x5c\xe2\xde\xbd\x60\xdd\xbc\x48\x88\x9e\x01\xdd\x51\x34\x22\xd9\x13\x31\x3b\x69\xa9\xd3\x1e\xe3\xd1\x84\xf4\xb6\x7f\x79\x08\x7d\x90\x34\x68\x69\x34\xba\x8f\xeb\x97\x30\xbc\x86\x8f\x06\xe7\xff\xba\x12\x67\xa5\x98\x1d\x3b\x1a\x34\x1d\xef\x69\x82\x7a\xe8\xa9\xd5\x14\x4f\x17\xf9\x25\xac\x57\xdb\x25\xbc\x71\x91\x33\x8e\x54\xf0\x18\x48\xb6\xed\x38\xd2\x64\x32\x7a\xea\x74\x46\x20\x0d\x72\x91\xbb\x04\x27\x4a\xc8\x38\x5a\x64\x9c\x21\xb5\x48\xcc\xa2\x7c\x11\x65\x88\x92\x24\xa5\x90\x7a\x66\x45\x42\x5c\xf4\x63\x9a\x42\x7e\x2e\xb2\xac\xf8\x81\x52\x26\x28\x15\xa7\x90\x56\xe4\x04\x45\x71\x5c\xd0\x24\xcd\x27\x5a\xd4\x3e\x98\x8c\x18\x8f\xf2\x24\xa2\x89\x8b\x58\x81\x52\xde\x66\x28\x9a\xcf\xb3\x94\x24\x68\x91\x67\x84\x31\x14\xe5\x05\x9f\x12\xb1\x1a\x89\xb5\x93\x11\xee\x0d\xf2\x72\x21\xd6\x9a\xbc\x2f\x12\xe2\xa4\xf9\x7c\xc1\x5d\x94\xe6\x29\x17\x8b\x30\x82\x65\xd7\x81\x47\xf4\xe8\x91\x68\xf6\x34\xbd\xea\x47\x3a\xa1\x58\x9d\x90\x7d\x91\x48\xbe\x5a\x82\x58\x2c\x61\x19\xcc\x63\x52\x8c\x4b\x78\x84\xd4\xf9\xa2\xe2\x44\xbd\x5e\x0f\xb5\x09\xa5\x05\x6d\xaf\x0e\x31\x5f\x34\x8f\xa1\x08\xda\x12\xc1\xb6\x3f\xc8\x97\xca\x0f\x22\x3e\x10\x5b\xcc\x45\xba\x43\x71\x94\xc7\x24\xcb\x22\x61\x7a\x31\x46\xca\xc1\x0c\x5d\xa5\x91\x40\x5d\x4e\x55\x54\xcc\x81\xc2\x15\x19\x93\x71\x4a\xa2\x59\x9a\x4f\xa4\x2b\x80\x49\xc5\xc0\xa8\x48\x6e\x2a\x4e\x41\xa6\x38\x91\xc3\x88\xf2\x50\x50\x24\x37\xa7\xa2\xaf\xe3\x0d\x72\x88\x5e\x5a\x86\x45\x59\x1c\x49\x3d\x1b\xf0\x97\x51\x47\xc9\x77\xd4\x13\x05\x88\x02\xce\xa2\x14\x48\x50\xf2\xdd\x00\x71\xbd\x5b\x7d\xed\x13\x60\x10\x06\x18\x6e\xd9\x2f\xe8\xec\x4d\xc4\x23\x03\xf9\xef\xde\x58\x35\xca\x90\x17\x3c\x7e\xd5\x0b\x8f\xa0\xdb\x22\xcb\xb4\x47\xb4\xd6\xa3\x34\x8f\xe8\x0d\xc8\x8c\x40\xa5\xe1\x50\x18\x39\x94\xcd\x43\x10\x1b\x71\x47\xcb\xa8\x14\xb3\xd8\xb6\x7a\x52\xb6\xa5\x91\xa4\x00\x60\xd7\xe8\x64\x0f\x6c\x0a\xbc\x9f\xe6\x94\xb0\x12\x6f\xe9\x6b\x47\xea\x27\xbb\x55\x90\xf4\x90\x35\x33\x64\xab\x49\x26\xbc\xbe\x42\x26\x5b\xf7\x84\x3b\x6b\x11\xe2\x74\xd0\xae\x56\xc8\x88\x6b\xa0\xfc\x48\x8b\x59\xca\x88\xe3\x50\xc2\x8a\xec\x8a\xb8\x88\x12\x28\x5e\x3a\xa8\xf7\x87\xc6\x06\xd0\x53\xba\x3d\x7a\xa4\xb4\xf4\xc4\xaa\x45\x92\x12\xc0\x52\xaa\xe4\x77\x86\xc3\x39\x4d\xaf\x22\x4e\xb4\x7f\x04\xc3\x50\x4c\x41\xa7\xd3\x51\x80\xa9\x89\x86\x50\x46\x38\x2a\xf2\x10\xd2\xa8\xea\x12\xa3\x0a\xf5\x8d\x31\x14\x09\xea\x21\x87\x50\xea\xca\xbc\xeb\xa2\xa4\xc8\x89\xa9\xf1\xaa\xd6\x40\x8e\xfe\xf9\x47\x52\x1a\x02\xe1\x5f\x59\x44\xc9\xac\xb8\x22\xfd\x2b\x92\xf3\x77\x29\xe3\x24\x27\xd4\x69\x0b\xad\xdb\x2e\x12\xdf\x5a\x6b\x4b\x73\xf8\x97\x73\xd9\x1b\x0e\xe7\x0b\x36\x5d\xd5\xac\x64\x5b\xd6\xcd\x96\x1e\x15\xc2\x45\x28\xd7\x30\x23\xb2\xda\x86\x68\x06\xc7\x30\x17\x81\x19\xa6\x9d\xcd\x56\x6e\x49\x85\x6c\x43\x7f\xc1\x4c\xc3\x48\x18\x88\x50\x6a\x49\xb4\x3d\x0e\xbd\xeb\x38\x95\x3e\x36\x33\xd3\x73\x45\xf6\xfa\xab\x7d\x5f\x98\x28\xe1\xc5\x76\xa4\xd6\xcd\xc9\x35\x4c\x42\x47\xb8\xbd\xc4\x9f\x92\x28\xc1\x59\xe6\x74\x6a\xd4\x11\xa5\xd1\x4d\xb0\x18\x8f\x09\x15\x73\x9b\x79\xa3\xac\x18\x95\xfc\x6a\x16\x78\xca\x12\x98\x18\x9f\x6f\xe6\xa4\x2f\x82\xb5\x2d\x27\x3a\x12\xca\x16\x63\x14\x29\x7d\x49\x52\xa5\xec\x94\xa1\xbc\xe0\x7a\x41\x20\x49\xbb\xd3\x08\x45\x09\x98\x98\x6b\x30\xe7\x34\xd9\xd2\xd5\xe1\xaf\x76\x3b\xeb\x80\x2b\x4d\x95\x6b\x0e\xea\xc9\xd0\xd4\x62\x2c\x5e\xe1\x70\x93\x57\x4d\xdd\x24\xb9\x8f\xfb\x97\xf0\xb5\xec\xe8\x55\xef\xce\x02\xd3\xaa\x95\xaa\x0a\x48\x15\xd9\x76\x65\x63\xd6\xdb\x2b\xab\x57\x9c\x45\x8c\x95\x6b\x79\xb5\x5e\xd1\x45\xcc\x0b\xaa\x97\x9d\xdb\x19\xe1\xd3\x22\x71\x91\x92\xe5\x96\x8b\x93\x2b\x5c\xb5\xec\xdd\x2e\x4b\xdb\xf9\x34\x65\x9e\x64\x40\x3d\xd4\x3e\xe8\x7f\x6e\x2b\x2b\x45\x8f\x12\xa1\x92\xb3\xd2\xd8\xb9\x5d\x6a\x28\x04\x91\x16\x0f\x02\x64\xbd\x65\xc9\xb0\xf3\x7e\xe5\x85\x75\x45\x8a\xe1\x17\xc1\xbf\xa0\x99\xc8\xe9\x50\x8c\x2c\x68\xe6\x5b\x9d\xa5\xea\x42\x9a\xb2\xc4\x26\x69\xb6\x41\xd2\xab\xbe\x8e\xcd\x61\x18\xd4\x50\x03\x21\xb4\x44\x24\x63\x64\xbd\x9a\x4d\x31\x27\x35\xab\xdb\x56\xaa\x6f\x29\x6e\x31\x6a\x15\x6b\x9c\xcd\x56\x69\xe2\x26\x41\xda\x88\xba\xa4\x86\x2a\xa6\x89\x1d\xdc\x58\x67\x55\xae\x35\xca\x81\xa5\x5a\xd9\x97\xf7\x98\x17\xaa\x18\x34\x67\x46\x63\xc0\x2b\xe3\xe4\xd8\xc3\xca\x70\x7d\x04\x56\x9b\x04\x65\x2d\xa7\xc3\x2c\xb5\xa2\x4c\x49\x33\x4c\x11\x14\x10\x61\x29\xf7\xb4\xf8\x26\x04\xf8\xcd\x1c\x04\x00\x9d\x2c\x9b\xe5\x71\x46\x5b\x97\x1a\xab\xc5\x13\x42\xe3\x82\x22\x07\x0e\xa7\x2e\xe1\x10\xcd\x52\x0e\xfe\xa1\xe7\x4a\x55\x2c\x67\x97\xe7\x6a\x54\x3d\xe0\x16\x86\xec\xec\xa5\x4c\x7c\x3b\x57\xf5\xf5\x19\x38\xcf\xae\x4c\xae\x52\x5f\xf8\xbf\x82\x52\xb2\x1f\xc5\x53\x87\xc0\x5a\x20\xc2\x26\x9a\xcf\x49\x9e\x38\x97\x2e\x22\x76\x26\x2e\xbf\x94\x08\x45\x08\x6f\xd0\x5d\x74\x15\x65\x0b\x52\x0e\x2e\xe0\x46\x39\x94\x7b\x59\xca\x48\xf2\x21\x9a\x11\xd4\xd3\xc0\x7a\x65\x07\xb4\x0b\x01\x76\xe6\xde\x3a\x16\xb0\x79\xd3\x88\x1d\xff\xc8\x3f\xd2\x62\x4e\x28\xbf\x91\x87\x61\x42\x47\xed\x03\xb7\x36\x86\x69\xbe\x45\x78\x66\xd3\xc1\xf6\xfc\x4c\xa3\x52\x22\xb2\x91\xc3\x13\xf5\xc9\x29\xa7\x69\x3e\x71\xa4\xb5\x1d\x5d\xa4\xc2\x57\x42\x32\xc2\x95\x31\x5a\x09\xd9\x56\xd3\x64\x03\x08\xe7\xa6\xc0\x09\xe1\xb6\xb4\x7f\x0b\xaa\x1d\x2a\x1b\x8d\x35\x71\x54\x0b\xaf\x4a\xce\x16\x60\xaa\x6b\xa3\x28\xef\xa2\x48\x73\xa7\xed\xa2\xb6\x05\xd7\x84\x70\x28\x36\x2c\x03\x1b\xc5\x6d\x82\x0b\xea\xb5\xb3\x73\x53\xec\x34\x62\x0f\x06\x9a\x52\xe7\x67\x60\x33\x55\x61\x84\x3f\xf4\xdc\xd8\x38\x38\x84\xb4\x15\x9e\x16\x30\x7a\x9e\xc3\x1c\x1a\x45\xf1\xa5\x2b\x60\xc6\x74\x52\xea\x05\xcf\xde\x25\xb9\x61\x4e\xa7\xcc\x0a\x30\x3c\x24\x06\xcd\x55\x4d\x41\x4c\x27\x52\x84\x57\xc6\xa9\x8b\xe0\x4b\xb6\xda\x73\x43\x4a\x45\xb7\x16\xae\x6a\x8e\x8b\x3e\xcb\xb0\x8e\xc7\x0a\xca\x1d\x4b\x02\xe3\x11\x4f\x63\xd4\x00\x4e\x4d\x2a\xa8\xe0\xf1\xe2\x5d\xf1\x83\xd0\x30\x2a\x77\x89\xcb\xfb\x56\x62\x4a\x87\x87\xac\xc5\x54\x9d\x2b\xf4\x34\x2a\xf3\xea\x70\x1d\x0d\x57\x0b\x20\x73\xc1\x82\x5e\x17\xdd\x02\x06\x0b\xd6\x7b\xb6\xbd\xed\xc2\x19\x13\x5f\xb0\xcf\xe4\x9a\xf7\xda\xc7\x47\xed\xb2\x92\xeb\xdd\x2e\x57\x8b\xb7\x7b\x15\x02\xc2\x05\xc5\x25\xea\x29\xd9\xe8\x8f\x1e\x7a\xb6\xbd\x0d\x2b\x97\x6a\xf8\x6f\xb4\xb3\xbd\x6d\x52\xab\x76\xcd\xb1\xda\x05\xfa\x95\x02\xe1\xc1\x24\x81\xb5\x12\xf5\x2c\x0d\x45\x2c\xb5\xe1\x38\x97\xe4\xfc\x09\x10\xd8\x49\x03\x36\x2e\x2b\x71\x64\x6e\xc5\xd5\xee\x00\x42\x56\x6f\x14\x84\x5d\x02\x60\x3b\x26\x2f\x58\x91\xaf\xc8\x12\xc4\x72\x14\x8f\x4f\x49\xee\x24\x20\xea\xed\xe9\xf1\x07\x6f\x1e\x51\x46\x9c\xa4\xf3\x93\xf1\xa4\xb7\x39\xf7\x3b\x3d\x56\xbe\xf9\xad\xce\x8f\xb5\xef\x1e\xee\x04\x79\xe5\xdd\x42\xe3\x29\xf2\xcb\xbb\x4e\x91\xd7\xc3\xac\xb6\x11\xbf\x15\xcc\x46\x29\xfc\x40\x30\xaf\xbc\x9c\x69\x84\xf9\xd5\xbf\x81\x59\xc6\xfe\x6f\x86\x73\x35\x61\x1f\x0c\xe8\x95\xf7\x5b\x8d\x50\xff\xe7\xd7\xa1\xae\xbd\x1a\xfb\xad\x10\x6f\xf8\x71\xef\x43\x21\x7f\xf7\x1b\xc3\x46\x47\xbc\xbe\xcb\x11\x62\x75\x46\xc6\x8b\x45\x03\xed\x76\xe3\x3b\xc6\xc6\xda\xa0\xa6\x1e\xba\xad\xaf\xfc\xe5\xfa\x24\x16\x26\x75\x58\x29\x97\x71\x63\x70\xbb\x54\x12\x83\x37\x72\x7a\xc3\xa1\xea\xfc\xb9\x35\x6c\x1d\x66\x6b\xb7\xe3\x26\x30\xb7\xa5\x4e\xd6\x79\x24\xb4\x16\xb9\x3e\xbd\x35\x0a\x9f\xe1\x30\x53\x67\x6b\x4c\x6f\xd2\x80\x76\xe5\xe0\x0d\xaa\x03\x17\x69\xda\xd2\x5c\xbd\x0b\x97\xdb\x6f\x21\x5f\xec\xbe\xd5\xce\x5c\xd3\xcb\x6e\xfd\xae\xa7\x7a\xaf\xa5\xf0\x32\x94\x90\xfb\x3e\xfd\xd8\xf1\x57\xf7\xc1\x4d\xa7\xc2\x77\x69\xb7\x55\x69\x67\x8c\x2d\xab\x90\x6a\x88\x41\xde\xa4\x11\xea\xad\x34\x79\xe3\x34\xe3\x84\x3a\x19\x94\x2b\x99\x38\x65\xd0\x7d\x56\x6c\x94\x01\x60\x29\x04\xc2\xd6\xbd\x93\x30\xed\x45\xc8\x24\xb5\x4b\x58\x15\xb7\x88\xc0\xe9\x28\xfc\x86\x06\x10\xd8\xd5\x36\xba\x88\x47\x74\x42\xf8\xae\x90\xb0\xf4\x6d\x34\xc4\x4f\x48\xa0\xfa\xd4\xf1\xb0\xd1\x37\x8a\xca\x11\x23\x75\xee\x02\xcb\x63\x59\x1a\x13\x63\x4b\xa3\x7b\x04\x52\xea\x5e\xc9\xfa\xa5\x69\xa1\xa6\xd6\x05\xbb\x6f\x96\x96\xbf\x44\xf8\x0d\x33\x74\x65\xe9\x43\x66\xe7\xc6\x1f\x66\x34\x66\xe5\xa7\xcf\x36\xa7\xe5\x73\xfd\xb3\xb9\x4f\x45\xc1\x5b\xbb\xad\xd6\xf2\xff\x07\x00\xa7\x18\x6d\xc3\xb3\x33\x00\x00")
 
 func distFetchBundleJsMapBytes() ([]byte, error) {
 	return bindataRead(
@@ -104,7 +104,7 @@ func distFetchBundleJsMap() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "dist-fetch/bundle.js.map", size: 10661, mode: os.FileMode(420), modTime: time.Unix(1505683992, 0)}
+	info := bindataFileInfo{name: "dist-fetch/bundle.js.map", size: 13235, mode: os.FileMode(420), modTime: time.Unix(1792125577, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -3,19 +3,116 @@ package fetch
 //go:generate go-bindata -pkg fetch -o dist_fetch.go ./dist-fetch/
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"time"
 
 	"github.com/robertkrimen/otto"
 
 	"github.com/status-im/status-go/geth/jail/internal/loop"
 	"github.com/status-im/status-go/geth/jail/internal/promise"
+	"github.com/status-im/status-go/geth/jail/internal/typedarray"
 	"github.com/status-im/status-go/geth/jail/internal/vm"
 )
 
+// streamChunkSize is the maximum size of a chunk of streamed response body.
+const streamChunkSize = 32 * 1024
+
+var (
+	// errTimeout is returned when the request doesn't complete within the configured timeout.
+	errTimeout = errors.New("The operation timed out")
+	// errAborted is returned when the request is aborted with AbortController.
+	errAborted = errors.New("The user aborted a request")
+	// errRedirect is returned when the response is a redirect, and the redirect mode is error.
+	errRedirect = errors.New("redirect is not allowed by the request")
+)
+
+// Redirect modes of a request, set with the redirect option of fetch.
+const (
+	// redirectFollow makes the client follow redirects, it's the default mode.
+	redirectFollow = "follow"
+	// redirectError rejects the request with an error if the response is a redirect.
+	redirectError = "error"
+	// redirectManual resolves the request with a redirect response as is.
+	redirectManual = "manual"
+)
+
+// Options configures limits applied to the requests made by fetch.
+// Zero values mean there's no limit.
+type Options struct {
+	// Timeout limits the time of a single request, including reading of the response body.
+	Timeout time.Duration
+	// MaxResponseSize limits the size of the response body in bytes.
+	MaxResponseSize int64
+	// Client is used to make requests, http.DefaultClient is used if nil.
+	Client *http.Client
+	// DisableDecompression makes gzip and deflate encoded response bodies to be
+	// passed as is, instead of being decoded the way browsers do it.
+	DisableDecompression bool
+	// Retry configures retrying of failed GET requests, they're not retried by default.
+	Retry RetryPolicy
+	// MaxConcurrent limits the number of requests in flight at the same time. Once it's
+	// reached, new requests wait for a slot, the waiting time counts towards Timeout.
+	MaxConcurrent int
+	// Interceptors are called around every request, in the given order.
+	Interceptors []Interceptor
+}
+
+// Interceptor observes and modifies requests made by fetch, e.g. to audit them or to add
+// authentication headers. Every attempt of a retried request is intercepted separately.
+// Interceptors are called from multiple goroutines, so they must be safe for concurrent use.
+type Interceptor interface {
+	// BeforeRequest is called before the request is sent, it may modify the request.
+	// Returned error blocks the request, the promise is rejected with it.
+	BeforeRequest(req *http.Request) error
+	// AfterResponse is called once the response headers are received or the request fails,
+	// with the time it took.
+	AfterResponse(req *http.Request, res *http.Response, err error, duration time.Duration)
+}
+
+// blockedError is returned if the request is blocked by an interceptor.
+type blockedError struct {
+	err error
+}
+
+func (e *blockedError) Error() string {
+	return "request blocked: " + e.err.Error()
+}
+
+// RetryPolicy configures retrying of GET requests failed with connection errors
+// or 5xx status codes. Promise is rejected (or resolved with the last 5xx response)
+// only once all the attempts fail. Timeout limits all the attempts together.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first one.
+	// Values below 2 disable retrying.
+	MaxAttempts int
+	// Backoff is the delay before the first retry, it's doubled for every next one.
+	Backoff time.Duration
+	// MaxBackoff limits the delay between retries, zero means no limit.
+	MaxBackoff time.Duration
+}
+
+// delay returns the delay before a given retry (1 for the first one).
+func (p RetryPolicy) delay(retry int) time.Duration {
+	d := p.Backoff
+	for i := 1; i < retry && (p.MaxBackoff <= 0 || d < p.MaxBackoff); i++ {
+		d *= 2
+	}
+
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		return p.MaxBackoff
+	}
+
+	return d
+}
+
 func mustValue(v otto.Value, err error) otto.Value {
 	if err != nil {
 		panic(err)
@@ -33,6 +130,7 @@ type fetchTask struct {
 	statusText   string
 	headers      map[string][]string
 	body         []byte
+	abort        context.CancelFunc
 }
 
 func (t *fetchTask) SetID(id int64) { t.id = id }
@@ -42,7 +140,7 @@ func (t *fetchTask) Execute(vm *vm.VM, l *loop.Loop) error {
 	var arguments []interface{}
 
 	if t.err != nil {
-		e, err := vm.Call(`new Error`, nil, t.err.Error())
+		e, err := errorValue(vm, t.err)
 		if err != nil {
 			return err
 		}
@@ -50,9 +148,19 @@ func (t *fetchTask) Execute(vm *vm.VM, l *loop.Loop) error {
 		arguments = append(arguments, e)
 	}
 
+	if err := t.setResponse(vm); err != nil {
+		return err
+	}
+
+	_, err := vm.CallFunction(t.cb, otto.NullValue(), arguments...)
+	return err
+}
+
+// setResponse fills the JS response object in.
+func (t *fetchTask) setResponse(vm *vm.VM) error {
 	// We're locking on VM here because underlying otto's VM
 	// is not concurrently safe, and this function indirectly
-	// access vm's functions in cb.Call/h.Set.
+	// access vm's functions in h.Call/jsRes.Set.
 	vm.Lock()
 	defer vm.Unlock()
 
@@ -79,11 +187,71 @@ func (t *fetchTask) Execute(vm *vm.VM, l *loop.Loop) error {
 		return err
 	}
 
-	_, err = t.cb.Call(otto.NullValue(), arguments...)
-	return err
+	return t.jsRes.Set("__binaryBody", binaryBodyFunc(t.body))
 }
 
+// Cancel aborts the request if it's still in flight.
 func (t *fetchTask) Cancel() {
+	if t.abort != nil {
+		t.abort()
+	}
+}
+
+// chunkTask passes a chunk of streamed response body to JS. The last task
+// of a stream is done, or carries an error if reading the body failed.
+type chunkTask struct {
+	id    int64
+	cb    otto.Value
+	chunk []byte
+	done  bool
+	err   error
+	abort context.CancelFunc
+}
+
+func (t *chunkTask) SetID(id int64) { t.id = id }
+func (t *chunkTask) GetID() int64   { return t.id }
+
+func (t *chunkTask) Execute(vm *vm.VM, l *loop.Loop) error {
+	e := otto.NullValue()
+	if t.err != nil {
+		var err error
+		if e, err = errorValue(vm, t.err); err != nil {
+			return err
+		}
+	}
+
+	_, err := vm.CallFunction(t.cb, otto.NullValue(), e, string(t.chunk), t.done)
+	return err
+}
+
+// Cancel aborts reading the body of a streamed response.
+func (t *chunkTask) Cancel() {
+	if t.abort != nil {
+		t.abort()
+	}
+}
+
+// errorValue converts request error to JS error value. Timed out and
+// aborted requests are reported with AbortError.
+func errorValue(vm *vm.VM, err error) (otto.Value, error) {
+	if err == errTimeout || err == errAborted {
+		return vm.MakeCustomError("AbortError", err.Error()), nil
+	}
+
+	return vm.Call(`new Error`, nil, err.Error())
+}
+
+// InFlight returns the number of fetch requests which haven't completed yet.
+func InFlight(l *loop.Loop) int {
+	var n int
+	for _, t := range l.Tasks() {
+		switch t.(type) {
+		case *fetchTask, *chunkTask:
+			n++
+		}
+	}
+
+	return n
 }
 
 // Define fetch
@@ -93,10 +261,32 @@ func Define(vm *vm.VM, l *loop.Loop) error {
 
 //DefineWithHandler fetch with handler
 func DefineWithHandler(vm *vm.VM, l *loop.Loop, h http.Handler) error {
+	return DefineWithOptions(vm, l, h, Options{})
+}
+
+// DefineWithOptions fetch with handler and limits applied to the requests.
+func DefineWithOptions(vm *vm.VM, l *loop.Loop, h http.Handler, opts Options) error {
 	if err := promise.Define(vm, l); err != nil {
 		return err
 	}
 
+	// Uint8Array is used for binary bodies, it doesn't share a buffer
+	// with other arrays (see typedarray.Define)
+	if err := typedarray.Define(vm); err != nil {
+		return err
+	}
+
+	client := opts.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	// slots limit the number of requests in flight, if configured
+	var slots chan struct{}
+	if opts.MaxConcurrent > 0 {
+		slots = make(chan struct{}, opts.MaxConcurrent)
+	}
+
 	jsData := MustAsset("dist-fetch/bundle.js")
 	smData := MustAsset("dist-fetch/bundle.js.map")
 
@@ -110,59 +300,137 @@ func DefineWithHandler(vm *vm.VM, l *loop.Loop, h http.Handler) error {
 		return err
 	}
 
+	_, err = vm.Run(streamSrc)
+	if err != nil {
+		return err
+	}
+
+	_, err = vm.Run(formDataSrc)
+	if err != nil {
+		return err
+	}
+
+	_, err = vm.Run(binarySrc)
+	if err != nil {
+		return err
+	}
+
+	err = vm.Set("__private__fetch_abort_error", func(c otto.FunctionCall) otto.Value {
+		return c.Otto.MakeCustomError("AbortError", errAborted.Error())
+	})
+	if err != nil {
+		return err
+	}
+
 	err = vm.Set("__private__fetch_execute", func(c otto.FunctionCall) otto.Value {
 		jsReq := c.Argument(0).Object()
 		jsRes := c.Argument(1).Object()
 		cb := c.Argument(2)
+		onChunk := c.Argument(3)
 
 		method := mustValue(jsReq.Get("method")).String()
 		urlStr := mustValue(jsReq.Get("url")).String()
+		redirect := mustValue(jsReq.Get("redirect")).String()
 		jsBody := mustValue(jsReq.Get("body"))
+		jsForm := mustValue(jsReq.Get("formData"))
+		jsBinaryBody := mustValue(jsReq.Get("binaryBody"))
 		var body io.Reader
+		header, err := encodeHeaders(mustValue(jsReq.Get("headers")).Object())
+		if err != nil {
+			panic(c.Otto.MakeTypeError(err.Error()))
+		}
 		if jsBody.IsString() {
 			body = strings.NewReader(jsBody.String())
+		} else if jsBinaryBody.IsObject() {
+			data, err := encodeBinaryBody(jsBinaryBody.Object(), mustValue(jsReq.Get("binaryFormat")).Object())
+			if err != nil {
+				panic(c.Otto.MakeTypeError(err.Error()))
+			}
+			body = bytes.NewReader(data)
+		} else if jsForm.IsObject() {
+			data, contentType, err := encodeFormData(jsForm.Object())
+			if err != nil {
+				panic(c.Otto.MakeTypeError(err.Error()))
+			}
+			body = bytes.NewReader(data)
+			header.Set("Content-Type", contentType)
+		}
+
+		// Request is cancelled either by the abort function returned
+		// to the caller, when it exceeds the timeout, or when the task
+		// is cancelled by the loop.
+		var (
+			ctx    context.Context
+			cancel context.CancelFunc
+		)
+		if opts.Timeout > 0 {
+			ctx, cancel = context.WithTimeout(context.Background(), opts.Timeout)
+		} else {
+			ctx, cancel = context.WithCancel(context.Background())
 		}
 
 		t := &fetchTask{
 			jsReq: jsReq,
 			jsRes: jsRes,
 			cb:    cb,
+			abort: cancel,
 		}
 
 		// If err is non-nil, then the loop is closed
 		// and we shouldn't do anymore with it.
 		if err := l.Add(t); err != nil {
+			cancel()
 			return otto.UndefinedValue()
 		}
 
 		go func() {
-			defer l.Ready(t) // nolint: errcheck
+			defer cancel()
 
-			req, rqErr := http.NewRequest(method, urlStr, body)
-			if rqErr != nil {
-				t.err = rqErr
+			client, e := redirectClient(client, redirect)
+			if e != nil {
+				t.err = e
+				l.Ready(t) // nolint: errcheck
 				return
 			}
 
-			if h != nil && urlStr[0] == '/' {
-				res := httptest.NewRecorder()
+			if slots != nil {
+				select {
+				case slots <- struct{}{}:
+					defer func() { <-slots }()
+				case <-ctx.Done():
+					t.err = requestError(ctx, ctx.Err())
+					l.Ready(t) // nolint: errcheck
+					return
+				}
+			}
 
-				h.ServeHTTP(res, req)
+			res, e := doRequestWithRetry(ctx, client, h, opts, method, urlStr, header, body)
+			if e != nil {
+				t.err = requestError(ctx, e)
+				l.Ready(t) // nolint: errcheck
+				return
+			}
+			defer res.Body.Close() // nolint: errcheck
 
-				t.status = res.Code
-				t.statusText = http.StatusText(res.Code)
-				t.headers = res.Header()
-				t.body = res.Body.Bytes()
-			} else {
-				res, e := http.DefaultClient.Do(req)
-				if e != nil {
-					t.err = e
+			if redirect == redirectError && isRedirect(res) {
+				t.err = errRedirect
+				l.Ready(t) // nolint: errcheck
+				return
+			}
+
+			if !opts.DisableDecompression {
+				if e := decompressResponse(res); e != nil {
+					t.err = requestError(ctx, e)
+					l.Ready(t) // nolint: errcheck
 					return
 				}
+			}
 
-				d, e := ioutil.ReadAll(res.Body)
+			if !onChunk.IsFunction() {
+				d, e := readBody(res, opts.MaxResponseSize)
 				if e != nil {
-					t.err = e
+					t.err = requestError(ctx, e)
+					l.Ready(t) // nolint: errcheck
 					return
 				}
 
@@ -170,11 +438,216 @@ func DefineWithHandler(vm *vm.VM, l *loop.Loop, h http.Handler) error {
 				t.statusText = res.Status
 				t.headers = res.Header
 				t.body = d
+				l.Ready(t) // nolint: errcheck
+				return
+			}
+
+			// Response is resolved once headers are received, and body is
+			// passed in chunks. Last task is added right away, so that
+			// the request is considered in flight until body is read.
+			t.status = res.StatusCode
+			t.statusText = res.Status
+			t.headers = res.Header
+
+			last := &chunkTask{cb: onChunk, done: true, abort: cancel}
+			if err := l.Add(last); err != nil {
+				l.Ready(t) // nolint: errcheck
+				return
+			}
+			if err := l.Ready(t); err != nil {
+				return
 			}
+
+			last.err = streamBody(ctx, l, res.Body, onChunk, opts.MaxResponseSize)
+			l.Ready(last) // nolint: errcheck
 		}()
 
-		return otto.UndefinedValue()
+		return mustValue(c.Otto.ToValue(func(otto.FunctionCall) otto.Value {
+			cancel()
+			return otto.UndefinedValue()
+		}))
 	})
 
 	return err
 }
+
+// redirectClient returns the client handling redirects according to a given mode.
+// Client is copied, if its redirect policy has to be changed.
+func redirectClient(client *http.Client, mode string) (*http.Client, error) {
+	switch mode {
+	case redirectFollow:
+		return client, nil
+	case redirectError, redirectManual:
+		// redirect response is returned to the caller, which rejects it in error mode
+		c := *client
+		c.CheckRedirect = func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		}
+		return &c, nil
+	}
+
+	return nil, fmt.Errorf("invalid redirect mode: %s", mode)
+}
+
+// isRedirect checks whether the response is a redirect to another location.
+func isRedirect(res *http.Response) bool {
+	switch res.StatusCode {
+	case http.StatusMovedPermanently, http.StatusFound, http.StatusSeeOther,
+		http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+		return res.Header.Get("Location") != ""
+	}
+
+	return false
+}
+
+// doRequestWithRetry makes the request with doRequest, retrying GET requests failed with
+// connection errors or 5xx status codes according to the retry policy. Blocked requests
+// are not retried.
+func doRequestWithRetry(ctx context.Context, client *http.Client, h http.Handler, opts Options, method, urlStr string, header http.Header, body io.Reader) (*http.Response, error) {
+	policy := opts.Retry
+	attempts := 1
+	if method == http.MethodGet && policy.MaxAttempts > 1 {
+		attempts = policy.MaxAttempts
+	}
+
+	for attempt := 1; ; attempt++ {
+		res, err := doRequest(ctx, client, h, opts.Interceptors, method, urlStr, header, body)
+		if _, blocked := err.(*blockedError); blocked {
+			return nil, err
+		}
+		if attempt == attempts || ctx.Err() != nil {
+			return res, err
+		}
+		if err == nil && res.StatusCode < http.StatusInternalServerError {
+			return res, nil
+		}
+		if err == nil {
+			res.Body.Close() // nolint: errcheck
+		}
+
+		timer := time.NewTimer(policy.delay(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// doRequest makes the request with a given header, either with the handler for relative
+// URLs (if the handler is set), or with the client otherwise. Interceptors are called
+// before and after the request.
+func doRequest(ctx context.Context, client *http.Client, h http.Handler, interceptors []Interceptor, method, urlStr string, header http.Header, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequest(method, urlStr, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	req.Header.Set("Accept-Encoding", acceptEncoding)
+
+	for _, i := range interceptors {
+		if err := i.BeforeRequest(req); err != nil {
+			return nil, &blockedError{err: err}
+		}
+	}
+
+	start := time.Now()
+	res, err := send(client, h, req)
+	for _, i := range interceptors {
+		i.AfterResponse(req, res, err, time.Since(start))
+	}
+
+	return res, err
+}
+
+// send sends the request with the handler for relative URLs (if the handler is set),
+// or with the client otherwise.
+func send(client *http.Client, h http.Handler, req *http.Request) (*http.Response, error) {
+	if h != nil && req.URL.String()[0] == '/' {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		res := rec.Result()
+		res.Status = http.StatusText(rec.Code)
+		return res, nil
+	}
+
+	return client.Do(req)
+}
+
+// streamBody reads the response body, passing every chunk to onChunk through
+// the loop. Reading stops with an error once body exceeds maxSize bytes.
+// If maxSize is zero, body size is not limited.
+func streamBody(ctx context.Context, l *loop.Loop, body io.Reader, onChunk otto.Value, maxSize int64) error {
+	var size int64
+	buf := make([]byte, streamChunkSize)
+
+	for {
+		n, err := body.Read(buf)
+		if n > 0 {
+			size += int64(n)
+			if maxSize > 0 && size > maxSize {
+				return newResponseTooLargeError(maxSize)
+			}
+
+			t := &chunkTask{cb: onChunk, chunk: append([]byte(nil), buf[:n]...)}
+			if err := l.Add(t); err != nil {
+				return err
+			}
+			if err := l.Ready(t); err != nil {
+				return err
+			}
+		}
+
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return requestError(ctx, err)
+		}
+	}
+}
+
+// readBody reads the response body, failing as soon as it exceeds maxSize bytes.
+// If maxSize is zero, body size is not limited.
+func readBody(res *http.Response, maxSize int64) ([]byte, error) {
+	if maxSize <= 0 {
+		return ioutil.ReadAll(res.Body)
+	}
+
+	if res.ContentLength > maxSize {
+		return nil, newResponseTooLargeError(maxSize)
+	}
+
+	d, err := ioutil.ReadAll(io.LimitReader(res.Body, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(d)) > maxSize {
+		return nil, newResponseTooLargeError(maxSize)
+	}
+
+	return d, nil
+}
+
+// requestError replaces the error caused by the cancelled request context
+// with either errTimeout or errAborted.
+func requestError(ctx context.Context, err error) error {
+	switch ctx.Err() {
+	case context.DeadlineExceeded:
+		return errTimeout
+	case context.Canceled:
+		return errAborted
+	}
+
+	return err
+}
+
+func newResponseTooLargeError(maxSize int64) error {
+	return fmt.Errorf("response body exceeds the limit of %d bytes", maxSize)
+}
@@ -1,9 +1,18 @@
 package fetch_test
 
 import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
 	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -102,6 +111,110 @@ func (s *FetchSuite) TestFetchHeaders() {
 	}
 }
 
+func (s *FetchSuite) TestFetchHeadersCaseInsensitive() {
+	// transport is used to keep header names exactly as the server sent them,
+	// as net/http would otherwise canonicalize them
+	client := &http.Client{
+		Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header: http.Header{
+					"content-type": {"text/plain"},
+					"SET-COOKIE":   {"a=1", "b=2"},
+				},
+				Body:    ioutil.NopCloser(strings.NewReader("hello")),
+				Request: req,
+			}, nil
+		}),
+	}
+
+	err := fetch.DefineWithOptions(s.vm, s.loop, nil, fetch.Options{Client: client})
+	s.NoError(err)
+
+	ch := make(chan struct{})
+	err = s.vm.Set("__capture", func(str string) {
+		s.JSONEq(`{"type":"text/plain","TYPE":"text/plain","cookie":"a=1, b=2","missing":null}`, str)
+		ch <- struct{}{}
+	})
+	s.NoError(err)
+
+	err = s.loop.Eval(`fetch('` + s.srv.URL + `').then(function(r) {
+    return __capture(JSON.stringify({
+      type: r.headers.get('Content-Type'),
+      TYPE: r.headers.get('CONTENT-TYPE'),
+      cookie: r.headers.get('set-cookie'),
+      missing: r.headers.get('X-Missing'),
+    }));
+  })`)
+	s.NoError(err)
+
+	select {
+	case <-ch:
+	case <-time.After(1 * time.Second):
+		s.Fail("test timed out")
+	}
+}
+
+func (s *FetchSuite) TestHeaders() {
+	err := fetch.Define(s.vm, s.loop)
+	s.NoError(err)
+
+	v, err := s.vm.Run(`
+    var h = new Headers({'Content-Type': 'text/plain'});
+    h.append('Set-Cookie', 'a=1');
+    h.append('set-cookie', 'b=2');
+    h.set('X-Count', 1);
+    h.set('x-count', 2);
+    h.append('X-Gone', 'yes');
+    h.delete('x-GONE');
+
+    var names = [];
+    h.forEach(function(value, name) { names.push(name + ': ' + value); });
+
+    JSON.stringify({
+      type: h.get('content-type'),
+      cookie: h.get('SET-COOKIE'),
+      cookies: h.getAll('Set-Cookie'),
+      count: h.get('X-Count'),
+      hasType: h.has('CONTENT-TYPE'),
+      hasGone: h.has('X-Gone'),
+      names: names,
+      request: new Request('/', {headers: h}).headers.get('Set-Cookie'),
+    });
+  `)
+	s.NoError(err)
+	s.JSONEq(`{"type":"text/plain","cookie":"a=1, b=2","cookies":["a=1","b=2"],"count":"2",`+
+		`"hasType":true,"hasGone":false,"names":["content-type: text/plain","set-cookie: a=1, b=2","x-count: 2"],`+
+		`"request":"a=1, b=2"}`, v.String())
+}
+
+func (s *FetchSuite) TestFetchRequestHeaders() {
+	ch := make(chan http.Header, 1)
+	s.mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		ch <- r.Header
+	})
+
+	err := fetch.Define(s.vm, s.loop)
+	s.NoError(err)
+
+	err = s.loop.Eval(`
+    var h = new Headers({'Content-Type': 'application/json'});
+    h.append('X-Tag', 'a');
+    h.append('x-tag', 'b');
+    fetch('` + s.srv.URL + `', {method: 'POST', headers: h, body: '{}'});
+  `)
+	s.NoError(err)
+
+	select {
+	case header := <-ch:
+		s.Equal("application/json", header.Get("Content-Type"))
+		s.Equal([]string{"a", "b"}, header["X-Tag"])
+		s.Equal("gzip, deflate", header.Get("Accept-Encoding"))
+	case <-time.After(1 * time.Second):
+		s.Fail("test timed out")
+	}
+}
+
 func (s *FetchSuite) TestFetchJSON() {
 	s.mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		// these spaces are here so we can disambiguate between this and the
@@ -193,6 +306,12 @@ loop:
 	s.Equal(5, count)
 }
 
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
 type FetchSuite struct {
 	suite.Suite
 
@@ -203,6 +322,861 @@ type FetchSuite struct {
 	vm   *vm.VM
 }
 
+func (s *FetchSuite) TestFetchTimeout() {
+	s.mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+			w.Write([]byte("hello")) //nolint: errcheck
+		}
+	})
+
+	err := fetch.DefineWithOptions(s.vm, s.loop, nil, fetch.Options{Timeout: 100 * time.Millisecond})
+	s.NoError(err)
+
+	ch := make(chan string)
+	err = s.vm.Set("__capture", func(str string) {
+		ch <- str
+	})
+	s.NoError(err)
+
+	err = s.loop.Eval(`fetch('` + s.srv.URL + `').then(function(r) {
+		__capture("resolved");
+	}, function(e) {
+		__capture(e.name);
+	})`)
+	s.NoError(err)
+
+	select {
+	case str := <-ch:
+		s.Equal("AbortError", str)
+	case <-time.After(500 * time.Millisecond):
+		s.Fail("test timed out")
+	}
+}
+
+func (s *FetchSuite) TestFetchMaxConcurrent() {
+	var active, maxActive int32
+	s.mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&active, 1)
+		defer atomic.AddInt32(&active, -1)
+		for {
+			max := atomic.LoadInt32(&maxActive)
+			if n <= max || atomic.CompareAndSwapInt32(&maxActive, max, n) {
+				break
+			}
+		}
+
+		time.Sleep(50 * time.Millisecond)
+		w.Write([]byte("hello")) //nolint: errcheck
+	})
+
+	err := fetch.DefineWithOptions(s.vm, s.loop, nil, fetch.Options{MaxConcurrent: 2})
+	s.NoError(err)
+
+	ch := make(chan string)
+	err = s.vm.Set("__capture", func(str string) {
+		ch <- str
+	})
+	s.NoError(err)
+
+	err = s.loop.Eval(`for (var i = 0; i < 6; i++) {
+		fetch('` + s.srv.URL + `').then(function(r) {
+			return r.text();
+		}).then(function(text) {
+			__capture(text);
+		}, function(e) {
+			__capture(e.message);
+		});
+	}`)
+	s.NoError(err)
+
+	for i := 0; i < 6; i++ {
+		select {
+		case str := <-ch:
+			s.Equal("hello", str)
+		case <-time.After(time.Second):
+			s.FailNow("test timed out")
+		}
+	}
+	s.Equal(int32(2), atomic.LoadInt32(&maxActive))
+}
+
+func (s *FetchSuite) TestFetchMaxResponseSize() {
+	chunk := []byte(strings.Repeat("a", 1024))
+	s.mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		// flushing makes response chunked, so its size is not known in advance
+		for i := 0; i < 10; i++ {
+			w.Write(chunk) //nolint: errcheck
+			w.(http.Flusher).Flush()
+		}
+	})
+
+	err := fetch.DefineWithOptions(s.vm, s.loop, nil, fetch.Options{MaxResponseSize: 4096})
+	s.NoError(err)
+
+	ch := make(chan string)
+	err = s.vm.Set("__capture", func(str string) {
+		ch <- str
+	})
+	s.NoError(err)
+
+	err = s.loop.Eval(`fetch('` + s.srv.URL + `').then(function(r) {
+		__capture("resolved");
+	}, function(e) {
+		__capture(e.message);
+	})`)
+	s.NoError(err)
+
+	select {
+	case str := <-ch:
+		s.Equal("response body exceeds the limit of 4096 bytes", str)
+	case <-time.After(time.Second):
+		s.Fail("test timed out")
+	}
+}
+
+func (s *FetchSuite) TestFetchGzip() {
+	s.mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		s.Equal("gzip, deflate", r.Header.Get("Accept-Encoding"))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Encoding", "gzip")
+		gz := gzip.NewWriter(w)
+		gz.Write([]byte(`{"hello":"world"}`)) //nolint: errcheck
+		gz.Close()                            //nolint: errcheck
+	})
+
+	err := fetch.Define(s.vm, s.loop)
+	s.NoError(err)
+
+	ch := make(chan string)
+	err = s.vm.Set("__capture", func(str string) {
+		ch <- str
+	})
+	s.NoError(err)
+
+	err = s.loop.Eval(`fetch('` + s.srv.URL + `').then(function(r) {
+		return r.json().then(function(d) {
+			__capture(d.hello + "," + r.headers.has('Content-Encoding'));
+		});
+	}, function(e) {
+		__capture(e.message);
+	})`)
+	s.NoError(err)
+
+	select {
+	case str := <-ch:
+		s.Equal("world,false", str)
+	case <-time.After(time.Second):
+		s.Fail("test timed out")
+	}
+}
+
+func (s *FetchSuite) TestFetchDeflate() {
+	s.mux.HandleFunc("/zlib", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "deflate")
+		zw := zlib.NewWriter(w)
+		zw.Write([]byte("zlib")) //nolint: errcheck
+		zw.Close()               //nolint: errcheck
+	})
+	s.mux.HandleFunc("/raw", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "deflate")
+		fw, _ := flate.NewWriter(w, flate.DefaultCompression)
+		fw.Write([]byte("raw")) //nolint: errcheck
+		fw.Close()              //nolint: errcheck
+	})
+
+	err := fetch.Define(s.vm, s.loop)
+	s.NoError(err)
+
+	ch := make(chan string)
+	err = s.vm.Set("__capture", func(str string) {
+		ch <- str
+	})
+	s.NoError(err)
+
+	err = s.loop.Eval(`Promise.all([
+		fetch('` + s.srv.URL + `/zlib').then(function(r) { return r.text(); }),
+		fetch('` + s.srv.URL + `/raw').then(function(r) { return r.text(); }),
+	]).then(function(d) {
+		__capture(d.join(","));
+	}, function(e) {
+		__capture(e.message);
+	})`)
+	s.NoError(err)
+
+	select {
+	case str := <-ch:
+		s.Equal("zlib,raw", str)
+	case <-time.After(time.Second):
+		s.Fail("test timed out")
+	}
+}
+
+func (s *FetchSuite) TestFetchDisableDecompression() {
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	gz.Write([]byte("hello")) //nolint: errcheck
+	gz.Close()                //nolint: errcheck
+
+	s.mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Write(compressed.Bytes()) //nolint: errcheck
+	})
+
+	err := fetch.DefineWithOptions(s.vm, s.loop, nil, fetch.Options{DisableDecompression: true})
+	s.NoError(err)
+
+	ch := make(chan string)
+	err = s.vm.Set("__capture", func(str string) {
+		ch <- str
+	})
+	s.NoError(err)
+
+	err = s.loop.Eval(`fetch('` + s.srv.URL + `').then(function(r) {
+		return r.text().then(function(d) {
+			__capture(r.headers.get('Content-Encoding') + "," + d.length);
+		});
+	})`)
+	s.NoError(err)
+
+	select {
+	case str := <-ch:
+		s.Equal(fmt.Sprintf("gzip,%d", compressed.Len()), str)
+	case <-time.After(time.Second):
+		s.Fail("test timed out")
+	}
+}
+
+func (s *FetchSuite) TestFetchRetry() {
+	var requests int32
+	s.mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("hello")) //nolint: errcheck
+	})
+
+	err := fetch.DefineWithOptions(s.vm, s.loop, nil, fetch.Options{
+		Retry: fetch.RetryPolicy{MaxAttempts: 3, Backoff: 10 * time.Millisecond},
+	})
+	s.NoError(err)
+
+	ch := make(chan string)
+	err = s.vm.Set("__capture", func(str string) {
+		ch <- str
+	})
+	s.NoError(err)
+
+	err = s.loop.Eval(`fetch('` + s.srv.URL + `').then(function(r) {
+		return r.text().then(function(d) { __capture(r.status + ":" + d); });
+	}, function(e) {
+		__capture(e.message);
+	})`)
+	s.NoError(err)
+
+	select {
+	case str := <-ch:
+		s.Equal("200:hello", str)
+		s.Equal(int32(3), atomic.LoadInt32(&requests))
+	case <-time.After(time.Second):
+		s.Fail("test timed out")
+	}
+
+	// attempts are exhausted, last response is passed
+	atomic.StoreInt32(&requests, -10)
+	err = s.loop.Eval(`fetch('` + s.srv.URL + `').then(function(r) {
+		__capture(String(r.status));
+	})`)
+	s.NoError(err)
+
+	select {
+	case str := <-ch:
+		s.Equal("503", str)
+		s.Equal(int32(-7), atomic.LoadInt32(&requests))
+	case <-time.After(time.Second):
+		s.Fail("test timed out")
+	}
+
+	// only GET requests are retried
+	atomic.StoreInt32(&requests, 0)
+	err = s.loop.Eval(`fetch('` + s.srv.URL + `', {method: 'POST'}).then(function(r) {
+		__capture(String(r.status));
+	})`)
+	s.NoError(err)
+
+	select {
+	case str := <-ch:
+		s.Equal("503", str)
+		s.Equal(int32(1), atomic.LoadInt32(&requests))
+	case <-time.After(time.Second):
+		s.Fail("test timed out")
+	}
+}
+
+func (s *FetchSuite) TestFetchRetryConnectionError() {
+	var requests int32
+	client := &http.Client{
+		Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			atomic.AddInt32(&requests, 1)
+			return nil, errors.New("connection reset")
+		}),
+	}
+
+	err := fetch.DefineWithOptions(s.vm, s.loop, nil, fetch.Options{
+		Client: client,
+		Retry:  fetch.RetryPolicy{MaxAttempts: 3, Backoff: 10 * time.Millisecond},
+	})
+	s.NoError(err)
+
+	ch := make(chan string)
+	err = s.vm.Set("__capture", func(str string) {
+		ch <- str
+	})
+	s.NoError(err)
+
+	err = s.loop.Eval(`fetch('` + s.srv.URL + `').then(function(r) {
+		__capture("resolved");
+	}, function(e) {
+		__capture("rejected");
+	})`)
+	s.NoError(err)
+
+	select {
+	case str := <-ch:
+		s.Equal("rejected", str)
+		s.Equal(int32(3), atomic.LoadInt32(&requests))
+	case <-time.After(time.Second):
+		s.Fail("test timed out")
+	}
+}
+
+func (s *FetchSuite) TestFetchWithClient() {
+	s.mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("hello")) //nolint: errcheck
+	})
+
+	requested := make(chan string, 1)
+	client := &http.Client{
+		Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			requested <- req.URL.String()
+			return http.DefaultTransport.RoundTrip(req)
+		}),
+	}
+
+	err := fetch.DefineWithOptions(s.vm, s.loop, nil, fetch.Options{Client: client})
+	s.NoError(err)
+
+	ch := make(chan string)
+	err = s.vm.Set("__capture", func(str string) {
+		ch <- str
+	})
+	s.NoError(err)
+
+	err = s.loop.Eval(`fetch('` + s.srv.URL + `/path').then(function(r) {
+		return r.text();
+	}).then(__capture)`)
+	s.NoError(err)
+
+	select {
+	case str := <-ch:
+		s.Equal("hello", str)
+	case <-time.After(time.Second):
+		s.Fail("test timed out")
+	}
+	s.Equal(s.srv.URL+"/path", <-requested)
+}
+
+func (s *FetchSuite) TestFetchStream() {
+	chunks := []string{"first", "second", "third"}
+	received := make(chan string)
+	ack := make(chan struct{})
+
+	s.mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		for _, chunk := range chunks {
+			w.Write([]byte(chunk)) //nolint: errcheck
+			w.(http.Flusher).Flush()
+
+			// next chunk is sent once the previous one is read by JS
+			select {
+			case <-ack:
+			case <-time.After(time.Second):
+				return
+			}
+		}
+	})
+
+	err := fetch.Define(s.vm, s.loop)
+	s.NoError(err)
+
+	err = s.vm.Set("__capture", func(str string) {
+		received <- str
+	})
+	s.NoError(err)
+
+	err = s.loop.Eval(`fetch('` + s.srv.URL + `', {stream: true}).then(function(r) {
+		__capture("status:" + r.status + ":" + r.headers.get("content-type"));
+
+		var reader = r.body.getReader();
+		function next() {
+			return reader.read().then(function(result) {
+				if (result.done) {
+					__capture("done");
+					return;
+				}
+
+				__capture("chunk:" + result.value);
+				return next();
+			});
+		}
+
+		return next();
+	}).catch(function(e) {
+		__capture("error:" + e.message);
+	})`)
+	s.NoError(err)
+
+	expected := []string{"status:200:text/plain"}
+	for _, chunk := range chunks {
+		expected = append(expected, "chunk:"+chunk)
+	}
+	expected = append(expected, "done")
+
+	for i, e := range expected {
+		select {
+		case str := <-received:
+			s.Equal(e, str)
+		case <-time.After(time.Second):
+			s.FailNow("test timed out")
+		}
+
+		if i > 0 && i <= len(chunks) {
+			ack <- struct{}{}
+		}
+	}
+}
+
+func (s *FetchSuite) TestFetchStreamText() {
+	s.mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		for i := 0; i < 3; i++ {
+			w.Write([]byte(strings.Repeat("a", 1024))) //nolint: errcheck
+			w.(http.Flusher).Flush()
+		}
+	})
+
+	err := fetch.Define(s.vm, s.loop)
+	s.NoError(err)
+
+	ch := make(chan string)
+	err = s.vm.Set("__capture", func(str string) {
+		ch <- str
+	})
+	s.NoError(err)
+
+	err = s.loop.Eval(`fetch('` + s.srv.URL + `', {stream: true}).then(function(r) {
+		return r.text();
+	}).then(function(text) {
+		__capture("text:" + text.length);
+	}, function(e) {
+		__capture(e.message);
+	})`)
+	s.NoError(err)
+
+	select {
+	case str := <-ch:
+		s.Equal("text:3072", str)
+	case <-time.After(time.Second):
+		s.Fail("test timed out")
+	}
+}
+
+func (s *FetchSuite) TestFetchStreamMaxResponseSize() {
+	s.mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		for i := 0; i < 3; i++ {
+			w.Write([]byte(strings.Repeat("a", 1024))) //nolint: errcheck
+			w.(http.Flusher).Flush()
+		}
+	})
+
+	err := fetch.DefineWithOptions(s.vm, s.loop, nil, fetch.Options{MaxResponseSize: 2048})
+	s.NoError(err)
+
+	ch := make(chan string)
+	err = s.vm.Set("__capture", func(str string) {
+		ch <- str
+	})
+	s.NoError(err)
+
+	// body exceeding the limit fails the stream, not the response
+	err = s.loop.Eval(`fetch('` + s.srv.URL + `', {stream: true}).then(function(r) {
+		__capture("resolved");
+		return r.text();
+	}).then(function(text) {
+		__capture("text:" + text.length);
+	}, function(e) {
+		__capture(e.message);
+	})`)
+	s.NoError(err)
+
+	for _, expected := range []string{"resolved", "response body exceeds the limit of 2048 bytes"} {
+		select {
+		case str := <-ch:
+			s.Equal(expected, str)
+		case <-time.After(time.Second):
+			s.FailNow("test timed out")
+		}
+	}
+}
+
+func (s *FetchSuite) TestFetchAbort() {
+	requestCancelled := make(chan struct{})
+	s.mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+			close(requestCancelled)
+		case <-time.After(time.Second):
+			w.Write([]byte("hello")) //nolint: errcheck
+		}
+	})
+
+	err := fetch.Define(s.vm, s.loop)
+	s.NoError(err)
+
+	ch := make(chan string)
+	err = s.vm.Set("__capture", func(str string) {
+		ch <- str
+	})
+	s.NoError(err)
+
+	err = s.loop.Eval(`
+		var controller = new AbortController();
+		fetch('` + s.srv.URL + `', {signal: controller.signal}).then(function(r) {
+			__capture("resolved");
+		}, function(e) {
+			__capture(e.name + ": " + e.message);
+		});
+		setTimeout(function() { controller.abort(); }, 50);
+	`)
+	s.NoError(err)
+
+	select {
+	case str := <-ch:
+		s.Equal("AbortError: The user aborted a request", str)
+	case <-time.After(500 * time.Millisecond):
+		s.Fail("test timed out")
+	}
+
+	select {
+	case <-requestCancelled:
+	case <-time.After(500 * time.Millisecond):
+		s.Fail("request context hasn't been cancelled")
+	}
+}
+
+func (s *FetchSuite) TestFetchAlreadyAborted() {
+	err := fetch.Define(s.vm, s.loop)
+	s.NoError(err)
+
+	ch := make(chan string)
+	err = s.vm.Set("__capture", func(str string) {
+		ch <- str
+	})
+	s.NoError(err)
+
+	err = s.loop.Eval(`
+		var controller = new AbortController();
+		controller.abort();
+		fetch('` + s.srv.URL + `', {signal: controller.signal}).catch(function(e) {
+			__capture(e.name + ":" + controller.signal.aborted);
+		});
+	`)
+	s.NoError(err)
+
+	select {
+	case str := <-ch:
+		s.Equal("AbortError:true", str)
+	case <-time.After(500 * time.Millisecond):
+		s.Fail("test timed out")
+	}
+}
+
+func (s *FetchSuite) TestFetchRedirect() {
+	s.mux.HandleFunc("/redirect", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/target", http.StatusFound)
+	})
+	s.mux.HandleFunc("/target", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("target")) //nolint: errcheck
+	})
+
+	err := fetch.Define(s.vm, s.loop)
+	s.NoError(err)
+
+	ch := make(chan string)
+	err = s.vm.Set("__capture", func(str string) {
+		ch <- str
+	})
+	s.NoError(err)
+
+	// redirect response is captured with its location, others with their body
+	testCases := []struct {
+		name     string
+		options  string
+		expected string
+	}{
+		{"default", `{}`, "200:target"},
+		{"follow", `{redirect: 'follow'}`, "200:target"},
+		{"manual", `{redirect: 'manual'}`, "302:/target"},
+		{"error", `{redirect: 'error'}`, "Error"},
+		{"invalid", `{redirect: 'invalid'}`, "Error"},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			err = s.loop.Eval(`fetch('` + s.srv.URL + `/redirect', ` + tc.options + `).then(function(r) {
+				return r.text().then(function(d) {
+					__capture(r.status + ':' + (r.status === 302 ? r.headers.get('Location') : d));
+				});
+			}).catch(function(e) {
+				__capture(e.name);
+			});`)
+			s.NoError(err)
+
+			select {
+			case str := <-ch:
+				s.Equal(tc.expected, str)
+			case <-time.After(time.Second):
+				s.Fail("test timed out")
+			}
+		})
+	}
+}
+
+func (s *FetchSuite) TestFetchFormData() {
+	// server echoes parsed multipart parts
+	s.mux.HandleFunc("/upload", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(1024); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		defer file.Close() //nolint: errcheck
+		data, err := ioutil.ReadAll(file)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		fmt.Fprintf(w, "%s|%s|%s|%s|%s", r.FormValue("field"), header.Filename,
+			header.Header.Get("Content-Type"), data, strings.SplitN(r.Header.Get("Content-Type"), ";", 2)[0])
+	})
+
+	err := fetch.Define(s.vm, s.loop)
+	s.NoError(err)
+
+	ch := make(chan string)
+	err = s.vm.Set("__capture", func(str string) {
+		ch <- str
+	})
+	s.NoError(err)
+
+	err = s.loop.Eval(`var form = new FormData();
+		form.append('field', 'value');
+		form.append('file', new Blob(['hello ', 'world'], {type: 'text/plain'}), 'hello.txt');
+		fetch('` + s.srv.URL + `/upload', {method: 'POST', body: form}).then(function(r) {
+			return r.text();
+		}).then(__capture, function(e) {
+			__capture(e.message);
+		});`)
+	s.NoError(err)
+
+	select {
+	case str := <-ch:
+		s.Equal("value|hello.txt|text/plain|hello world|multipart/form-data", str)
+	case <-time.After(time.Second):
+		s.Fail("test timed out")
+	}
+}
+
+func (s *FetchSuite) TestFormData() {
+	err := fetch.Define(s.vm, s.loop)
+	s.NoError(err)
+
+	value, err := s.vm.Run(`var form = new FormData();
+		form.append('a', 1);
+		form.append('b', new Blob(['data']));
+		form.append('a', 2);
+		form.set('b', 'replaced');
+		form['delete']('missing');
+		var names = [];
+		form.forEach(function(value, name) {
+			names.push(name + '=' + value);
+		});
+		[names.join(','), form.getAll('a').length, form.has('c'), form.get('c')].join(';')`)
+	s.NoError(err)
+	s.Equal("a=1,b=replaced,a=2;2;false;", value.String())
+}
+
+func (s *FetchSuite) TestFetchBinary() {
+	payload := []byte{0x00, 0x01, 0x7f, 0x80, 0xc3, 0xfe, 0xff}
+	s.mux.HandleFunc("/image", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		w.Write(payload) //nolint: errcheck
+	})
+
+	err := fetch.Define(s.vm, s.loop)
+	s.NoError(err)
+
+	ch := make(chan string)
+	err = s.vm.Set("__capture", func(str string) {
+		ch <- str
+	})
+	s.NoError(err)
+
+	err = s.loop.Eval(`fetch('` + s.srv.URL + `/image').then(function(r) {
+			return r.arrayBuffer();
+		}).then(function(bytes) {
+			__capture((bytes instanceof Uint8Array) + ':' + bytes.length + ':' + bytes.join(','));
+		}).catch(function(e) {
+			__capture(e.message);
+		});
+		fetch('` + s.srv.URL + `/image').then(function(r) {
+			return r.blob();
+		}).then(function(blob) {
+			__capture(blob.size + ':' + blob.type);
+		}).catch(function(e) {
+			__capture(e.message);
+		});`)
+	s.NoError(err)
+
+	expected := map[string]bool{"true:7:0,1,127,128,195,254,255": true, "7:image/png": true}
+	for range expected {
+		select {
+		case str := <-ch:
+			s.True(expected[str], str)
+		case <-time.After(time.Second):
+			s.Fail("test timed out")
+		}
+	}
+}
+
+func (s *FetchSuite) TestFetchBinaryBody() {
+	bodies := make(chan []byte)
+	s.mux.HandleFunc("/upload", func(w http.ResponseWriter, r *http.Request) {
+		data, err := ioutil.ReadAll(r.Body)
+		s.NoError(err)
+		bodies <- data
+	})
+
+	err := fetch.Define(s.vm, s.loop)
+	s.NoError(err)
+
+	testCases := []struct {
+		name     string
+		body     string
+		expected []byte
+	}{
+		{"Uint8Array", `new Uint8Array([0, 1, 127, 128, 195, 255])`, []byte{0, 1, 127, 128, 195, 255}},
+		{"Array", `[104, 105, 256 + 33]`, []byte("hi!")},
+		{"Uint16Array", `new Uint16Array([1, 0xabcd])`, []byte{0x01, 0x00, 0xcd, 0xab}},
+		{"Int32Array", `new Int32Array([-2])`, []byte{0xfe, 0xff, 0xff, 0xff}},
+		{"Float32Array", `new Float32Array([1.5])`, []byte{0x00, 0x00, 0xc0, 0x3f}},
+		{"Float64Array", `new Float64Array([-2])`, []byte{0, 0, 0, 0, 0, 0, 0, 0xc0}},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			err = s.loop.Eval(`fetch('` + s.srv.URL + `/upload', {method: 'POST', body: ` + tc.body + `});`)
+			s.NoError(err)
+
+			select {
+			case data := <-bodies:
+				s.Equal(tc.expected, data)
+			case <-time.After(time.Second):
+				s.Fail("test timed out")
+			}
+		})
+	}
+}
+
+// testInterceptor adds a header to every request, blocks requests to blocked path,
+// and records statuses of the responses.
+type testInterceptor struct {
+	blocked   string
+	responses chan int
+}
+
+func (i *testInterceptor) BeforeRequest(req *http.Request) error {
+	if req.URL.Path == i.blocked {
+		return errors.New("not allowed")
+	}
+
+	req.Header.Set("X-Auth", "secret")
+	return nil
+}
+
+func (i *testInterceptor) AfterResponse(req *http.Request, res *http.Response, err error, duration time.Duration) {
+	if err == nil {
+		i.responses <- res.StatusCode
+	}
+}
+
+func (s *FetchSuite) TestFetchInterceptors() {
+	var requested int32
+	s.mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requested, 1)
+		w.Write([]byte(r.Header.Get("X-Auth"))) //nolint: errcheck
+	})
+
+	interceptor := &testInterceptor{blocked: "/blocked", responses: make(chan int, 1)}
+	err := fetch.DefineWithOptions(s.vm, s.loop, nil, fetch.Options{
+		Interceptors: []fetch.Interceptor{interceptor},
+		Retry:        fetch.RetryPolicy{MaxAttempts: 3},
+	})
+	s.NoError(err)
+
+	ch := make(chan string)
+	err = s.vm.Set("__capture", func(str string) {
+		ch <- str
+	})
+	s.NoError(err)
+
+	err = s.loop.Eval(`fetch('` + s.srv.URL + `/allowed').then(function(r) {
+		return r.text();
+	}).then(__capture);`)
+	s.NoError(err)
+
+	select {
+	case str := <-ch:
+		s.Equal("secret", str)
+	case <-time.After(time.Second):
+		s.Fail("test timed out")
+	}
+	s.Equal(http.StatusOK, <-interceptor.responses)
+
+	err = s.loop.Eval(`fetch('` + s.srv.URL + `/blocked').catch(function(e) {
+		__capture(e.message);
+	});`)
+	s.NoError(err)
+
+	select {
+	case str := <-ch:
+		s.Equal("request blocked: not allowed", str)
+	case <-time.After(time.Second):
+		s.Fail("test timed out")
+	}
+
+	// blocked request is neither sent nor retried
+	s.Equal(int32(1), atomic.LoadInt32(&requested))
+}
+
 func (s *FetchSuite) SetupTest() {
 	s.mux = http.NewServeMux()
 	s.srv = httptest.NewServer(s.mux)
@@ -0,0 +1,178 @@
+package fetch
+
+import (
+	"bytes"
+	"fmt"
+	"mime/multipart"
+	"net/textproto"
+	"strings"
+
+	"github.com/robertkrimen/otto"
+)
+
+// formDataSrc defines Blob and FormData classes. Requests with a FormData body
+// are sent as multipart/form-data, see encodeFormData. Blob data is a string,
+// as there are no binary arrays in the VM.
+const formDataSrc = `'use strict';
+
+/**
+ * @constructor
+ */
+function Blob(parts, options) {
+  var data = '';
+  (parts || []).forEach(function(part) {
+    data += part instanceof Blob ? part.__data : String(part);
+  });
+
+  this.__data = data;
+  this.size = data.length;
+  this.type = options && options.type ? String(options.type).toLowerCase() : '';
+}
+
+Blob.prototype.text = function() {
+  return Promise.resolve(this.__data);
+};
+
+/**
+ * @constructor
+ */
+function FormData() {
+  this.__entries = [];
+}
+
+FormData.__entry = function(name, value, filename) {
+  var entry = {name: String(name), value: String(value), filename: null, type: ''};
+  if (value instanceof Blob) {
+    entry.value = value.__data;
+    entry.filename = filename !== undefined ? String(filename) : (value.name || 'blob');
+    entry.type = value.type;
+  }
+
+  return entry;
+};
+
+FormData.__value = function(entry) {
+  if (entry.filename === null) {
+    return entry.value;
+  }
+
+  var blob = new Blob([entry.value], {type: entry.type});
+  blob.name = entry.filename;
+  return blob;
+};
+
+FormData.prototype.append = function(name, value, filename) {
+  this.__entries.push(FormData.__entry(name, value, filename));
+};
+
+FormData.prototype.set = function(name, value, filename) {
+  var entry = FormData.__entry(name, value, filename);
+  var index = -1;
+  this.__entries = this.__entries.filter(function(e, i) {
+    if (e.name !== entry.name) {
+      return true;
+    }
+    if (index === -1) {
+      index = i;
+    }
+    return false;
+  });
+
+  if (index === -1) {
+    this.__entries.push(entry);
+  } else {
+    this.__entries.splice(index, 0, entry);
+  }
+};
+
+FormData.prototype.get = function(name) {
+  var all = this.getAll(name);
+  return all.length > 0 ? all[0] : null;
+};
+
+FormData.prototype.getAll = function(name) {
+  name = String(name);
+  return this.__entries.filter(function(e) {
+    return e.name === name;
+  }).map(FormData.__value);
+};
+
+FormData.prototype.has = function(name) {
+  return this.get(name) !== null;
+};
+
+// delete is a keyword, which can't be used as a property name with the dot notation in ES5
+FormData.prototype['delete'] = function(name) {
+  name = String(name);
+  this.__entries = this.__entries.filter(function(e) {
+    return e.name !== name;
+  });
+};
+
+FormData.prototype.forEach = function(callback, thisArg) {
+  var form = this;
+  this.__entries.slice().forEach(function(e) {
+    callback.call(thisArg, FormData.__value(e), e.name, form);
+  });
+};
+`
+
+// quoteEscaper escapes names and filenames in Content-Disposition of multipart parts.
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+// encodeFormData encodes entries of a FormData as multipart/form-data body.
+// It returns the body, and the content type carrying the boundary.
+func encodeFormData(form *otto.Object) ([]byte, string, error) {
+	entries, err := form.Get("__entries")
+	if err != nil {
+		return nil, "", err
+	}
+	if !entries.IsObject() {
+		return nil, "", fmt.Errorf("invalid form data entries: %s", entries.Class())
+	}
+
+	length, err := mustValue(entries.Object().Get("length")).ToInteger()
+	if err != nil {
+		return nil, "", err
+	}
+
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	for i := int64(0); i < length; i++ {
+		entry := mustValue(entries.Object().Get(fmt.Sprint(i))).Object()
+		name := mustValue(entry.Get("name")).String()
+		value := mustValue(entry.Get("value")).String()
+		filename := mustValue(entry.Get("filename"))
+
+		if filename.IsNull() {
+			if err := w.WriteField(name, value); err != nil {
+				return nil, "", err
+			}
+			continue
+		}
+
+		contentType := mustValue(entry.Get("type")).String()
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+
+		header := make(textproto.MIMEHeader)
+		header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`,
+			quoteEscaper.Replace(name), quoteEscaper.Replace(filename.String())))
+		header.Set("Content-Type", contentType)
+
+		part, err := w.CreatePart(header)
+		if err != nil {
+			return nil, "", err
+		}
+		if _, err := part.Write([]byte(value)); err != nil {
+			return nil, "", err
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, "", err
+	}
+
+	return buf.Bytes(), w.FormDataContentType(), nil
+}
@@ -0,0 +1,57 @@
+package fetch
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/robertkrimen/otto"
+)
+
+// encodeHeaders converts the Headers of a request built in JS to the header
+// sent with it. Every value of a header set several times is kept.
+func encodeHeaders(headers *otto.Object) (http.Header, error) {
+	names, err := headers.Call("keys")
+	if err != nil {
+		return nil, err
+	}
+	if !names.IsObject() {
+		return nil, fmt.Errorf("invalid header names: %s", names.Class())
+	}
+
+	header := make(http.Header)
+	err = forEachElement(names.Object(), func(name otto.Value) error {
+		values, err := headers.Call("getAll", name)
+		if err != nil {
+			return err
+		}
+		if !values.IsObject() {
+			return fmt.Errorf("invalid values of header %s: %s", name, values.Class())
+		}
+
+		return forEachElement(values.Object(), func(value otto.Value) error {
+			header.Add(name.String(), value.String())
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return header, nil
+}
+
+// forEachElement calls fn with every element of a JS array.
+func forEachElement(array *otto.Object, fn func(otto.Value) error) error {
+	length, err := mustValue(array.Get("length")).ToInteger()
+	if err != nil {
+		return err
+	}
+
+	for i := int64(0); i < length; i++ {
+		if err := fn(mustValue(array.Get(fmt.Sprint(i)))); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
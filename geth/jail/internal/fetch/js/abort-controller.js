@@ -0,0 +1,11 @@
+const AbortSignal = require('./abort-signal');
+
+export default class AbortController {
+  constructor() {
+    this.signal = new AbortSignal();
+  }
+
+  abort() {
+    this.signal.__abort();
+  }
+}
@@ -0,0 +1,35 @@
+export default class AbortSignal {
+  aborted = false;
+
+  onabort = null;
+
+  __listeners = [];
+
+  addEventListener(type, listener) {
+    if (type === 'abort' && typeof listener === 'function') {
+      this.__listeners.push(listener);
+    }
+  }
+
+  removeEventListener(type, listener) {
+    if (type !== 'abort') {
+      return;
+    }
+
+    this.__listeners = this.__listeners.filter(l => l !== listener);
+  }
+
+  __abort() {
+    if (this.aborted) {
+      return;
+    }
+    this.aborted = true;
+
+    const event = {type: 'abort', target: this};
+    if (typeof this.onabort === 'function') {
+      this.onabort(event);
+    }
+
+    this.__listeners.slice().forEach(listener => listener(event));
+  }
+}
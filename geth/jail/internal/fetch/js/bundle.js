@@ -53,6 +53,8 @@
 	__webpack_require__(/*! expose?Headers!./headers */ 6);
 	__webpack_require__(/*! expose?Request!./request */ 7);
 	__webpack_require__(/*! expose?Response!./response */ 8);
+	__webpack_require__(/*! expose?AbortController!./abort-controller */ 9);
+	__webpack_require__(/*! expose?AbortSignal!./abort-signal */ 12);
 
 /***/ },
 /* 1 */
@@ -80,18 +82,86 @@
 	var Request = __webpack_require__(/*! ./request */ 3);
 	var Response = __webpack_require__(/*! ./response */ 5);
 	
+	// Request defaults to the manual redirect mode, while follow is the default
+	// one according to the Fetch standard, so it's applied unless another mode is set.
+	function redirectMode(input, init) {
+	  if (init && init.redirect) {
+	    return init.redirect;
+	  }
+	
+	  if (input instanceof Request && input.redirect === 'error') {
+	    return input.redirect;
+	  }
+	
+	  return 'follow';
+	}
+	
+	// fetch supports cancellation of requests via the signal option, and streaming
+	// of response body via the stream option (see FetchBodyStream).
+	
 	function fetch(input, init) {
 	  var req = new Request(input, init);
+	  req.redirect = redirectMode(input, init);
+	  if (req.body instanceof FormData) {
+	    req.formData = req.body;
+	    req.body = null;
+	  }
+	  var binaryFormat = __fetch_binary_format(req.body);
+	  if (binaryFormat !== null) {
+	    req.binaryBody = req.body;
+	    req.binaryFormat = binaryFormat;
+	    req.body = null;
+	  }
 	  var res = new Response();
+	  var signal = init && init.signal;
+	  var stream = init && init.stream ? new FetchBodyStream() : null;
 	
 	  return new Promise(function (resolve, reject) {
-	    return __private__fetch_execute(req, res, function (err) {
+	    if (signal && signal.aborted) {
+	      return reject(__private__fetch_abort_error());
+	    }
+	
+	    var onChunk = undefined;
+	    if (stream) {
+	      onChunk = function (err, chunk, done) {
+	        if (signal && (err || done)) {
+	          signal.removeEventListener('abort', abort);
+	        }
+	
+	        stream.__push(err, chunk, done);
+	      };
+	    }
+	
+	    var abort = __private__fetch_execute(req, res, function (err) {
+	      if (signal && (err || !stream)) {
+	        signal.removeEventListener('abort', abort);
+	      }
+	
 	      if (err) {
 	        return reject(err);
 	      }
 	
+	      if (stream) {
+	        res.body = stream;
+	        res.bodyUsed = false;
+	        res.text = function () {
+	          return stream.__readAll();
+	        };
+	        res.arrayBuffer = res.blob = function () {
+	          return Promise.reject(new TypeError('binary body of a streamed response is not supported'));
+	        };
+	      }
+	
 	      return resolve(res);
-	    });
+	    }, onChunk);
+	
+	    if (stream) {
+	      stream.__cancel = abort;
+	    }
+	
+	    if (signal) {
+	      signal.addEventListener('abort', abort);
+	    }
 	  });
 	}
 	
@@ -210,7 +280,7 @@
 	        this._headers[normalisedName] = [];
 	      }
 	
-	      this._headers[normalisedName].push(value);
+	      this._headers[normalisedName].push(String(value));
 	    }
 	  }, {
 	    key: 'delete',
@@ -222,9 +292,11 @@
 	    value: function get(name) {
 	      var normalisedName = Headers.normaliseName(name);
 	
-	      if (this._headers[normalisedName]) {
-	        return this._headers[normalisedName][0];
+	      if (!Array.isArray(this._headers[normalisedName])) {
+	        return null;
 	      }
+	
+	      return this._headers[normalisedName].join(', ');
 	    }
 	  }, {
 	    key: 'getAll',
@@ -243,7 +315,21 @@
 	    value: function set(name, value) {
 	      var normalisedName = Headers.normaliseName(name);
 	
-	      this._headers[normalisedName] = [value];
+	      this._headers[normalisedName] = [String(value)];
+	    }
+	  }, {
+	    key: 'forEach',
+	    value: function forEach(callback, thisArg) {
+	      var _this2 = this;
+	
+	      this.keys().forEach(function (name) {
+	        return callback.call(thisArg, _this2.get(name), name, _this2);
+	      });
+	    }
+	  }, {
+	    key: 'keys',
+	    value: function keys() {
+	      return Object.keys(this._headers).sort();
 	    }
 	  }], [{
 	    key: 'normaliseName',
@@ -354,6 +440,134 @@
 	/* WEBPACK VAR INJECTION */(function(global) {module.exports = global["Response"] = __webpack_require__(/*! -!./~/babel-loader?stage=0!./response.js */ 5);
 	/* WEBPACK VAR INJECTION */}.call(exports, (function() { return this; }())))
 
+/***/ },
+/* 9 */
+/*!***************************************************************!*\
+  !*** ./~/expose-loader?AbortController!./abort-controller.js ***!
+  \***************************************************************/
+/***/ function(module, exports, __webpack_require__) {
+
+	/* WEBPACK VAR INJECTION */(function(global) {module.exports = global["AbortController"] = __webpack_require__(/*! -!./~/babel-loader?stage=0!./abort-controller.js */ 10);
+	/* WEBPACK VAR INJECTION */}.call(exports, (function() { return this; }())))
+
+/***/ },
+/* 10 */
+/*!******************************************************!*\
+  !*** ./~/babel-loader?stage=0!./abort-controller.js ***!
+  \******************************************************/
+/***/ function(module, exports, __webpack_require__) {
+
+	'use strict';
+	
+	Object.defineProperty(exports, '__esModule', {
+	  value: true
+	});
+	
+	var _createClass = (function () { function defineProperties(target, props) { for (var i = 0; i < props.length; i++) { var descriptor = props[i]; descriptor.enumerable = descriptor.enumerable || false; descriptor.configurable = true; if ('value' in descriptor) descriptor.writable = true; Object.defineProperty(target, descriptor.key, descriptor); } } return function (Constructor, protoProps, staticProps) { if (protoProps) defineProperties(Constructor.prototype, protoProps); if (staticProps) defineProperties(Constructor, staticProps); return Constructor; }; })();
+	
+	function _classCallCheck(instance, Constructor) { if (!(instance instanceof Constructor)) { throw new TypeError('Cannot call a class as a function'); } }
+	
+	var AbortSignal = __webpack_require__(/*! ./abort-signal */ 11);
+	
+	var AbortController = (function () {
+	  function AbortController() {
+	    _classCallCheck(this, AbortController);
+	
+	    this.signal = new AbortSignal();
+	  }
+	
+	  _createClass(AbortController, [{
+	    key: 'abort',
+	    value: function abort() {
+	      this.signal.__abort();
+	    }
+	  }]);
+	
+	  return AbortController;
+	})();
+	
+	exports['default'] = AbortController;
+	module.exports = exports['default'];
+
+/***/ },
+/* 11 */
+/*!**************************************************!*\
+  !*** ./~/babel-loader?stage=0!./abort-signal.js ***!
+  \**************************************************/
+/***/ function(module, exports, __webpack_require__) {
+
+	'use strict';
+	
+	Object.defineProperty(exports, '__esModule', {
+	  value: true
+	});
+	
+	var _createClass = (function () { function defineProperties(target, props) { for (var i = 0; i < props.length; i++) { var descriptor = props[i]; descriptor.enumerable = descriptor.enumerable || false; descriptor.configurable = true; if ('value' in descriptor) descriptor.writable = true; Object.defineProperty(target, descriptor.key, descriptor); } } return function (Constructor, protoProps, staticProps) { if (protoProps) defineProperties(Constructor.prototype, protoProps); if (staticProps) defineProperties(Constructor, staticProps); return Constructor; }; })();
+	
+	function _classCallCheck(instance, Constructor) { if (!(instance instanceof Constructor)) { throw new TypeError('Cannot call a class as a function'); } }
+	
+	var AbortSignal = (function () {
+	  function AbortSignal() {
+	    _classCallCheck(this, AbortSignal);
+	
+	    this.aborted = false;
+	    this.onabort = null;
+	    this.__listeners = [];
+	  }
+	
+	  _createClass(AbortSignal, [{
+	    key: 'addEventListener',
+	    value: function addEventListener(type, listener) {
+	      if (type === 'abort' && typeof listener === 'function') {
+	        this.__listeners.push(listener);
+	      }
+	    }
+	  }, {
+	    key: 'removeEventListener',
+	    value: function removeEventListener(type, listener) {
+	      if (type !== 'abort') {
+	        return;
+	      }
+	
+	      this.__listeners = this.__listeners.filter(function (l) {
+	        return l !== listener;
+	      });
+	    }
+	  }, {
+	    key: '__abort',
+	    value: function __abort() {
+	      if (this.aborted) {
+	        return;
+	      }
+	      this.aborted = true;
+	
+	      var event = { type: 'abort', target: this };
+	      if (typeof this.onabort === 'function') {
+	        this.onabort(event);
+	      }
+	
+	      this.__listeners.slice().forEach(function (listener) {
+	        return listener(event);
+	      });
+	    }
+	  }]);
+	
+	  return AbortSignal;
+	})();
+	
+	exports['default'] = AbortSignal;
+	module.exports = exports['default'];
+
+/***/ },
+/* 12 */
+/*!*******************************************************!*\
+  !*** ./~/expose-loader?AbortSignal!./abort-signal.js ***!
+  \*******************************************************/
+/***/ function(module, exports, __webpack_require__) {
+
+	/* WEBPACK VAR INJECTION */(function(global) {module.exports = global["AbortSignal"] = __webpack_require__(/*! -!./~/babel-loader?stage=0!./abort-signal.js */ 11);
+	/* WEBPACK VAR INJECTION */}.call(exports, (function() { return this; }())))
+
 /***/ }
 /******/ ]);
 //# sourceMappingURL=bundle.js.map
\ No newline at end of file
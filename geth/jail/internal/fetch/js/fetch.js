@@ -1,17 +1,80 @@
 const Request = require('./request');
 const Response = require('./response');
 
+// Request defaults to the manual redirect mode, while follow is the default
+// one according to the Fetch standard, so it's applied unless another mode is set.
+function redirectMode(input, init) {
+  if (init && init.redirect) {
+    return init.redirect;
+  }
+
+  if (input instanceof Request && input.redirect === 'error') {
+    return input.redirect;
+  }
+
+  return 'follow';
+}
+
+// fetch supports cancellation of requests via the signal option, and streaming
+// of response body via the stream option (see FetchBodyStream).
 export default function fetch(input, init) {
   const req = new Request(input, init);
+  req.redirect = redirectMode(input, init);
+  if (req.body instanceof FormData) {
+    req.formData = req.body;
+    req.body = null;
+  }
+  const binaryFormat = __fetch_binary_format(req.body);
+  if (binaryFormat !== null) {
+    req.binaryBody = req.body;
+    req.binaryFormat = binaryFormat;
+    req.body = null;
+  }
   const res = new Response();
+  const signal = init && init.signal;
+  const stream = init && init.stream ? new FetchBodyStream() : null;
 
   return new Promise((resolve, reject) => {
-    return __private__fetch_execute(req, res, err => {
+    if (signal && signal.aborted) {
+      return reject(__private__fetch_abort_error());
+    }
+
+    let onChunk;
+    if (stream) {
+      onChunk = (err, chunk, done) => {
+        if (signal && (err || done)) {
+          signal.removeEventListener('abort', abort);
+        }
+
+        stream.__push(err, chunk, done);
+      };
+    }
+
+    const abort = __private__fetch_execute(req, res, err => {
+      if (signal && (err || !stream)) {
+        signal.removeEventListener('abort', abort);
+      }
+
       if (err) {
         return reject(err);
       }
 
+      if (stream) {
+        res.body = stream;
+        res.bodyUsed = false;
+        res.text = () => stream.__readAll();
+        res.arrayBuffer = res.blob = () => Promise.reject(new TypeError('binary body of a streamed response is not supported'));
+      }
+
       return resolve(res);
-    });
+    }, onChunk);
+
+    if (stream) {
+      stream.__cancel = abort;
+    }
+
+    if (signal) {
+      signal.addEventListener('abort', abort);
+    }
   });
 }
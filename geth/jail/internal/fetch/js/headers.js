@@ -25,7 +25,7 @@ export default class Headers {
       this._headers[normalisedName] = [];
     }
 
-    this._headers[normalisedName].push(value);
+    this._headers[normalisedName].push(String(value));
   }
 
   delete(name) {
@@ -35,9 +35,11 @@ export default class Headers {
   get(name) {
     const normalisedName = Headers.normaliseName(name);
 
-    if (this._headers[normalisedName]) {
-      return this._headers[normalisedName][0];
+    if (!Array.isArray(this._headers[normalisedName])) {
+      return null;
     }
+
+    return this._headers[normalisedName].join(', ');
   }
 
   getAll(name) {
@@ -53,7 +55,15 @@ export default class Headers {
   set(name, value) {
     const normalisedName = Headers.normaliseName(name);
 
-    this._headers[normalisedName] = [value];
+    this._headers[normalisedName] = [String(value)];
+  }
+
+  forEach(callback, thisArg) {
+    this.keys().forEach(name => callback.call(thisArg, this.get(name), name, this));
+  }
+
+  keys() {
+    return Object.keys(this._headers).sort();
   }
 
   static normaliseName(name) {
@@ -2,3 +2,5 @@ require('expose?fetch!./fetch');
 require('expose?Headers!./headers');
 require('expose?Request!./request');
 require('expose?Response!./response');
+require('expose?AbortController!./abort-controller');
+require('expose?AbortSignal!./abort-signal');
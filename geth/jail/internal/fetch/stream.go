@@ -0,0 +1,128 @@
+package fetch
+
+// streamSrc defines the body stream of responses fetched with the stream option,
+// e.g. fetch(url, {stream: true}). Response is resolved as soon as headers are
+// received, and body chunks (strings) are read with response.body.getReader().
+const streamSrc = `'use strict';
+
+/**
+ * @constructor
+ */
+function FetchBodyStream() {
+  this.locked = false;
+  this.__chunks = [];
+  this.__reads = [];
+  this.__done = false;
+  this.__error = null;
+  this.__cancel = null;
+}
+
+FetchBodyStream.prototype.getReader = function() {
+  if (this.locked) {
+    throw new TypeError('ReadableStream is locked');
+  }
+  this.locked = true;
+
+  return new FetchBodyStreamReader(this);
+};
+
+// __push is called for every chunk of the body, and once the body is read
+// completely (done is true) or reading it failed (err is set).
+FetchBodyStream.prototype.__push = function(err, chunk, done) {
+  if (this.__done || this.__error) {
+    return;
+  }
+
+  if (err) {
+    this.__error = err;
+  } else if (done) {
+    this.__done = true;
+  } else {
+    this.__chunks.push(chunk);
+  }
+
+  this.__flush();
+};
+
+FetchBodyStream.prototype.__flush = function() {
+  while (this.__reads.length > 0) {
+    var read = this.__reads[0];
+
+    if (this.__chunks.length > 0) {
+      read.resolve({value: this.__chunks.shift(), done: false});
+    } else if (this.__error) {
+      read.reject(this.__error);
+    } else if (this.__done) {
+      read.resolve({value: undefined, done: true});
+    } else {
+      return;
+    }
+
+    this.__reads.shift();
+  }
+};
+
+FetchBodyStream.prototype.__read = function() {
+  var stream = this;
+
+  return new Promise(function(resolve, reject) {
+    stream.__reads.push({resolve: resolve, reject: reject});
+    stream.__flush();
+  });
+};
+
+FetchBodyStream.prototype.__readAll = function() {
+  var reader = this.getReader();
+  var chunks = [];
+
+  function pump() {
+    return reader.read().then(function(result) {
+      if (result.done) {
+        return chunks.join('');
+      }
+
+      chunks.push(result.value);
+      return pump();
+    });
+  }
+
+  return pump();
+};
+
+/**
+ * @constructor
+ */
+function FetchBodyStreamReader(stream) {
+  this.__stream = stream;
+}
+
+FetchBodyStreamReader.prototype.read = function() {
+  if (!this.__stream) {
+    return Promise.reject(new TypeError('Reader is released'));
+  }
+
+  return this.__stream.__read();
+};
+
+FetchBodyStreamReader.prototype.cancel = function() {
+  var stream = this.__stream;
+  if (stream && !stream.__done && !stream.__error) {
+    stream.__chunks = [];
+    stream.__done = true;
+    stream.__flush();
+
+    if (typeof stream.__cancel === 'function') {
+      stream.__cancel();
+    }
+  }
+
+  return Promise.resolve();
+};
+
+FetchBodyStreamReader.prototype.releaseLock = function() {
+  if (this.__stream) {
+    this.__stream.locked = false;
+    this.__stream = null;
+  }
+};
+`
@@ -0,0 +1,151 @@
+package localstorage
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/robertkrimen/otto"
+
+	"github.com/status-im/status-go/geth/jail/internal/vm"
+)
+
+// Store is a key-value storage backing the localStorage object.
+// Implementations must be safe for concurrent use, as a single store
+// can be shared by multiple cells.
+type Store interface {
+	Get(key string) (string, bool)
+	Set(key, value string)
+	Delete(key string)
+}
+
+// keyLister is implemented by stores able to enumerate their keys.
+// localStorage.clear is only supported for such stores.
+type keyLister interface {
+	Keys() []string
+}
+
+// MemoryStore implements in-memory Store.
+type MemoryStore struct {
+	sync.RWMutex
+	items map[string]string
+}
+
+// NewMemoryStore creates a new instance of MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		items: make(map[string]string),
+	}
+}
+
+// Get returns the value keyed by the provided key.
+func (s *MemoryStore) Get(key string) (string, bool) {
+	s.RLock()
+	defer s.RUnlock()
+
+	value, ok := s.items[key]
+	return value, ok
+}
+
+// Set sets the value to be keyed by the provided key.
+func (s *MemoryStore) Set(key, value string) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.items[key] = value
+}
+
+// Delete removes the value keyed by the provided key.
+func (s *MemoryStore) Delete(key string) {
+	s.Lock()
+	defer s.Unlock()
+
+	delete(s.items, key)
+}
+
+// Keys returns sorted list of the stored keys.
+func (s *MemoryStore) Keys() []string {
+	s.RLock()
+	defer s.RUnlock()
+
+	keys := make([]string, 0, len(s.items))
+	for key := range s.items {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// Define jail localStorage object backed by the given store.
+func Define(vm *vm.VM, store Store) error {
+	if v, err := vm.Get("localStorage"); err != nil {
+		return err
+	} else if !v.IsUndefined() {
+		return nil
+	}
+
+	v, err := vm.Run(`({})`)
+	if err != nil {
+		return err
+	}
+	obj := v.Object()
+
+	handlers := map[string]func(call otto.FunctionCall) otto.Value{
+		"getItem":    newGetItemHandler(store),
+		"setItem":    newSetItemHandler(store),
+		"removeItem": newRemoveItemHandler(store),
+		"clear":      newClearHandler(store),
+	}
+
+	for k, handler := range handlers {
+		if err := obj.Set(k, handler); err != nil {
+			return err
+		}
+	}
+
+	return vm.Set("localStorage", obj)
+}
+
+func newGetItemHandler(store Store) func(call otto.FunctionCall) otto.Value {
+	return func(call otto.FunctionCall) otto.Value {
+		value, ok := store.Get(call.Argument(0).String())
+		if !ok {
+			return otto.NullValue()
+		}
+
+		v, err := otto.ToValue(value)
+		if err != nil {
+			panic(err)
+		}
+
+		return v
+	}
+}
+
+func newSetItemHandler(store Store) func(call otto.FunctionCall) otto.Value {
+	return func(call otto.FunctionCall) otto.Value {
+		store.Set(call.Argument(0).String(), call.Argument(1).String())
+		return otto.UndefinedValue()
+	}
+}
+
+func newRemoveItemHandler(store Store) func(call otto.FunctionCall) otto.Value {
+	return func(call otto.FunctionCall) otto.Value {
+		store.Delete(call.Argument(0).String())
+		return otto.UndefinedValue()
+	}
+}
+
+func newClearHandler(store Store) func(call otto.FunctionCall) otto.Value {
+	return func(call otto.FunctionCall) otto.Value {
+		lister, ok := store.(keyLister)
+		if !ok {
+			panic(call.Otto.MakeTypeError("localStorage.clear is not supported by the store"))
+		}
+
+		for _, key := range lister.Keys() {
+			store.Delete(key)
+		}
+		return otto.UndefinedValue()
+	}
+}
@@ -0,0 +1,108 @@
+package localstorage_test
+
+import (
+	"testing"
+
+	"github.com/status-im/status-go/geth/jail/internal/localstorage"
+	"github.com/status-im/status-go/geth/jail/internal/vm"
+	"github.com/stretchr/testify/suite"
+)
+
+func (s *LocalStorageSuite) TestSetAndGetItem() {
+	v, err := s.vm.Run(`
+		localStorage.setItem("key", "value");
+		localStorage.getItem("key");
+	`)
+	s.NoError(err)
+	s.Equal("value", v.String())
+
+	value, ok := s.store.Get("key")
+	s.True(ok)
+	s.Equal("value", value)
+}
+
+func (s *LocalStorageSuite) TestGetMissingItem() {
+	v, err := s.vm.Run(`localStorage.getItem("missing") === null`)
+	s.NoError(err)
+	s.Equal("true", v.String())
+}
+
+func (s *LocalStorageSuite) TestSetItemConvertsToString() {
+	v, err := s.vm.Run(`
+		localStorage.setItem("number", 42);
+		typeof localStorage.getItem("number");
+	`)
+	s.NoError(err)
+	s.Equal("string", v.String())
+}
+
+func (s *LocalStorageSuite) TestRemoveItem() {
+	s.store.Set("key", "value")
+
+	_, err := s.vm.Run(`localStorage.removeItem("key")`)
+	s.NoError(err)
+
+	_, ok := s.store.Get("key")
+	s.False(ok)
+}
+
+func (s *LocalStorageSuite) TestClear() {
+	s.store.Set("key1", "value1")
+	s.store.Set("key2", "value2")
+
+	_, err := s.vm.Run(`localStorage.clear()`)
+	s.NoError(err)
+	s.Empty(s.store.Keys())
+}
+
+// getSetDeleteStore is a store which can't enumerate its keys.
+type getSetDeleteStore struct {
+	localstorage.Store
+}
+
+func (s *LocalStorageSuite) TestClearNotSupported() {
+	o := vm.New()
+	err := localstorage.Define(o, getSetDeleteStore{s.store})
+	s.NoError(err)
+
+	_, err = o.Run(`localStorage.setItem("key", "value")`)
+	s.NoError(err)
+
+	_, err = o.Run(`localStorage.clear()`)
+	s.Error(err)
+
+	_, ok := s.store.Get("key")
+	s.True(ok)
+}
+
+func (s *LocalStorageSuite) TestSharedStore() {
+	_, err := s.vm.Run(`localStorage.setItem("key", "value")`)
+	s.NoError(err)
+
+	o := vm.New()
+	err = localstorage.Define(o, s.store)
+	s.NoError(err)
+
+	v, err := o.Run(`localStorage.getItem("key")`)
+	s.NoError(err)
+	s.Equal("value", v.String())
+}
+
+type LocalStorageSuite struct {
+	suite.Suite
+
+	vm    *vm.VM
+	store *localstorage.MemoryStore
+}
+
+func (s *LocalStorageSuite) SetupTest() {
+	s.vm = vm.New()
+	s.store = localstorage.NewMemoryStore()
+
+	err := localstorage.Define(s.vm, s.store)
+	s.NoError(err)
+}
+
+func TestLocalStorageSuite(t *testing.T) {
+	suite.Run(t, new(LocalStorageSuite))
+}
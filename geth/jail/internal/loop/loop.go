@@ -3,16 +3,44 @@ package loop
 import (
 	"context"
 	"errors"
+	"fmt"
+	"runtime/debug"
 	"sync"
 	"sync/atomic"
 
 	"github.com/status-im/status-go/geth/jail/internal/vm"
+	"github.com/status-im/status-go/geth/log"
 )
 
 // ErrClosed represents the error returned when we try to add or ready
 // a task on a closed loop.
 var ErrClosed = errors.New("The loop is closed and no longer accepting tasks")
 
+// ErrTaskPanicked is the cause of a panic recovered while executing a task.
+// The task is cancelled as if it returned an error, while the loop keeps running.
+var ErrTaskPanicked = errors.New("task panicked")
+
+// ErrLoopPanicked is the cause of a panic recovered outside of task execution,
+// e.g. while cancelling a task. The loop stops, returning it from Run.
+var ErrLoopPanicked = errors.New("loop panicked")
+
+// PanicError is the error a recovered panic is converted to.
+type PanicError struct {
+	// Err is either ErrTaskPanicked or ErrLoopPanicked.
+	Err error
+	// Value is the value passed to panic.
+	Value interface{}
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("%v: %v", e.Err, e.Value)
+}
+
+// Cause returns either ErrTaskPanicked or ErrLoopPanicked.
+func (e *PanicError) Cause() error {
+	return e.Err
+}
+
 // Task represents something that the event loop can schedule and run.
 //
 // Task describes two operations that will almost always be boilerplate,
@@ -30,22 +58,50 @@ type Task interface {
 	Cancel()
 }
 
+// Priority defines the order in which ready tasks are executed.
+type Priority int
+
+// Tasks of high priority, like calls triggered by the user, are executed
+// before ready tasks of normal priority, like timer ticks.
+const (
+	PriorityNormal Priority = iota
+	PriorityHigh
+)
+
+// PriorityTask is a task which is executed with a given priority. Tasks not
+// implementing it are executed with normal priority.
+type PriorityTask interface {
+	Task
+	Priority() Priority
+}
+
+// priorityOf returns the priority of a task.
+func priorityOf(t Task) Priority {
+	if pt, ok := t.(PriorityTask); ok {
+		return pt.Priority()
+	}
+	return PriorityNormal
+}
+
 // Loop encapsulates the event loop's state. This includes the vm on which the
 // loop operates, a monotonically incrementing event id, a map of tasks that
 // aren't ready yet, keyed by their ID, a channel of tasks that are ready
-// to finalise on the VM, and a boolean that indicates if the loop is still
+// to finalise on the VM (one per priority), and a boolean that indicates if the loop is still
 // accepting tasks. The channel holding the tasks pending finalising can be
 // buffered or unbuffered.
 //
-// Warning: id must be the first field in this struct as it's accessed atomically.
-// Otherwise, on ARM and x86-32 it will panic.
+// Warning: id and executed must be the first fields in this struct as they're
+// accessed atomically. Otherwise, on ARM and x86-32 it will panic.
 // More information: https://golang.org/pkg/sync/atomic/#pkg-note-BUG.
 type Loop struct {
 	id         int64
+	executed   int64
 	vm         *vm.VM
 	lock       sync.RWMutex
 	tasks      map[int64]Task
+	microtasks []Task
 	ready      chan Task
+	readyHigh  chan Task
 	closer     sync.Once
 	closedChan chan struct{}
 }
@@ -62,10 +118,21 @@ func NewWithBacklog(vm *vm.VM, backlog int) *Loop {
 		vm:         vm,
 		tasks:      make(map[int64]Task),
 		ready:      make(chan Task, backlog),
+		readyHigh:  make(chan Task, backlog),
 		closedChan: make(chan struct{}),
 	}
 }
 
+// isClosed returns true once the loop no longer accepts tasks.
+func (l *Loop) isClosed() bool {
+	select {
+	case <-l.closedChan:
+		return true
+	default:
+		return false
+	}
+}
+
 // close the loop so that it no longer accepts tasks.
 func (l *Loop) close() {
 	l.closer.Do(func() {
@@ -82,13 +149,13 @@ func (l *Loop) VM() *vm.VM {
 // doing something outside of the JavaScript environment, and that at some
 // point, it will become ready for finalising.
 func (l *Loop) Add(t Task) error {
-	select {
-	case <-l.closedChan:
-		return ErrClosed
-	default:
-	}
+	// Closed state is checked under the lock, so that a task is never added
+	// after the loop has cancelled all of its tasks, see Run.
 	l.lock.Lock()
 	defer l.lock.Unlock()
+	if l.isClosed() {
+		return ErrClosed
+	}
 	t.SetID(atomic.AddInt64(&l.id, 1))
 	l.tasks[t.GetID()] = t
 	return nil
@@ -114,25 +181,166 @@ func (l *Loop) removeByID(id int64) {
 
 func (l *Loop) removeAll() {
 	l.lock.Lock()
-	for _, t := range l.tasks {
+	defer l.lock.Unlock()
+
+	tasks, microtasks := l.tasks, l.microtasks
+	l.tasks = make(map[int64]Task)
+	l.microtasks = nil
+
+	for _, t := range tasks {
 		t.Cancel()
 	}
-	l.tasks = make(map[int64]Task)
+	for _, t := range microtasks {
+		t.Cancel()
+	}
+}
+
+// Reset cancels all the tasks and microtasks pending in the loop, while
+// keeping it running. Tasks that become ready after being cancelled
+// are dropped by the loop instead of being executed.
+func (l *Loop) Reset() {
+	l.removeAll()
+}
+
+// AddMicrotask puts a task into the microtask queue of the loop. Microtasks
+// are executed in order they were added, before the loop gets to the next task.
+func (l *Loop) AddMicrotask(t Task) error {
+	l.lock.Lock()
+	if l.isClosed() {
+		l.lock.Unlock()
+		return ErrClosed
+	}
+	l.microtasks = append(l.microtasks, t)
 	l.lock.Unlock()
+
+	// wake the loop up, in case it's idle
+	go l.Ready(nil) // nolint: errcheck
+	return nil
+}
+
+// runMicrotasks executes all the queued microtasks, including
+// the ones added by microtasks being executed.
+func (l *Loop) runMicrotasks() {
+	for {
+		l.lock.Lock()
+		if len(l.microtasks) == 0 {
+			l.lock.Unlock()
+			return
+		}
+		t := l.microtasks[0]
+		l.microtasks = l.microtasks[1:]
+		l.lock.Unlock()
+
+		err := l.execute(t)
+		atomic.AddInt64(&l.executed, 1)
+
+		if err != nil {
+			t.Cancel()
+		}
+	}
+}
+
+// execute executes a task, converting a panic in it into a PanicError caused by ErrTaskPanicked.
+func (l *Loop) execute(t Task) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &PanicError{Err: ErrTaskPanicked, Value: r}
+			log.Error("Loop task panicked", "error", r, "stack", string(debug.Stack()))
+		}
+	}()
+
+	return t.Execute(l.vm, l)
 }
 
 // Ready signals to the loop that a task is ready to be finalised. This might
-// block if the "ready channel" in the loop is at capacity.
+// block if the "ready channel" in the loop is at capacity. Ready tasks of high
+// priority are finalised before the ones of normal priority, see PriorityTask.
 func (l *Loop) Ready(t Task) error {
+	ready := l.ready
+	// nil task is used to wake up the loop, see Remove.
+	if t != nil && priorityOf(t) == PriorityHigh {
+		ready = l.readyHigh
+	}
+
+	// A buffered ready queue may still have room once the loop is closed,
+	// so closed state is checked first, for the task not to be lost.
+	if l.isClosed() {
+		if t != nil {
+			t.Cancel()
+		}
+		return ErrClosed
+	}
+
 	select {
 	case <-l.closedChan:
-		t.Cancel()
+		if t != nil {
+			t.Cancel()
+		}
 		return ErrClosed
-	case l.ready <- t:
+	case ready <- t:
 		return nil
 	}
 }
 
+// TryReady signals to the loop that a task is ready to be finalised, just like
+// Ready does, but doesn't block: false is returned if the ready queue has no room
+// for the task, i.e. the loop is busy and the backlog is full. The task is left
+// in the loop then, it's up to the caller to either retry or Remove it.
+func (l *Loop) TryReady(t Task) (bool, error) {
+	ready := l.ready
+	if priorityOf(t) == PriorityHigh {
+		ready = l.readyHigh
+	}
+
+	if l.isClosed() {
+		t.Cancel()
+		return false, ErrClosed
+	}
+
+	select {
+	case ready <- t:
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// Tasks returns a snapshot of the tasks added to the loop
+// and not finalised yet.
+func (l *Loop) Tasks() []Task {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	tasks := make([]Task, 0, len(l.tasks))
+	for _, t := range l.tasks {
+		tasks = append(tasks, t)
+	}
+
+	return tasks
+}
+
+// PendingTasks returns the number of tasks added to the loop
+// and not finalised yet.
+func (l *Loop) PendingTasks() int {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	return len(l.tasks)
+}
+
+func (l *Loop) isPending(t Task) bool {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	_, ok := l.tasks[t.GetID()]
+	return ok
+}
+
+// ExecutedTasks returns the total number of tasks executed by the loop.
+func (l *Loop) ExecutedTasks() int64 {
+	return atomic.LoadInt64(&l.executed)
+}
+
 // AddAndExecute combines Add and Ready for immediate execution.
 func (l *Loop) AddAndExecute(t Task) error {
 	if err := l.Add(t); err != nil {
@@ -149,47 +357,87 @@ func (l *Loop) Eval(s interface{}) error {
 }
 
 func (l *Loop) processTask(t Task) error {
+	// microtasks queued outside of the loop go first
+	l.runMicrotasks()
+	defer l.runMicrotasks()
+
 	id := t.GetID()
 
-	if err := t.Execute(l.vm, l); err != nil {
-		l.lock.RLock()
-		t.Cancel()
-		l.lock.RUnlock()
+	err := l.execute(t)
+	atomic.AddInt64(&l.executed, 1)
 
-		return err
+	if err != nil {
+		l.cancel(t)
 	}
 
+	// a failed task is not going to be finalised either
 	l.removeByID(id)
 
-	return nil
+	return err
+}
+
+// cancel cancels a failed task. The lock is held, so that
+// the task is not cancelled by removeAll at the same time.
+func (l *Loop) cancel(t Task) {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	t.Cancel()
 }
 
 // Run handles the task scheduling and finalisation.
-// It runs infinitely waiting for new tasks.
-func (l *Loop) Run(ctx context.Context) error {
-	defer l.close()
+// It runs infinitely waiting for new tasks, until ctx is done. A panic
+// outside of task execution, e.g. in Cancel of a task, stops the loop
+// with a PanicError caused by ErrLoopPanicked.
+func (l *Loop) Run(ctx context.Context) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &PanicError{Err: ErrLoopPanicked, Value: r}
+			log.Error("Loop panicked", "error", r, "stack", string(debug.Stack()))
+		}
+	}()
+	// The loop is closed before its tasks are cancelled,
+	// so that no task can be added in between.
 	defer l.removeAll()
+	defer l.close()
 
 	for {
+		t, err := l.next(ctx)
+		if err != nil {
+			return err
+		}
+
+		if t == nil {
+			l.runMicrotasks()
+			continue
+		}
+
+		// task was cancelled by Reset before it became ready
+		if !l.isPending(t) {
+			continue
+		}
+
+		// TODO(divan): do we need to report
+		// errors up to the caller?
+		// Ignoring for now.
+		l.processTask(t) // nolint: errcheck
+	}
+}
+
+// next waits for the next ready task, preferring tasks of high priority.
+func (l *Loop) next(ctx context.Context) (Task, error) {
+	var t Task
+
+	select {
+	case t = <-l.readyHigh:
+	default:
 		select {
-		case t := <-l.ready:
-			if ctx.Err() != nil {
-				return ctx.Err()
-			}
-
-			if t == nil {
-				continue
-			}
-
-			err := l.processTask(t)
-			if err != nil {
-				// TODO(divan): do we need to report
-				// errors up to the caller?
-				// Ignoring for now.
-				continue
-			}
+		case t = <-l.readyHigh:
+		case t = <-l.ready:
 		case <-ctx.Done():
-			return ctx.Err()
+			return nil, ctx.Err()
 		}
 	}
+
+	return t, ctx.Err()
 }
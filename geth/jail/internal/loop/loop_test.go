@@ -2,11 +2,13 @@ package loop
 
 import (
 	"context"
+	"errors"
 	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/status-im/status-go/geth/jail/internal/vm"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -32,6 +34,28 @@ func (d *DummyTask) Executed() bool {
 	return atomic.LoadInt32(&d.executed) == 1
 }
 
+// PanicTask is a task panicking on execution.
+type PanicTask struct {
+	DummyTask
+}
+
+func (*PanicTask) Execute(*vm.VM, *Loop) error {
+	panic("task failure")
+}
+
+// FailingTask is a task failing on execution and panicking on cancellation.
+type FailingTask struct {
+	DummyTask
+}
+
+func (*FailingTask) Execute(*vm.VM, *Loop) error {
+	return errors.New("task failure")
+}
+
+func (*FailingTask) Cancel() {
+	panic("cancel failure")
+}
+
 func TestLoopSuite(t *testing.T) {
 	suite.Run(t, new(LoopSuite))
 }
@@ -53,9 +77,10 @@ func (s *LoopSuite) SetupTest() {
 
 	ctx, cancel := context.WithCancel(context.Background())
 	s.cancel = cancel
+	a := s.Assertions // Cache assertions reference as otherwise we'd incur in a race condition
+	loop := s.loop
 	go func() {
-		a := s.Assertions // Cache assertions reference as otherwise we'd incur in a race condition
-		err := s.loop.Run(ctx)
+		err := loop.Run(ctx)
 		a.Equal(context.Canceled, err)
 	}()
 }
@@ -76,6 +101,27 @@ func (s *LoopSuite) TestAddAndReady() {
 	s.cancel()
 }
 
+func (s *LoopSuite) TestTaskCounters() {
+	s.Equal(0, s.loop.PendingTasks())
+	s.Equal(int64(0), s.loop.ExecutedTasks())
+
+	err := s.loop.Add(s.task)
+	s.NoError(err)
+	s.Equal(1, s.loop.PendingTasks())
+	s.Len(s.loop.Tasks(), 1)
+
+	err = s.loop.Ready(s.task)
+	s.NoError(err)
+
+	// Wait to process task
+	time.Sleep(100 * time.Millisecond)
+	s.Equal(0, s.loop.PendingTasks())
+	s.Empty(s.loop.Tasks())
+	s.Equal(int64(1), s.loop.ExecutedTasks())
+
+	s.cancel()
+}
+
 func (s *LoopSuite) TestLoopErrorWhenClosed() {
 	s.cancel()
 
@@ -90,6 +136,152 @@ func (s *LoopSuite) TestLoopErrorWhenClosed() {
 	s.True(s.task.Canceled())
 }
 
+func (s *LoopSuite) TestReadyWhenClosedWithBacklog() {
+	loop := NewWithBacklog(vm.New(), 10)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	s.Equal(context.Canceled, loop.Run(ctx))
+
+	// task is never queued, even though there's room in the ready queue
+	for i := 0; i < 10; i++ {
+		task := &DummyTask{}
+		s.Equal(ErrClosed, loop.Ready(task))
+		s.True(task.Canceled())
+	}
+	s.Equal(ErrClosed, loop.Add(s.task))
+	s.Equal(ErrClosed, loop.AddMicrotask(s.task))
+
+	s.cancel()
+}
+
+func (s *LoopSuite) TestTryReady() {
+	// loop is not running, so only the backlog accepts tasks
+	loop := NewWithBacklog(vm.New(), 1)
+
+	first, second := &DummyTask{}, &DummyTask{}
+	s.NoError(loop.Add(first))
+	s.NoError(loop.Add(second))
+
+	queued, err := loop.TryReady(first)
+	s.NoError(err)
+	s.True(queued)
+
+	queued, err = loop.TryReady(second)
+	s.NoError(err)
+	s.False(queued)
+	s.False(second.Canceled())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	s.Equal(context.Canceled, loop.Run(ctx))
+
+	task := &DummyTask{}
+	queued, err = loop.TryReady(task)
+	s.Equal(ErrClosed, err)
+	s.False(queued)
+	s.True(task.Canceled())
+
+	s.cancel()
+}
+
+func (s *LoopSuite) TestRemoveWhenClosed() {
+	err := s.loop.Add(s.task)
+	s.NoError(err)
+
+	s.cancel()
+
+	// Wait for the context to cancel and loop to close
+	time.Sleep(100 * time.Millisecond)
+
+	s.NotPanics(func() {
+		s.loop.Remove(s.task)
+		err = s.loop.Ready(nil)
+	})
+	s.Equal(ErrClosed, err)
+}
+
+func (s *LoopSuite) TestTaskPanicIsRecovered() {
+	task := &PanicTask{}
+	s.NoError(s.loop.Add(task))
+	s.NoError(s.loop.Ready(task))
+
+	// subsequent tasks are still executed
+	s.NoError(s.loop.Add(s.task))
+	s.NoError(s.loop.Ready(s.task))
+
+	time.Sleep(100 * time.Millisecond)
+	s.True(task.Canceled())
+	s.True(s.task.Executed())
+	s.Equal(0, s.loop.PendingTasks())
+
+	// panicking microtask doesn't stop the loop either
+	task = &PanicTask{}
+	s.NoError(s.loop.AddMicrotask(task))
+	time.Sleep(100 * time.Millisecond)
+	s.True(task.Canceled())
+	s.NoError(s.loop.Add(&DummyTask{}))
+
+	s.cancel()
+}
+
+func (s *LoopSuite) TestExecuteRecoversPanic() {
+	err := s.loop.execute(&PanicTask{})
+	s.Require().IsType(&PanicError{}, err)
+	s.Equal(ErrTaskPanicked, err.(*PanicError).Cause())
+	s.Equal("task failure", err.(*PanicError).Value)
+	s.Contains(err.Error(), "task failure")
+
+	s.cancel()
+}
+
+func TestLoopStopsOnPanicOutsideOfTask(t *testing.T) {
+	loop := New(vm.New())
+	task := &FailingTask{}
+	require.NoError(t, loop.Add(task))
+
+	errc := make(chan error, 1)
+	go func() {
+		errc <- loop.Run(context.Background())
+	}()
+	require.NoError(t, loop.Ready(task))
+
+	select {
+	case err := <-errc:
+		require.IsType(t, &PanicError{}, err)
+		require.Equal(t, ErrLoopPanicked, err.(*PanicError).Cause())
+		require.Contains(t, err.Error(), "cancel failure")
+	case <-time.After(time.Second):
+		t.Fatal("loop is expected to be stopped")
+	}
+
+	// the loop is closed and the lock is not held anymore
+	require.Equal(t, ErrClosed, loop.Add(&DummyTask{}))
+	require.Equal(t, 0, loop.PendingTasks())
+}
+
+func (s *LoopSuite) TestMicrotask() {
+	err := s.loop.AddMicrotask(s.task)
+	s.NoError(err)
+
+	// Wait for the task to execute
+	time.Sleep(100 * time.Millisecond)
+	s.True(s.task.Executed())
+	s.Equal(int64(1), s.loop.ExecutedTasks())
+
+	s.cancel()
+}
+
+func (s *LoopSuite) TestMicrotaskErrorWhenClosed() {
+	s.cancel()
+
+	// Wait for the context to cancel and loop to close
+	time.Sleep(100 * time.Millisecond)
+
+	err := s.loop.AddMicrotask(s.task)
+	s.Equal(ErrClosed, err)
+	s.False(s.task.Executed())
+}
+
 func (s *LoopSuite) TestImmediateExecution() {
 	err := s.loop.AddAndExecute(s.task)
 
@@ -115,3 +307,79 @@ func (s *LoopSuite) TestImmediateExecutionErrorWhenClosed() {
 	s.False(s.task.Executed())
 
 }
+
+func (s *LoopSuite) TestResetDropsCancelledTasks() {
+	err := s.loop.Add(s.task)
+	s.NoError(err)
+
+	s.loop.Reset()
+	s.True(s.task.Canceled())
+	s.Equal(0, s.loop.PendingTasks())
+
+	// task becoming ready after the reset is not executed
+	err = s.loop.Ready(s.task)
+	s.NoError(err)
+
+	time.Sleep(100 * time.Millisecond)
+	s.False(s.task.Executed())
+
+	s.cancel()
+}
+
+// orderTask records the order in which tasks are executed.
+type orderTask struct {
+	id       int64
+	name     string
+	priority Priority
+	started  chan struct{}
+	block    chan struct{}
+	order    chan string
+}
+
+func (t *orderTask) SetID(id int64)     { t.id = id }
+func (t *orderTask) GetID() int64       { return t.id }
+func (t *orderTask) Cancel()            {}
+func (t *orderTask) Priority() Priority { return t.priority }
+func (t *orderTask) Execute(*vm.VM, *Loop) error {
+	if t.block != nil {
+		close(t.started)
+		<-t.block
+	}
+	t.order <- t.name
+	return nil
+}
+
+func (s *LoopSuite) TestHighPriorityTaskPreemptsNormalOnes() {
+	loop := NewWithBacklog(vm.New(), 20)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go loop.Run(ctx) // nolint: errcheck
+
+	order := make(chan string, 20)
+
+	// keep the loop busy, while other tasks become ready
+	blocker := &orderTask{name: "blocker", started: make(chan struct{}), block: make(chan struct{}), order: order}
+	s.NoError(loop.AddAndExecute(blocker))
+	<-blocker.started
+
+	for i := 0; i < 10; i++ {
+		s.NoError(loop.AddAndExecute(&orderTask{name: "normal", order: order}))
+	}
+	s.NoError(loop.AddAndExecute(&orderTask{name: "high", priority: PriorityHigh, order: order}))
+
+	close(blocker.block)
+
+	var names []string
+	for i := 0; i < 12; i++ {
+		select {
+		case name := <-order:
+			names = append(names, name)
+		case <-time.After(time.Second):
+			s.FailNow("tasks are not executed")
+		}
+	}
+	s.Equal("blocker", names[0])
+	s.Equal("high", names[1])
+
+	s.cancel()
+}
@@ -60,15 +60,20 @@ func (e *EvalTask) SetID(ID int64) { e.ID = ID }
 // GetID gets the ID of an EvalTask.
 func (e EvalTask) GetID() int64 { return e.ID }
 
-// Cancel does nothing for an EvalTask, as there's nothing to clean up.
-func (e EvalTask) Cancel() {}
+// Cancel reports that the loop is closed, as the task won't ever be executed.
+func (e EvalTask) Cancel() {
+	select {
+	case e.Error <- loop.ErrClosed:
+	default:
+	}
+}
 
 // Execute runs the EvalTask's otto.Script in the vm provided, pushing the
 // resultant return value and error (or nil) into the associated channels.
 // If the execution results in an error, it will return that error.
 // nolint: unparam
 func (e EvalTask) Execute(vm *vm.VM, l *loop.Loop) error {
-	v, err := vm.Run(e.Script)
+	v, err := e.run(vm)
 	e.Value <- v
 	e.Error <- err
 
@@ -79,6 +84,21 @@ func (e EvalTask) Execute(vm *vm.VM, l *loop.Loop) error {
 	return err
 }
 
+// run runs the script within the vm. A panic while running it is reported
+// through the associated channels as a loop.PanicError caused by
+// loop.ErrTaskPanicked, and propagated further for the loop to recover it.
+func (e EvalTask) run(vm *vm.VM) (otto.Value, error) {
+	defer func() {
+		if r := recover(); r != nil {
+			e.Value <- otto.UndefinedValue()
+			e.Error <- &loop.PanicError{Err: loop.ErrTaskPanicked, Value: r}
+			panic(r)
+		}
+	}()
+
+	return vm.Run(e.Script)
+}
+
 // CallTask schedules an otto.Value (which should be a function) to be called
 // with a specific set of arguments. It has two channels for communicating the
 // result of the call.
@@ -89,6 +109,10 @@ type CallTask struct {
 	Value     chan otto.Value
 	Error     chan error
 	SoftError bool
+
+	// HighPriority makes the call preempt ready tasks of normal priority,
+	// like timer ticks, see loop.PriorityTask.
+	HighPriority bool
 }
 
 // NewCallTask creates a new CallTask object for a given otto.Value (which
@@ -112,17 +136,20 @@ func (c CallTask) GetID() int64 { return c.ID }
 // Cancel does nothing for a CallTask, as there's nothing to clean up.
 func (c CallTask) Cancel() {}
 
-// Execute calls the associated function (not necessarily in the given vm),
+// Priority returns the priority the CallTask is executed with.
+func (c CallTask) Priority() loop.Priority {
+	if c.HighPriority {
+		return loop.PriorityHigh
+	}
+	return loop.PriorityNormal
+}
+
+// Execute calls the associated function in the given vm,
 // pushing the resultant return value and error (or nil) into the associated
 // channels. If the call results in an error, it will return that error.
 // nolint: unparam
 func (c CallTask) Execute(vm *vm.VM, l *loop.Loop) error {
-	// vm is not used directly here, but underlying
-	// FunctionCall in CallTask likely does use it,
-	// so we must to guard it here
-	vm.Lock()
-	v, err := c.Function.Call(otto.NullValue(), c.Args...)
-	vm.Unlock()
+	v, err := c.call(vm)
 
 	c.Value <- v
 	c.Error <- err
@@ -133,3 +160,62 @@ func (c CallTask) Execute(vm *vm.VM, l *loop.Loop) error {
 
 	return err
 }
+
+// call calls the associated function within the vm, so that the execution
+// timeout and the memory limit apply to it. A panic in the function is
+// reported through the associated channels as a loop.PanicError caused by
+// loop.ErrTaskPanicked, and propagated further for the loop to recover it.
+func (c CallTask) call(vm *vm.VM) (otto.Value, error) {
+	defer func() {
+		if r := recover(); r != nil {
+			c.Value <- otto.UndefinedValue()
+			c.Error <- &loop.PanicError{Err: loop.ErrTaskPanicked, Value: r}
+			panic(r)
+		}
+	}()
+
+	return vm.CallFunction(c.Function, otto.NullValue(), c.Args...)
+}
+
+// SetTask schedules setting a value keyed by a given name in the vm, so
+// that it doesn't interleave with other tasks. It has a channel for
+// communicating the result of the operation.
+type SetTask struct {
+	ID    int64
+	Key   string
+	Value interface{}
+	Error chan error
+}
+
+// NewSetTask creates a new SetTask for a given key and value, creating
+// a buffered channel for the response.
+func NewSetTask(key string, value interface{}) *SetTask {
+	return &SetTask{
+		Key:   key,
+		Value: value,
+		Error: make(chan error, 1),
+	}
+}
+
+// SetID sets the ID of a SetTask.
+func (s *SetTask) SetID(ID int64) { s.ID = ID }
+
+// GetID gets the ID of a SetTask.
+func (s SetTask) GetID() int64 { return s.ID }
+
+// Cancel reports that the loop is closed, as the task won't ever be executed.
+func (s SetTask) Cancel() {
+	select {
+	case s.Error <- loop.ErrClosed:
+	default:
+	}
+}
+
+// Execute sets the value in the vm provided, pushing the resultant error
+// (or nil) into the associated channel. Error is never returned, as failing
+// to set a value should not affect the loop.
+// nolint: unparam
+func (s SetTask) Execute(vm *vm.VM, l *loop.Loop) error {
+	s.Error <- vm.Set(s.Key, s.Value)
+	return nil
+}
@@ -0,0 +1,57 @@
+package textencoding
+
+const src = `(function(global) {
+  'use strict';
+
+  function normalizeLabel(label) {
+    label = label === undefined ? 'utf-8' : String(label).trim().toLowerCase();
+    if (label !== 'utf-8' && label !== 'utf8' && label !== 'unicode-1-1-utf-8') {
+      throw new RangeError("The encoding label provided ('" + label + "') is invalid.");
+    }
+
+    return 'utf-8';
+  }
+
+  /**
+   * @constructor
+   */
+  function TextEncoder() {
+    if (!(this instanceof TextEncoder)) {
+      throw new TypeError("Constructor TextEncoder requires 'new'");
+    }
+
+    Object.defineProperty(this, 'encoding', {value: 'utf-8', enumerable: true});
+  }
+
+  TextEncoder.prototype.encode = function(input) {
+    return new Uint8Array(__textencoding_encode(input === undefined ? '' : String(input)));
+  };
+
+  /**
+   * @constructor
+   */
+  function TextDecoder(label, options) {
+    if (!(this instanceof TextDecoder)) {
+      throw new TypeError("Constructor TextDecoder requires 'new'");
+    }
+
+    Object.defineProperty(this, 'encoding', {value: normalizeLabel(label), enumerable: true});
+    Object.defineProperty(this, 'fatal', {value: !!(options && options.fatal), enumerable: true});
+  }
+
+  TextDecoder.prototype.decode = function(input) {
+    if (input === undefined || input === null) {
+      return '';
+    }
+
+    if (typeof input !== 'object' || typeof input.length !== 'number') {
+      throw new TypeError('The provided value is not of type (ArrayBuffer or ArrayBufferView)');
+    }
+
+    return __textencoding_decode(input, this.fatal);
+  };
+
+  global.TextEncoder = TextEncoder;
+  global.TextDecoder = TextDecoder;
+})(this);
+`
@@ -0,0 +1,105 @@
+package textencoding
+
+import (
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/robertkrimen/otto"
+
+	"github.com/status-im/status-go/geth/jail/internal/typedarray"
+	"github.com/status-im/status-go/geth/jail/internal/vm"
+)
+
+// Define jail TextEncoder and TextDecoder, supporting UTF-8 only.
+func Define(vm *vm.VM) error {
+	if v, err := vm.Get("TextEncoder"); err != nil {
+		return err
+	} else if !v.IsUndefined() {
+		return nil
+	}
+
+	if err := typedarray.Define(vm); err != nil {
+		return err
+	}
+
+	if err := vm.Set("__textencoding_encode", encode); err != nil {
+		return err
+	}
+
+	if err := vm.Set("__textencoding_decode", decode); err != nil {
+		return err
+	}
+
+	s, err := vm.Compile("textencoding.js", src)
+	if err != nil {
+		return err
+	}
+
+	_, err = vm.Run(s)
+	return err
+}
+
+// encode returns UTF-8 bytes of the string as a list of numbers,
+// which is then used to construct Uint8Array.
+func encode(call otto.FunctionCall) otto.Value {
+	data := []byte(call.Argument(0).String())
+
+	bytes := make([]int, len(data))
+	for i, b := range data {
+		bytes[i] = int(b)
+	}
+
+	v, err := call.Otto.ToValue(bytes)
+	if err != nil {
+		panic(err)
+	}
+
+	return v
+}
+
+// decode converts array-like object of bytes to string. Invalid
+// sequences are replaced with U+FFFD, unless fatal flag is set.
+func decode(call otto.FunctionCall) otto.Value {
+	obj := call.Argument(0).Object()
+	fatal, _ := call.Argument(1).ToBoolean() // nolint: gas
+
+	length, err := mustGet(obj, "length").ToInteger()
+	if err != nil {
+		panic(err)
+	}
+
+	data := make([]byte, length)
+	for i := range data {
+		b, err := mustGet(obj, strconv.Itoa(i)).ToInteger()
+		if err != nil {
+			panic(err)
+		}
+		data[i] = byte(b)
+	}
+
+	runes := make([]rune, 0, len(data))
+	for len(data) > 0 {
+		r, size := utf8.DecodeRune(data)
+		if r == utf8.RuneError && size == 1 && fatal {
+			panic(call.Otto.MakeTypeError("The encoded data was not valid for encoding utf-8"))
+		}
+		runes = append(runes, r)
+		data = data[size:]
+	}
+
+	v, err := call.Otto.ToValue(string(runes))
+	if err != nil {
+		panic(err)
+	}
+
+	return v
+}
+
+func mustGet(obj *otto.Object, name string) otto.Value {
+	v, err := obj.Get(name)
+	if err != nil {
+		panic(err)
+	}
+
+	return v
+}
@@ -0,0 +1,59 @@
+package textencoding_test
+
+import (
+	"testing"
+
+	"github.com/status-im/status-go/geth/jail/internal/textencoding"
+	"github.com/status-im/status-go/geth/jail/internal/vm"
+	"github.com/stretchr/testify/suite"
+)
+
+func (s *TextEncodingSuite) TestEncode() {
+	v, err := s.vm.Run(`
+		var encoded = new TextEncoder().encode("zß水🀀");
+		[encoded instanceof Uint8Array, encoded.length, encoded.join(" ")].join(",")
+	`)
+	s.NoError(err)
+	s.Equal("true,10,122 195 159 230 176 180 240 159 128 128", v.String())
+}
+
+func (s *TextEncodingSuite) TestRoundTrip() {
+	v, err := s.vm.Run(`new TextDecoder().decode(new TextEncoder().encode("zß水🀀"))`)
+	s.NoError(err)
+	s.Equal("zß水🀀", v.String())
+
+	v, err = s.vm.Run(`new TextDecoder("utf-8").decode([104, 105])`)
+	s.NoError(err)
+	s.Equal("hi", v.String())
+}
+
+func (s *TextEncodingSuite) TestDecodeInvalid() {
+	v, err := s.vm.Run(`new TextDecoder().decode(new Uint8Array([104, 255, 105]))`)
+	s.NoError(err)
+	s.Equal("h�i", v.String())
+
+	_, err = s.vm.Run(`new TextDecoder("utf-8", {fatal: true}).decode(new Uint8Array([104, 255, 105]))`)
+	s.Error(err)
+}
+
+func (s *TextEncodingSuite) TestUnsupportedEncoding() {
+	_, err := s.vm.Run(`new TextDecoder("latin1")`)
+	s.Error(err)
+}
+
+type TextEncodingSuite struct {
+	suite.Suite
+
+	vm *vm.VM
+}
+
+func (s *TextEncodingSuite) SetupTest() {
+	s.vm = vm.New()
+
+	err := textencoding.Define(s.vm)
+	s.NoError(err)
+}
+
+func TestTextEncodingSuite(t *testing.T) {
+	suite.Run(t, new(TextEncodingSuite))
+}
@@ -9,12 +9,15 @@ import (
 )
 
 type timerTask struct {
-	id       int64
-	timer    *time.Timer
-	duration time.Duration
-	interval bool
-	call     otto.FunctionCall
-	stopped  bool
+	id        int64 // loop task ID, which changes whenever an interval is rescheduled
+	timerID   int64 // ID of the timer reported to the observer
+	observer  Observer
+	timer     *time.Timer
+	duration  time.Duration
+	interval  bool
+	immediate bool
+	call      otto.FunctionCall
+	stopped   bool
 }
 
 func (t *timerTask) SetID(id int64) { t.id = id }
@@ -22,20 +25,61 @@ func (t *timerTask) GetID() int64   { return t.id }
 
 func (t *timerTask) Execute(vm *vm.VM, l *loop.Loop) error {
 	arguments := t.getArguments()
-	if _, err := vm.Call(`Function.call.call`, nil, arguments...); err != nil {
-		return err
-	}
+	arguments[0] = t.guard(l)
+	_, err := vm.Call(`Function.call.call`, nil, arguments...)
+	return err
+}
+
+// guard wraps the timer callback, so that it isn't called if the timer has been
+// cleared after it had become ready, but before the loop got to execute it.
+// Interval is rescheduled here too, unless it's been cleared by the callback.
+// Both checks are done within the VM, as the timer is cleared there.
+func (t *timerTask) guard(l *loop.Loop) func(call otto.FunctionCall) otto.Value {
+	return func(call otto.FunctionCall) otto.Value {
+		if t.stopped {
+			return otto.UndefinedValue()
+		}
+
+		args := make([]interface{}, len(call.ArgumentList))
+		for i, arg := range call.ArgumentList {
+			args[i] = arg
+		}
 
-	if !(t.interval && !t.stopped) {
-		return nil
+		if t.observer != nil {
+			t.observer.TimerStarted(t.timerID)
+			start := time.Now()
+			defer func() { t.observer.TimerFinished(t.timerID, time.Since(start)) }()
+		}
+
+		v, err := t.call.ArgumentList[0].Call(call.This, args...)
+		if err != nil {
+			panic(err)
+		}
+
+		if t.interval && !t.stopped {
+			t.timer.Reset(t.duration)
+			// If err is non-nil, then the loop is closed and
+			// the interval should not be rescheduled anymore.
+			l.Add(t) // nolint: errcheck
+		}
+
+		return v
 	}
+}
 
-	t.timer.Reset(t.duration)
-	return l.Add(t)
+// Priority implements loop.PriorityTask: immediates go before the timers.
+func (t *timerTask) Priority() loop.Priority {
+	if t.immediate {
+		return loop.PriorityHigh
+	}
+	return loop.PriorityNormal
 }
 
 func (t *timerTask) Cancel() {
-	t.timer.Stop()
+	// immediates have no timer
+	if t.timer != nil {
+		t.timer.Stop()
+	}
 }
 
 func (t *timerTask) getArguments() (arguments []interface{}) {
@@ -52,3 +96,19 @@ func (t *timerTask) getArguments() (arguments []interface{}) {
 
 	return
 }
+
+// microtask calls a function queued with queueMicrotask.
+type microtask struct {
+	id int64
+	fn otto.Value
+}
+
+func (t *microtask) SetID(id int64) { t.id = id }
+func (t *microtask) GetID() int64   { return t.id }
+
+func (t *microtask) Execute(vm *vm.VM, l *loop.Loop) error {
+	_, err := vm.Call(`Function.call.call`, nil, t.fn)
+	return err
+}
+
+func (t *microtask) Cancel() {}
@@ -1,6 +1,7 @@
 package timers
 
 import (
+	"sync/atomic"
 	"time"
 
 	"github.com/robertkrimen/otto"
@@ -9,21 +10,51 @@ import (
 	"github.com/status-im/status-go/geth/jail/internal/vm"
 )
 
+// Observer is notified whenever a callback of a timer (set with setTimeout, setInterval
+// or setImmediate) runs, e.g. to profile which timers take the most time. Timers are
+// identified by IDs unique within the VM, which are kept by intervals across runs.
+// Observer is called from the loop, so it should return quickly.
+type Observer interface {
+	// TimerStarted is called before the callback of the timer is called.
+	TimerStarted(id int64)
+	// TimerFinished is called once the callback of the timer returns, or throws,
+	// with the time it took.
+	TimerFinished(id int64, duration time.Duration)
+}
+
+// registry assigns IDs to timers and observes them, if the observer is set.
+type registry struct {
+	lastID   int64
+	observer Observer
+}
+
+func (r *registry) nextID() int64 {
+	return atomic.AddInt64(&r.lastID, 1)
+}
+
 // Define jail timers
 func Define(vm *vm.VM, l *loop.Loop) error {
+	return DefineWithObserver(vm, l, nil)
+}
+
+// DefineWithObserver defines jail timers, notifying the observer when their callbacks run.
+// Nil observer is not notified at all.
+func DefineWithObserver(vm *vm.VM, l *loop.Loop, observer Observer) error {
 	if v, err := vm.Get("setTimeout"); err != nil {
 		return err
 	} else if !v.IsUndefined() {
 		return nil
 	}
 
+	r := &registry{observer: observer}
 	timeHandlers := map[string]func(call otto.FunctionCall) otto.Value{
-		"setInterval":    newTimerHandler(l, true),
-		"setTimeout":     newTimerHandler(l, false),
-		"setImmediate":   newImmediateTimerHandler(l),
+		"setInterval":    newTimerHandler(l, r, true),
+		"setTimeout":     newTimerHandler(l, r, false),
+		"setImmediate":   newImmediateTimerHandler(l, r),
 		"clearTimeout":   newClearTimeoutHandler(l),
 		"clearInterval":  newClearTimeoutHandler(l),
 		"clearImmediate": newClearTimeoutHandler(l),
+		"queueMicrotask": newMicrotaskHandler(l),
 	}
 
 	for k, handler := range timeHandlers {
@@ -35,6 +66,30 @@ func Define(vm *vm.VM, l *loop.Loop) error {
 	return nil
 }
 
+// ActiveTimers returns the number of timers scheduled in the loop.
+func ActiveTimers(l *loop.Loop) int {
+	var n int
+	for _, t := range l.Tasks() {
+		if _, ok := t.(*timerTask); ok {
+			n++
+		}
+	}
+
+	return n
+}
+
+// PendingImmediates returns the number of callbacks scheduled with setImmediate.
+func PendingImmediates(l *loop.Loop) int {
+	var n int
+	for _, t := range l.Tasks() {
+		if t, ok := t.(*timerTask); ok && t.immediate {
+			n++
+		}
+	}
+
+	return n
+}
+
 func getDelayWithMin(call otto.FunctionCall, interval bool) int64 {
 	var minDelay = map[bool]int64{
 		true:  10,
@@ -48,11 +103,13 @@ func getDelayWithMin(call otto.FunctionCall, interval bool) int64 {
 	return delay
 }
 
-func newTimerHandler(l *loop.Loop, interval bool) func(call otto.FunctionCall) otto.Value {
+func newTimerHandler(l *loop.Loop, r *registry, interval bool) func(call otto.FunctionCall) otto.Value {
 	return func(call otto.FunctionCall) otto.Value {
 		delay := getDelayWithMin(call, interval)
 
 		t := &timerTask{
+			timerID:  r.nextID(),
+			observer: r.observer,
 			duration: time.Duration(delay) * time.Millisecond,
 			call:     call,
 			interval: interval,
@@ -76,11 +133,15 @@ func newTimerHandler(l *loop.Loop, interval bool) func(call otto.FunctionCall) o
 	}
 }
 
-func newImmediateTimerHandler(l *loop.Loop) func(call otto.FunctionCall) otto.Value {
+func newImmediateTimerHandler(l *loop.Loop, r *registry) func(call otto.FunctionCall) otto.Value {
 	return func(call otto.FunctionCall) otto.Value {
+		// setImmediate callback runs once the task which is being executed
+		// is done, before the timers, as it's ready with high priority.
 		t := &timerTask{
-			duration: time.Millisecond,
-			call:     call,
+			timerID:   r.nextID(),
+			observer:  r.observer,
+			call:      call,
+			immediate: true,
 		}
 
 		// If err is non-nil, then the loop is closed and should not
@@ -89,9 +150,12 @@ func newImmediateTimerHandler(l *loop.Loop) func(call otto.FunctionCall) otto.Va
 			return otto.UndefinedValue()
 		}
 
-		t.timer = time.AfterFunc(t.duration, func() {
-			l.Ready(t) // nolint: errcheck, gas
-		})
+		// setImmediate is usually called from the loop itself, which can't
+		// wait for room in the ready queue, so that it's waited for in the
+		// background only if the queue is full.
+		if queued, err := l.TryReady(t); err == nil && !queued {
+			go l.Ready(t) // nolint: errcheck
+		}
 
 		value, setImmediateErr := call.Otto.ToValue(t)
 		if setImmediateErr != nil {
@@ -102,12 +166,28 @@ func newImmediateTimerHandler(l *loop.Loop) func(call otto.FunctionCall) otto.Va
 	}
 }
 
+func newMicrotaskHandler(l *loop.Loop) func(call otto.FunctionCall) otto.Value {
+	return func(call otto.FunctionCall) otto.Value {
+		fn := call.Argument(0)
+		if !fn.IsFunction() {
+			panic(call.Otto.MakeTypeError("queueMicrotask: argument is not a function"))
+		}
+
+		// If err is non-nil, then the loop is closed and should not
+		// be used anymore.
+		l.AddMicrotask(&microtask{fn: fn}) // nolint: errcheck
+
+		return otto.UndefinedValue()
+	}
+}
+
 func newClearTimeoutHandler(l *loop.Loop) func(call otto.FunctionCall) otto.Value {
 	return func(call otto.FunctionCall) otto.Value {
 		v, _ := call.Argument(0).Export() // nolint: gas
-		if t, ok := v.(*timerTask); ok {
+		// Clearing the timer more than once is a no-op.
+		if t, ok := v.(*timerTask); ok && !t.stopped {
 			t.stopped = true
-			t.timer.Stop()
+			t.Cancel()
 			l.Remove(t)
 		}
 
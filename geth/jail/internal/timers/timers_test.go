@@ -91,6 +91,68 @@ func (s *TimersSuite) TestClearIntervalImmediately() {
 	<-time.After(100 * time.Millisecond)
 }
 
+func (s *TimersSuite) TestClearIntervalFromCallback() {
+	err := s.loop.Eval(`
+		var ticks = 0;
+		var iv = setInterval(function() {
+			ticks++;
+			clearInterval(iv);
+			clearInterval(iv);
+		}, 10);
+	`)
+	s.NoError(err)
+
+	<-time.After(100 * time.Millisecond)
+
+	value, err := s.vm.Get("ticks")
+	s.NoError(err)
+	n, err := value.ToInteger()
+	s.NoError(err)
+	s.Equal(1, int(n))
+	s.Equal(0, timers.ActiveTimers(s.loop))
+}
+
+func (s *TimersSuite) TestClearIntervalWithQueuedTick() {
+	err := s.vm.Set("__shouldNeverRun", func() {
+		s.Fail("should never run")
+	})
+	s.NoError(err)
+
+	// Busy loop holds the VM, so the interval tick becomes ready
+	// and waits in the loop until it's cleared.
+	err = s.loop.Eval(`
+		var iv = setInterval(function() {
+			__shouldNeverRun();
+		}, 10);
+		var start = Date.now();
+		while (Date.now() - start < 50) {}
+		clearInterval(iv);
+	`)
+	s.NoError(err)
+
+	<-time.After(100 * time.Millisecond)
+}
+
+func (s *TimersSuite) TestClearFiredTimeout() {
+	err := s.vm.Set("__done", func() {
+		s.ch <- struct{}{}
+	})
+	s.NoError(err)
+
+	err = s.loop.Eval(`var t = setTimeout(__done, 10);`)
+	s.NoError(err)
+
+	select {
+	case <-s.ch:
+	case <-time.After(time.Second):
+		s.Fail("test timed out")
+		return
+	}
+
+	err = s.loop.Eval(`clearTimeout(t); clearTimeout(t);`)
+	s.NoError(err)
+}
+
 func (s *TimersSuite) TestImmediateTimer() {
 	err := s.vm.Set("__done", func() {
 		s.ch <- struct{}{}
@@ -114,6 +176,100 @@ func (s *TimersSuite) TestImmediateTimer() {
 	}
 }
 
+func (s *TimersSuite) TestExecutionOrder() {
+	err := s.vm.Set("__done", func() {
+		s.ch <- struct{}{}
+	})
+	s.NoError(err)
+
+	// loop is kept busy until both the timeout and the immediate are ready,
+	// so that the order doesn't depend on when they become ready
+	blocker := &blockingTask{release: make(chan struct{})}
+	s.NoError(s.loop.AddAndExecute(blocker))
+
+	err = s.loop.Eval(`
+		var order = [];
+		setTimeout(function() {
+			order.push("timeout");
+			__done();
+		}, 0);
+		setImmediate(function() {
+			order.push("immediate");
+		});
+		queueMicrotask(function() {
+			order.push("microtask");
+			queueMicrotask(function() {
+				order.push("nested microtask");
+			});
+		});
+	`)
+	s.NoError(err)
+
+	time.Sleep(50 * time.Millisecond)
+	close(blocker.release)
+
+	select {
+	case <-s.ch:
+		value, err := s.vm.Run(`order.join(",")`)
+		s.NoError(err)
+		s.Equal("microtask,nested microtask,immediate,timeout", value.String())
+	case <-time.After(1 * time.Second):
+		s.Fail("test timed out")
+	}
+}
+
+func (s *TimersSuite) TestMicrotaskWithinTask() {
+	err := s.vm.Set("__done", func() {
+		s.ch <- struct{}{}
+	})
+	s.NoError(err)
+
+	err = s.loop.Eval(`
+		var order = [];
+		setTimeout(function() {
+			setImmediate(function() {
+				order.push("immediate");
+				__done();
+			});
+			queueMicrotask(function() {
+				order.push("microtask");
+			});
+			order.push("task");
+		}, 0);
+	`)
+	s.NoError(err)
+
+	select {
+	case <-s.ch:
+		value, err := s.vm.Run(`order.join(",")`)
+		s.NoError(err)
+		s.Equal("task,microtask,immediate", value.String())
+	case <-time.After(1 * time.Second):
+		s.Fail("test timed out")
+	}
+}
+
+func (s *TimersSuite) TestQueueMicrotaskWithoutFunction() {
+	_, err := s.vm.Run(`queueMicrotask(1)`)
+	s.Error(err)
+}
+
+// blockingTask keeps the loop busy until released.
+type blockingTask struct {
+	id      int64
+	release chan struct{}
+}
+
+func (t *blockingTask) SetID(id int64) { t.id = id }
+func (t *blockingTask) GetID() int64   { return t.id }
+
+func (t *blockingTask) Execute(vm *vm.VM, l *loop.Loop) error {
+	<-t.release
+	return nil
+}
+
+func (t *blockingTask) Cancel() {}
+
 type TimersSuite struct {
 	suite.Suite
 
@@ -123,6 +279,25 @@ type TimersSuite struct {
 	ch chan struct{}
 }
 
+func (s *TimersSuite) TestActiveTimers() {
+	s.Equal(0, timers.ActiveTimers(s.loop))
+
+	err := s.loop.Eval(`
+		var t1 = setTimeout(function() {}, 50);
+		var t2 = setTimeout(function() {}, 1000);
+		var iv = setInterval(function() {}, 1000);
+	`)
+	s.NoError(err)
+	s.Equal(3, timers.ActiveTimers(s.loop))
+
+	<-time.After(100 * time.Millisecond)
+	s.Equal(2, timers.ActiveTimers(s.loop))
+
+	err = s.loop.Eval(`clearTimeout(t2); clearInterval(iv);`)
+	s.NoError(err)
+	s.Equal(0, timers.ActiveTimers(s.loop))
+}
+
 func (s *TimersSuite) SetupTest() {
 	s.vm = vm.New()
 	s.loop = loop.New(s.vm)
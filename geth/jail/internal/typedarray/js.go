@@ -0,0 +1,135 @@
+package typedarray
+
+const src = `(function(global) {
+  'use strict';
+
+  function relativeIndex(index, length, defaultIndex) {
+    if (index === undefined) {
+      return defaultIndex;
+    }
+
+    index = Math.floor(Number(index)) || 0;
+    return index < 0 ? Math.max(length + index, 0) : Math.min(index, length);
+  }
+
+  function define(name, bytesPerElement, coerce) {
+    if (typeof global[name] !== 'undefined') {
+      return;
+    }
+
+    /**
+     * @constructor
+     */
+    function TypedArray(arg) {
+      if (!(this instanceof TypedArray)) {
+        throw new TypeError("Constructor " + name + " requires 'new'");
+      }
+
+      var length = 0;
+      var source = null;
+      if (arg !== null && typeof arg === 'object') {
+        source = arg;
+        length = arg.length >>> 0;
+      } else if (arg !== undefined) {
+        length = Number(arg);
+        if (!(length >= 0) || length !== Math.floor(length)) {
+          throw new RangeError('Invalid typed array length: ' + arg);
+        }
+      }
+
+      for (var i = 0; i < length; i++) {
+        this[i] = coerce(source ? source[i] : 0);
+      }
+
+      Object.defineProperty(this, 'length', {value: length});
+    }
+
+    TypedArray.BYTES_PER_ELEMENT = bytesPerElement;
+    TypedArray.prototype.BYTES_PER_ELEMENT = bytesPerElement;
+
+    Object.defineProperty(TypedArray.prototype, 'byteLength', {
+      get: function() {
+        return this.length * bytesPerElement;
+      }
+    });
+
+    ['join', 'indexOf', 'lastIndexOf', 'forEach', 'every', 'some', 'reduce', 'reduceRight'].forEach(function(method) {
+      TypedArray.prototype[method] = Array.prototype[method];
+    });
+
+    TypedArray.prototype.toString = function() {
+      return Array.prototype.join.call(this, ',');
+    };
+
+    TypedArray.prototype.set = function(array, offset) {
+      offset = offset >>> 0;
+      if (offset + (array.length >>> 0) > this.length) {
+        throw new RangeError('Source is too large');
+      }
+
+      for (var i = 0; i < array.length; i++) {
+        this[offset + i] = coerce(array[i]);
+      }
+    };
+
+    TypedArray.prototype.fill = function(value, start, end) {
+      var from = relativeIndex(start, this.length, 0);
+      var to = relativeIndex(end, this.length, this.length);
+
+      for (var i = from; i < to; i++) {
+        this[i] = coerce(value);
+      }
+
+      return this;
+    };
+
+    TypedArray.prototype.slice = function(start, end) {
+      return new TypedArray(Array.prototype.slice.call(this, start, end));
+    };
+
+    TypedArray.prototype.map = function(callback, thisArg) {
+      return new TypedArray(Array.prototype.map.call(this, callback, thisArg));
+    };
+
+    TypedArray.from = function(source) {
+      return new TypedArray(source);
+    };
+
+    global[name] = TypedArray;
+  }
+
+  define('Int8Array', 1, function(v) {
+    v = Number(v) & 0xff;
+    return v > 0x7f ? v - 0x100 : v;
+  });
+  define('Uint8Array', 1, function(v) {
+    return Number(v) & 0xff;
+  });
+  define('Uint8ClampedArray', 1, function(v) {
+    v = Number(v);
+    if (!(v > 0)) {
+      return 0;
+    }
+    return v > 0xff ? 0xff : Math.round(v);
+  });
+  define('Int16Array', 2, function(v) {
+    v = Number(v) & 0xffff;
+    return v > 0x7fff ? v - 0x10000 : v;
+  });
+  define('Uint16Array', 2, function(v) {
+    return Number(v) & 0xffff;
+  });
+  define('Int32Array', 4, function(v) {
+    return Number(v) | 0;
+  });
+  define('Uint32Array', 4, function(v) {
+    return Number(v) >>> 0;
+  });
+  define('Float32Array', 4, function(v) {
+    return Number(v);
+  });
+  define('Float64Array', 8, function(v) {
+    return Number(v);
+  });
+})(this);
+`
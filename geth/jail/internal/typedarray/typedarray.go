@@ -0,0 +1,30 @@
+package typedarray
+
+import (
+	"github.com/status-im/status-go/geth/jail/internal/vm"
+)
+
+// Define jail typed arrays, which are missing in otto.
+// Typed arrays are emulated by array-like objects, which don't share
+// an underlying buffer, and values are coerced to the element type
+// only when they're passed to constructor, set or fill.
+//
+// There's no ArrayBuffer, so arrays have neither buffer nor byteOffset,
+// and subarray is missing, as views of the same memory can't be made.
+// Code writing to one array and reading another view of its buffer
+// doesn't work with the emulation, slice always returns a copy.
+func Define(vm *vm.VM) error {
+	if v, err := vm.Get("Uint8Array"); err != nil {
+		return err
+	} else if !v.IsUndefined() {
+		return nil
+	}
+
+	s, err := vm.Compile("typedarray.js", src)
+	if err != nil {
+		return err
+	}
+
+	_, err = vm.Run(s)
+	return err
+}
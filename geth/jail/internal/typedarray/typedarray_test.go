@@ -0,0 +1,62 @@
+package typedarray_test
+
+import (
+	"testing"
+
+	"github.com/status-im/status-go/geth/jail/internal/typedarray"
+	"github.com/status-im/status-go/geth/jail/internal/vm"
+	"github.com/stretchr/testify/suite"
+)
+
+func (s *TypedArraySuite) TestConstructors() {
+	v, err := s.vm.Run(`
+		var a = new Uint8Array(3);
+		var b = new Uint8Array([1, 256, -1]);
+		var c = new Int8Array([128, -129]);
+		[a.length, a.join("-"), b.join("-"), c.join("-"), b.byteLength, new Uint32Array(2).byteLength].join(",")
+	`)
+	s.NoError(err)
+	s.Equal("3,0-0-0,1-0-255,-128-127,3,8", v.String())
+}
+
+func (s *TypedArraySuite) TestClamped() {
+	v, err := s.vm.Run(`new Uint8ClampedArray([-5, 1.5, 300]).join(",")`)
+	s.NoError(err)
+	s.Equal("0,2,255", v.String())
+}
+
+func (s *TypedArraySuite) TestSetFillSlice() {
+	v, err := s.vm.Run(`
+		var a = new Uint8Array(5);
+		a.set([1, 2, 257], 1);
+		var filled = new Uint16Array(4).fill(65537, 1, -1);
+		[a.join("-"), filled.join("-"), a.slice(1, 3).join("-"), a.slice(1, 3) instanceof Uint8Array].join(",")
+	`)
+	s.NoError(err)
+	s.Equal("0-1-2-1-0,0-1-1-0,1-2,true", v.String())
+}
+
+func (s *TypedArraySuite) TestInvalidLength() {
+	_, err := s.vm.Run(`new Uint8Array(-1)`)
+	s.Error(err)
+
+	_, err = s.vm.Run(`Uint8Array(1)`)
+	s.Error(err)
+}
+
+type TypedArraySuite struct {
+	suite.Suite
+
+	vm *vm.VM
+}
+
+func (s *TypedArraySuite) SetupTest() {
+	s.vm = vm.New()
+
+	err := typedarray.Define(s.vm)
+	s.NoError(err)
+}
+
+func TestTypedArraySuite(t *testing.T) {
+	suite.Run(t, new(TypedArraySuite))
+}
@@ -0,0 +1,107 @@
+package vm
+
+import (
+	"time"
+
+	"github.com/robertkrimen/otto"
+)
+
+// memorySampleInterval defines how often memory usage is estimated while JS is executed.
+const memorySampleInterval = 100 * time.Millisecond
+
+// Sizes used to estimate memory held by JS values, roughly the ones of otto's representation.
+const (
+	valueSize    = 16  // primitive value
+	objectSize   = 128 // object with no properties
+	propertySize = 64  // property of an object, excluding its name and value
+)
+
+// watchMemory estimates memory held by values of the VM (see memoryUsage) on a schedule,
+// until stop is closed, and calls onExceeded from the VM goroutine once they take more than
+// limit bytes. Heap of the process is not looked at, as it's shared with other VMs, so the
+// VM is accounted on its own. Estimation blocks execution, so it's scheduled to take at most
+// a fifth of the execution time.
+func (vm *VM) watchMemory(stop <-chan struct{}, interrupt chan<- func(), limit uint64, onExceeded func()) {
+	ticker := time.NewTicker(memorySampleInterval)
+	defer ticker.Stop()
+
+	var nextCheck time.Time
+	for {
+		select {
+		case <-stop:
+			return
+		case now := <-ticker.C:
+			if now.Before(nextCheck) {
+				continue
+			}
+
+			done := make(chan struct{})
+			check := func() {
+				defer close(done)
+				if vm.memoryUsage(limit) > limit {
+					onExceeded()
+				}
+			}
+
+			select {
+			case interrupt <- check:
+			case <-stop:
+				return
+			}
+			select {
+			case <-done:
+			case <-stop:
+				return
+			}
+			nextCheck = time.Now().Add(4 * time.Since(now))
+		}
+	}
+}
+
+// memoryUsage estimates memory held by values reachable from the global object and from
+// variables of the call stack. Properties are read through their descriptors, so that getters
+// are never invoked. Estimation stops once it exceeds limit. It must be called from the VM
+// goroutine, while JS is executed.
+func (vm *VM) memoryUsage(limit uint64) uint64 {
+	var size uint64
+	visited := make(map[otto.Value]struct{})
+
+	values := []otto.Value{vm.global}
+	for _, value := range vm.vm.ContextSkip(-1, false).Symbols {
+		values = append(values, value)
+	}
+
+	for len(values) > 0 && size <= limit {
+		value := values[len(values)-1]
+		values = values[:len(values)-1]
+
+		switch {
+		case value.IsString():
+			size += valueSize + uint64(len(value.String()))
+		case value.IsObject():
+			if _, ok := visited[value]; ok {
+				continue
+			}
+			visited[value] = struct{}{}
+			size += objectSize
+
+			object := value.Object()
+			for _, name := range object.Keys() {
+				size += propertySize + uint64(len(name))
+
+				descriptor, err := vm.propertyDescriptor.Call(otto.NullValue(), value, name)
+				if err != nil || !descriptor.IsObject() {
+					continue
+				}
+				// accessor properties have no value
+				if property, err := descriptor.Object().Get("value"); err == nil {
+					values = append(values, property)
+				}
+			}
+		default:
+			size += valueSize
+		}
+	}
+
+	return size
+}
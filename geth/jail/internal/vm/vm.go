@@ -1,23 +1,48 @@
 package vm
 
 import (
+	"context"
+	"errors"
 	"sync"
+	"time"
 
 	"github.com/robertkrimen/otto"
 )
 
+// ErrExecutionTimeout is returned when JS execution exceeds the execution timeout.
+var ErrExecutionTimeout = errors.New("execution timed out")
+
+// ErrMemoryLimitExceeded is returned when JS execution allocates more memory than allowed.
+var ErrMemoryLimitExceeded = errors.New("memory limit exceeded")
+
+// errHalt is used to unwind the stack of the interrupted otto's VM.
+var errHalt = errors.New("halt")
+
 // VM implements concurrency safe wrapper to
 // otto's VM object.
 type VM struct {
 	sync.Mutex
 
-	vm *otto.Otto
+	vm          *otto.Otto
+	timeout     time.Duration
+	memoryLimit uint64
+
+	// global object and Object.getOwnPropertyDescriptor, captured before any
+	// script is run, used to estimate memory held by JS values
+	global             otto.Value
+	propertyDescriptor otto.Value
 }
 
 // New creates new instance of VM.
 func New() *VM {
+	vm := otto.New()
+	global, _ := vm.Run("this")
+	propertyDescriptor, _ := vm.Run("Object.getOwnPropertyDescriptor")
+
 	return &VM{
-		vm: otto.New(),
+		vm:                 vm,
+		global:             global,
+		propertyDescriptor: propertyDescriptor,
 	}
 }
 
@@ -56,7 +81,21 @@ func (vm *VM) Call(item string, this interface{}, args ...interface{}) (otto.Val
 	vm.Lock()
 	defer vm.Unlock()
 
-	return vm.vm.Call(item, this, args...)
+	return vm.interruptible(context.Background(), func() (otto.Value, error) {
+		return vm.vm.Call(item, this, args...)
+	})
+}
+
+// CallFunction calls the given function value with the given this and args.
+// Like Call, it's interrupted once the execution timeout or the memory limit
+// is exceeded.
+func (vm *VM) CallFunction(fn, this otto.Value, args ...interface{}) (otto.Value, error) {
+	vm.Lock()
+	defer vm.Unlock()
+
+	return vm.interruptible(context.Background(), func() (otto.Value, error) {
+		return fn.Call(this, args...)
+	})
 }
 
 // Run evaluates JS source, which may be string or otto.Script variable.
@@ -64,7 +103,105 @@ func (vm *VM) Run(src interface{}) (otto.Value, error) {
 	vm.Lock()
 	defer vm.Unlock()
 
-	return vm.vm.Run(src)
+	return vm.interruptible(context.Background(), func() (otto.Value, error) {
+		return vm.vm.Run(src)
+	})
+}
+
+// RunWithContext evaluates JS source, which may be string or otto.Script variable.
+// Evaluation is interrupted once the context is done, returning ctx.Err().
+func (vm *VM) RunWithContext(ctx context.Context, src interface{}) (otto.Value, error) {
+	vm.Lock()
+	defer vm.Unlock()
+
+	return vm.interruptible(ctx, func() (otto.Value, error) {
+		return vm.vm.Run(src)
+	})
+}
+
+// SetExecutionTimeout limits the duration of a single Run or Call.
+// Execution exceeding the timeout is aborted with ErrExecutionTimeout.
+// Zero value disables the limit.
+func (vm *VM) SetExecutionTimeout(timeout time.Duration) {
+	vm.Lock()
+	defer vm.Unlock()
+
+	vm.timeout = timeout
+}
+
+// SetMemoryLimit limits the amount of memory JS values of the VM may take during a single
+// Run or Call. Execution exceeding the limit is aborted with ErrMemoryLimitExceeded.
+// Accounting is approximate: size of values reachable from globals and variables of the VM
+// is estimated every 100ms during execution, backing off for estimation to take at most a
+// fifth of the execution time, so that VMs running concurrently are accounted separately.
+// Memory allocated by Go code called from JS is not accounted. Zero value disables the limit.
+func (vm *VM) SetMemoryLimit(limit uint64) {
+	vm.Lock()
+	defer vm.Unlock()
+
+	vm.memoryLimit = limit
+}
+
+// interruptible runs fn and interrupts it once the context is done, the
+// execution timeout or the memory limit is exceeded. Caller is expected
+// to hold the lock.
+func (vm *VM) interruptible(ctx context.Context, fn func() (otto.Value, error)) (value otto.Value, err error) {
+	parent := ctx
+	if vm.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, vm.timeout)
+		defer cancel()
+	}
+
+	// nothing can interrupt execution
+	if ctx.Done() == nil && vm.memoryLimit == 0 {
+		return fn()
+	}
+
+	interrupt := make(chan func(), 1)
+	vm.vm.Interrupt = interrupt
+
+	stop := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			select {
+			case interrupt <- func() {
+				panic(errHalt)
+			}:
+			case <-stop:
+			}
+		case <-stop:
+		}
+	}()
+
+	var memoryExceeded bool // only accessed from the VM goroutine
+	if vm.memoryLimit > 0 {
+		go vm.watchMemory(stop, interrupt, vm.memoryLimit, func() {
+			memoryExceeded = true
+			panic(errHalt)
+		})
+	}
+
+	defer func() {
+		close(stop)
+		vm.vm.Interrupt = nil
+
+		if caught := recover(); caught != nil {
+			if caught != errHalt {
+				panic(caught)
+			}
+
+			value, err = otto.UndefinedValue(), parent.Err()
+			if memoryExceeded {
+				err = ErrMemoryLimitExceeded
+			} else if err == nil {
+				err = ErrExecutionTimeout
+			}
+		}
+	}()
+
+	return fn()
 }
 
 // Compile parses given source and returns otto.Script.
@@ -0,0 +1,52 @@
+package webcrypto
+
+const src = `(function(global) {
+  'use strict';
+
+  var quota = 65536;
+  var integerTypes = [
+    Int8Array, Uint8Array, Uint8ClampedArray,
+    Int16Array, Uint16Array, Int32Array, Uint32Array
+  ];
+
+  function domError(name, message) {
+    var err = new Error(message);
+    err.name = name;
+    return err;
+  }
+
+  var crypto = global.crypto || {};
+
+  crypto.getRandomValues = function(array) {
+    var isInteger = integerTypes.some(function(type) {
+      return array instanceof type;
+    });
+    if (!isInteger) {
+      throw domError('TypeMismatchError', "Failed to execute 'getRandomValues' on 'Crypto': " +
+        "The provided ArrayBufferView is not an integer array type.");
+    }
+
+    if (array.byteLength > quota) {
+      throw domError('QuotaExceededError', "Failed to execute 'getRandomValues' on 'Crypto': " +
+        "The ArrayBufferView's byte length (" + array.byteLength + ") exceeds the number of bytes " +
+        "of entropy available via this API (" + quota + ").");
+    }
+
+    var bytes = __webcrypto_random_bytes(array.byteLength);
+    var size = array.BYTES_PER_ELEMENT;
+    var values = [];
+    for (var i = 0; i < array.length; i++) {
+      var value = 0;
+      for (var j = size - 1; j >= 0; j--) {
+        value = value * 256 + bytes[i * size + j];
+      }
+      values.push(value);
+    }
+    array.set(values);
+
+    return array;
+  };
+
+  global.crypto = crypto;
+})(this);
+`
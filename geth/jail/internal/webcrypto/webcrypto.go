@@ -0,0 +1,60 @@
+package webcrypto
+
+import (
+	"crypto/rand"
+
+	"github.com/robertkrimen/otto"
+
+	"github.com/status-im/status-go/geth/jail/internal/typedarray"
+	"github.com/status-im/status-go/geth/jail/internal/vm"
+)
+
+// Define jail crypto.getRandomValues backed by crypto/rand.
+func Define(vm *vm.VM) error {
+	if v, err := vm.Run(`typeof crypto !== 'undefined' && typeof crypto.getRandomValues === 'function'`); err != nil {
+		return err
+	} else if defined, _ := v.ToBoolean(); defined {
+		return nil
+	}
+
+	if err := typedarray.Define(vm); err != nil {
+		return err
+	}
+
+	if err := vm.Set("__webcrypto_random_bytes", randomBytes); err != nil {
+		return err
+	}
+
+	s, err := vm.Compile("webcrypto.js", src)
+	if err != nil {
+		return err
+	}
+
+	_, err = vm.Run(s)
+	return err
+}
+
+// randomBytes returns the given number of random bytes as a list of numbers.
+func randomBytes(call otto.FunctionCall) otto.Value {
+	n, err := call.Argument(0).ToInteger()
+	if err != nil {
+		panic(err)
+	}
+
+	data := make([]byte, n)
+	if _, err := rand.Read(data); err != nil {
+		panic(call.Otto.MakeCustomError("OperationError", err.Error()))
+	}
+
+	bytes := make([]int, len(data))
+	for i, b := range data {
+		bytes[i] = int(b)
+	}
+
+	v, err := call.Otto.ToValue(bytes)
+	if err != nil {
+		panic(err)
+	}
+
+	return v
+}
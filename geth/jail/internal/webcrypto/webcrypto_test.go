@@ -0,0 +1,77 @@
+package webcrypto_test
+
+import (
+	"testing"
+
+	"github.com/status-im/status-go/geth/jail/internal/vm"
+	"github.com/status-im/status-go/geth/jail/internal/webcrypto"
+	"github.com/stretchr/testify/suite"
+)
+
+func (s *WebCryptoSuite) TestGetRandomValues() {
+	v, err := s.vm.Run(`
+		var a = crypto.getRandomValues(new Uint8Array(32));
+		var b = crypto.getRandomValues(new Uint8Array(32));
+		[a.length, a.join(",") !== b.join(",")].join(",")
+	`)
+	s.NoError(err)
+	s.Equal("32,true", v.String())
+}
+
+func (s *WebCryptoSuite) TestGetRandomValuesWideTypes() {
+	v, err := s.vm.Run(`
+		var a = crypto.getRandomValues(new Uint32Array(16));
+		var b = crypto.getRandomValues(new Int16Array(16));
+		a.every(function(x) { return x >= 0 && x <= 0xffffffff && x === Math.floor(x); }) &&
+			b.every(function(x) { return x >= -0x8000 && x <= 0x7fff; }) &&
+			a.some(function(x) { return x > 0xffff; })
+	`)
+	s.NoError(err)
+	s.Equal("true", v.String())
+}
+
+func (s *WebCryptoSuite) TestQuotaExceeded() {
+	_, err := s.vm.Run(`crypto.getRandomValues(new Uint8Array(65536))`)
+	s.NoError(err)
+
+	v, err := s.vm.Run(`
+		try {
+			crypto.getRandomValues(new Uint8Array(65537));
+		} catch (e) {
+			e.name
+		}
+	`)
+	s.NoError(err)
+	s.Equal("QuotaExceededError", v.String())
+}
+
+func (s *WebCryptoSuite) TestNonIntegerArray() {
+	v, err := s.vm.Run(`
+		[new Float32Array(4), [1, 2, 3]].map(function(array) {
+			try {
+				crypto.getRandomValues(array);
+			} catch (e) {
+				return e.name;
+			}
+		}).join(",")
+	`)
+	s.NoError(err)
+	s.Equal("TypeMismatchError,TypeMismatchError", v.String())
+}
+
+type WebCryptoSuite struct {
+	suite.Suite
+
+	vm *vm.VM
+}
+
+func (s *WebCryptoSuite) SetupTest() {
+	s.vm = vm.New()
+
+	err := webcrypto.Define(s.vm)
+	s.NoError(err)
+}
+
+func TestWebCryptoSuite(t *testing.T) {
+	suite.Run(t, new(WebCryptoSuite))
+}
@@ -0,0 +1,45 @@
+package websocket
+
+const src = `'use strict';
+
+/**
+ * @constructor
+ */
+function WebSocket(url) {
+  if (!(this instanceof WebSocket)) {
+    throw new TypeError("Failed to construct 'WebSocket': Please use the 'new' operator");
+  }
+
+  this.url = String(url);
+  this.readyState = WebSocket.CONNECTING;
+
+  this.onopen = null;
+  this.onmessage = null;
+  this.onerror = null;
+  this.onclose = null;
+
+  __websocketConnect(this, this.url);
+}
+
+WebSocket.CONNECTING = 0;
+WebSocket.OPEN = 1;
+WebSocket.CLOSING = 2;
+WebSocket.CLOSED = 3;
+
+WebSocket.prototype.send = function(data) {
+  if (this.readyState !== WebSocket.OPEN) {
+    throw new Error("WebSocket is not open");
+  }
+
+  this.__send(String(data));
+};
+
+WebSocket.prototype.close = function() {
+  if (this.readyState === WebSocket.CLOSING || this.readyState === WebSocket.CLOSED) {
+    return;
+  }
+
+  this.readyState = WebSocket.CLOSING;
+  this.__close();
+};
+`
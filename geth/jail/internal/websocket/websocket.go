@@ -0,0 +1,249 @@
+package websocket
+
+import (
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/robertkrimen/otto"
+	"golang.org/x/net/websocket"
+
+	"github.com/status-im/status-go/geth/jail/internal/loop"
+	"github.com/status-im/status-go/geth/jail/internal/vm"
+)
+
+const (
+	origin      = "http://localhost/"
+	dialTimeout = 10 * time.Second
+)
+
+// ready states as defined by WebSocket API
+const (
+	stateOpen   = 1
+	stateClosed = 3
+)
+
+var errClosed = errors.New("connection closed")
+
+// Define jail WebSocket constructor
+func Define(vm *vm.VM, l *loop.Loop) error {
+	if v, err := vm.Get("WebSocket"); err != nil {
+		return err
+	} else if !v.IsUndefined() {
+		return nil
+	}
+
+	if err := vm.Set("__websocketConnect", newConnectHandler(l)); err != nil {
+		return err
+	}
+
+	s, err := vm.Compile("websocket.js", src)
+	if err != nil {
+		return err
+	}
+
+	_, err = vm.Run(s)
+	return err
+}
+
+func newConnectHandler(l *loop.Loop) func(call otto.FunctionCall) otto.Value {
+	return func(call otto.FunctionCall) otto.Value {
+		obj := call.Argument(0).Object()
+		url := call.Argument(1).String()
+
+		t := &connTask{obj: obj}
+		if err := obj.Set("__send", t.send); err != nil {
+			panic(call.Otto.MakeCustomError("WebSocket", err.Error()))
+		}
+		if err := obj.Set("__close", t.close); err != nil {
+			panic(call.Otto.MakeCustomError("WebSocket", err.Error()))
+		}
+
+		// The task lives in the loop for the whole lifetime of the connection,
+		// so that it gets cancelled when the loop is stopped.
+		if err := l.Add(t); err != nil {
+			panic(call.Otto.MakeCustomError("WebSocket", err.Error()))
+		}
+
+		go t.run(l, url)
+
+		return otto.UndefinedValue()
+	}
+}
+
+// connTask represents a single WebSocket connection. It becomes ready
+// for finalising when the connection is closed, dispatching onclose event.
+type connTask struct {
+	id  int64
+	obj *otto.Object
+
+	mu     sync.Mutex
+	conn   *websocket.Conn
+	closed bool
+	err    error
+}
+
+func (t *connTask) SetID(id int64) { t.id = id }
+func (t *connTask) GetID() int64   { return t.id }
+
+// Execute dispatches onclose event.
+func (t *connTask) Execute(vm *vm.VM, l *loop.Loop) error {
+	if err := setState(vm, t.obj, stateClosed); err != nil {
+		return err
+	}
+
+	return dispatch(vm, t.obj, "close", map[string]interface{}{
+		"type":     "close",
+		"wasClean": t.err == nil,
+	})
+}
+
+// Cancel closes the underlying connection.
+func (t *connTask) Cancel() {
+	t.close() // nolint: errcheck
+}
+
+func (t *connTask) run(l *loop.Loop, url string) {
+	conn, err := dial(url)
+
+	t.mu.Lock()
+	if err == nil && t.closed {
+		conn.Close() // nolint: errcheck
+		err = errClosed
+	}
+	t.conn = conn
+	t.mu.Unlock()
+
+	if err != nil {
+		// closing the socket before it's connected is not an error
+		if err != errClosed {
+			t.err = err
+			if l.AddAndExecute(newEventTask(t.obj, "error", err.Error())) != nil {
+				return
+			}
+		}
+		l.Ready(t) // nolint: errcheck
+		return
+	}
+
+	if l.AddAndExecute(newOpenTask(t.obj)) != nil {
+		return
+	}
+
+	for {
+		var msg string
+		if err := websocket.Message.Receive(conn, &msg); err != nil {
+			break
+		}
+
+		if l.AddAndExecute(newEventTask(t.obj, "message", msg)) != nil {
+			return
+		}
+	}
+
+	t.close()  // nolint: errcheck
+	l.Ready(t) // nolint: errcheck
+}
+
+func (t *connTask) send(call otto.FunctionCall) otto.Value {
+	t.mu.Lock()
+	conn := t.conn
+	t.mu.Unlock()
+
+	if conn == nil {
+		panic(call.Otto.MakeCustomError("WebSocket", "WebSocket is not open"))
+	}
+
+	if err := websocket.Message.Send(conn, call.Argument(0).String()); err != nil {
+		panic(call.Otto.MakeCustomError("WebSocket", err.Error()))
+	}
+
+	return otto.UndefinedValue()
+}
+
+func (t *connTask) close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.closed = true
+	if t.conn == nil {
+		return nil
+	}
+
+	return t.conn.Close()
+}
+
+func dial(url string) (*websocket.Conn, error) {
+	config, err := websocket.NewConfig(url, origin)
+	if err != nil {
+		return nil, err
+	}
+	config.Dialer = &net.Dialer{Timeout: dialTimeout}
+
+	return websocket.DialConfig(config)
+}
+
+// eventTask dispatches a single WebSocket event within the loop.
+type eventTask struct {
+	id    int64
+	obj   *otto.Object
+	event string
+	data  string
+	state int
+}
+
+func newEventTask(obj *otto.Object, event, data string) *eventTask {
+	return &eventTask{obj: obj, event: event, data: data}
+}
+
+func newOpenTask(obj *otto.Object) *eventTask {
+	return &eventTask{obj: obj, event: "open", state: stateOpen}
+}
+
+func (t *eventTask) SetID(id int64) { t.id = id }
+func (t *eventTask) GetID() int64   { return t.id }
+
+func (t *eventTask) Execute(vm *vm.VM, l *loop.Loop) error {
+	if t.state != 0 {
+		if err := setState(vm, t.obj, t.state); err != nil {
+			return err
+		}
+	}
+
+	event := map[string]interface{}{"type": t.event}
+	if t.event == "message" {
+		event["data"] = t.data
+	} else if t.event == "error" {
+		event["message"] = t.data
+	}
+
+	return dispatch(vm, t.obj, t.event, event)
+}
+
+func (t *eventTask) Cancel() {}
+
+// setState sets readyState of the object.
+func setState(vm *vm.VM, obj *otto.Object, state int) error {
+	vm.Lock()
+	defer vm.Unlock()
+
+	return obj.Set("readyState", state)
+}
+
+// dispatch calls on<event> handler of the object, if it's defined.
+func dispatch(vm *vm.VM, obj *otto.Object, event string, arg interface{}) error {
+	vm.Lock()
+	handler, err := obj.Get("on" + event)
+	vm.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if !handler.IsFunction() {
+		return nil
+	}
+
+	_, err = vm.CallFunction(handler, obj.Value(), arg)
+	return err
+}
@@ -0,0 +1,164 @@
+package websocket_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/net/websocket"
+
+	"github.com/status-im/status-go/geth/jail/internal/loop"
+	"github.com/status-im/status-go/geth/jail/internal/vm"
+	jailws "github.com/status-im/status-go/geth/jail/internal/websocket"
+)
+
+func (s *WebSocketSuite) TestEcho() {
+	err := s.vm.Set("__capture", func(event, data string) {
+		s.ch <- event + ":" + data
+	})
+	s.NoError(err)
+
+	err = s.loop.Eval(`
+		var ws = new WebSocket("` + s.url + `");
+		ws.onopen = function() {
+			__capture("open", String(ws.readyState));
+			ws.send("hello");
+		};
+		ws.onmessage = function(e) {
+			__capture("message", e.data);
+			ws.close();
+		};
+		ws.onclose = function(e) {
+			__capture("close", String(ws.readyState));
+		};
+	`)
+	s.NoError(err)
+
+	for _, expected := range []string{"open:1", "message:hello", "close:3"} {
+		select {
+		case received := <-s.ch:
+			s.Equal(expected, received)
+		case <-time.After(time.Second):
+			s.Fail("test timed out")
+			return
+		}
+	}
+}
+
+func (s *WebSocketSuite) TestConnectionError() {
+	err := s.vm.Set("__capture", func(event string) {
+		s.ch <- event
+	})
+	s.NoError(err)
+
+	err = s.loop.Eval(`
+		var ws = new WebSocket("ws://127.0.0.1:1/");
+		ws.onerror = function(e) { __capture("error"); };
+		ws.onclose = function(e) { __capture("close:" + e.wasClean); };
+	`)
+	s.NoError(err)
+
+	for _, expected := range []string{"error", "close:false"} {
+		select {
+		case received := <-s.ch:
+			s.Equal(expected, received)
+		case <-time.After(time.Second):
+			s.Fail("test timed out")
+			return
+		}
+	}
+}
+
+func (s *WebSocketSuite) TestSendBeforeOpen() {
+	_, err := s.vm.Run(`
+		var ws = new WebSocket("` + s.url + `");
+		ws.send("hello");
+	`)
+	s.Error(err)
+	s.True(strings.Contains(err.Error(), "WebSocket is not open"))
+}
+
+func (s *WebSocketSuite) TestLoopStopClosesConnection() {
+	err := s.vm.Set("__capture", func(event string) {
+		s.ch <- event
+	})
+	s.NoError(err)
+
+	err = s.loop.Eval(`
+		var ws = new WebSocket("` + s.url + `");
+		ws.onopen = function() { __capture("open"); };
+	`)
+	s.NoError(err)
+
+	select {
+	case <-s.ch:
+	case <-time.After(time.Second):
+		s.Fail("test timed out")
+		return
+	}
+
+	s.cancel()
+
+	select {
+	case <-s.serverDone:
+	case <-time.After(time.Second):
+		s.Fail("connection hasn't been closed")
+	}
+}
+
+type WebSocketSuite struct {
+	suite.Suite
+
+	loop   *loop.Loop
+	vm     *vm.VM
+	cancel context.CancelFunc
+
+	server     *httptest.Server
+	serverDone chan struct{}
+	url        string
+
+	ch chan string
+}
+
+func (s *WebSocketSuite) SetupTest() {
+	serverDone := make(chan struct{}, 1)
+	s.serverDone = serverDone
+	s.server = httptest.NewServer(websocket.Handler(func(conn *websocket.Conn) {
+		defer func() { serverDone <- struct{}{} }()
+
+		for {
+			var msg string
+			if err := websocket.Message.Receive(conn, &msg); err != nil {
+				return
+			}
+			if err := websocket.Message.Send(conn, msg); err != nil {
+				return
+			}
+		}
+	}))
+	s.url = "ws" + strings.TrimPrefix(s.server.URL, "http")
+
+	s.vm = vm.New()
+	s.loop = loop.New(s.vm)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	go s.loop.Run(ctx) //nolint: errcheck
+
+	err := jailws.Define(s.vm, s.loop)
+	s.NoError(err)
+
+	s.ch = make(chan string, 3)
+}
+
+func (s *WebSocketSuite) TearDownTest() {
+	s.cancel()
+	s.server.Close()
+}
+
+func TestWebSocketSuite(t *testing.T) {
+	suite.Run(t, new(WebSocketSuite))
+}
@@ -48,6 +48,8 @@ type Jail struct {
 	baseJS            string
 	cellsMx           sync.RWMutex
 	cells             map[string]*Cell
+	rpcFilterMx       sync.RWMutex
+	rpcFilter         RPCMethodFilter
 }
 
 // New returns a new Jail.
@@ -95,7 +97,7 @@ func (j *Jail) obtainCell(chatID string, expectNew bool) (cell *Cell, err error)
 	if cell, ok = j.cells[chatID]; ok {
 		// Return a non-nil error if a new cell was expected
 		if expectNew {
-			err = fmt.Errorf("cell with id '%s' already exists", chatID)
+			err = newCellExistsError(chatID)
 		}
 		return
 	}
@@ -127,6 +129,10 @@ func (j *Jail) initCell(cell *Cell) error {
 		return err
 	}
 
+	if err := registerWhisperSubscriptions(j, cell); err != nil {
+		return err
+	}
+
 	// Run some initial JS code to provide some global objects.
 	c := []string{
 		j.baseJS,
@@ -134,8 +140,12 @@ func (j *Jail) initCell(cell *Cell) error {
 		web3InstanceCode,
 	}
 
-	_, err := cell.Run(strings.Join(c, ";"))
-	return err
+	if _, err := cell.Run(strings.Join(c, ";")); err != nil {
+		return err
+	}
+
+	// Globals defined so far are kept by Reset.
+	return cell.snapshotGlobals()
 }
 
 // CreateAndInitCell creates and initializes a new Cell.
@@ -262,8 +272,33 @@ func (j *Jail) RPCClient() *rpc.Client {
 	return j.rpcClientProvider.RPCClient()
 }
 
-// sendRPCCall executes a raw JSON-RPC request.
+// sendRPCCall executes a raw JSON-RPC request. Requests for methods rejected
+// by RPCMethodFilter get error responses and are not sent to the node.
 func (j *Jail) sendRPCCall(request string) (interface{}, error) {
+	request, rejected, size := filterRPCRequest(j.rpcMethodFilter(), request)
+	if request == "" {
+		if size < 0 {
+			return rejected[0], nil
+		}
+		return mergeRPCResponses(size, nil, rejected), nil
+	}
+
+	response, err := j.callRaw(request)
+	if err != nil || len(rejected) == 0 {
+		return response, err
+	}
+
+	// A batch results in a single error response if the node failed to process it.
+	passed, ok := response.([]interface{})
+	if !ok {
+		return response, nil
+	}
+
+	return mergeRPCResponses(size, passed, rejected), nil
+}
+
+// callRaw sends a raw JSON-RPC request to the node.
+func (j *Jail) callRaw(request string) (interface{}, error) {
 	client := j.RPCClient()
 	if client == nil {
 		return nil, ErrNoRPCClient
@@ -0,0 +1,131 @@
+package jail
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+// RPCMethodFilter restricts JSON-RPC methods cells can call through the web3 provider.
+// Entries are either full method names (e.g. eth_sendTransaction), or namespaces
+// followed by an underscore (e.g. admin_), matching all the methods of the namespace.
+// Rejected requests get an error response, without reaching the node.
+type RPCMethodFilter struct {
+	// Allow lists the only allowed methods, all methods are allowed if it's empty.
+	Allow []string
+	// Block lists rejected methods, it takes precedence over Allow.
+	Block []string
+}
+
+// Allowed checks whether a given method passes the filter.
+func (f RPCMethodFilter) Allowed(method string) bool {
+	if matchRPCMethod(f.Block, method) {
+		return false
+	}
+
+	return len(f.Allow) == 0 || matchRPCMethod(f.Allow, method)
+}
+
+func matchRPCMethod(entries []string, method string) bool {
+	for _, entry := range entries {
+		if entry == method || (strings.HasSuffix(entry, "_") && strings.HasPrefix(method, entry)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SetRPCMethodFilter sets the filter applied to JSON-RPC requests made by cells
+// through the web3 provider. Zero filter, which is the default, allows all methods.
+func (j *Jail) SetRPCMethodFilter(filter RPCMethodFilter) {
+	j.rpcFilterMx.Lock()
+	j.rpcFilter = filter
+	j.rpcFilterMx.Unlock()
+}
+
+func (j *Jail) rpcMethodFilter() RPCMethodFilter {
+	j.rpcFilterMx.RLock()
+	defer j.rpcFilterMx.RUnlock()
+
+	return j.rpcFilter
+}
+
+// rpcRequestHeader holds the fields of a JSON-RPC request the filter is interested in.
+type rpcRequestHeader struct {
+	ID     interface{} `json:"id"`
+	Method string      `json:"method"`
+}
+
+// filterRPCRequest splits a raw JSON-RPC request into requests passing the filter, encoded
+// back the same way, and error responses of the rejected ones, indexed by their position
+// in the batch. size is the number of requests in the batch, or -1 if it's a single request.
+// Requests which can't be parsed are passed, so that the node reports the error.
+func filterRPCRequest(filter RPCMethodFilter, request string) (passed string, rejected map[int]interface{}, size int) {
+	trimmed := bytes.TrimSpace([]byte(request))
+	if len(trimmed) == 0 || trimmed[0] != '[' {
+		var header rpcRequestHeader
+		if err := json.Unmarshal(trimmed, &header); err != nil || filter.Allowed(header.Method) {
+			return request, nil, -1
+		}
+		return "", map[int]interface{}{0: newRPCMethodRejectedResponse(header)}, -1
+	}
+
+	var batch []json.RawMessage
+	if err := json.Unmarshal(trimmed, &batch); err != nil {
+		return request, nil, -1
+	}
+
+	rejected = make(map[int]interface{})
+	allowed := make([]json.RawMessage, 0, len(batch))
+	for i, raw := range batch {
+		var header rpcRequestHeader
+		if err := json.Unmarshal(raw, &header); err == nil && !filter.Allowed(header.Method) {
+			rejected[i] = newRPCMethodRejectedResponse(header)
+			continue
+		}
+		allowed = append(allowed, raw)
+	}
+
+	if len(rejected) == 0 {
+		return request, nil, len(batch)
+	}
+
+	if len(allowed) == 0 {
+		return "", rejected, len(batch)
+	}
+
+	encoded, err := json.Marshal(allowed)
+	if err != nil {
+		return request, nil, len(batch)
+	}
+
+	return string(encoded), rejected, len(batch)
+}
+
+// mergeRPCResponses puts error responses of rejected requests of a batch back
+// in place, among the responses of the passed ones.
+func mergeRPCResponses(size int, passed []interface{}, rejected map[int]interface{}) []interface{} {
+	responses := make([]interface{}, 0, size)
+	for i := 0; i < size; i++ {
+		if response, ok := rejected[i]; ok {
+			responses = append(responses, response)
+		} else if len(passed) > 0 {
+			responses = append(responses, passed[0])
+			passed = passed[1:]
+		}
+	}
+
+	return append(responses, passed...)
+}
+
+func newRPCMethodRejectedResponse(header rpcRequestHeader) interface{} {
+	return map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      header.ID,
+		"error": map[string]interface{}{
+			"code":    providerErrorUnauthorized,
+			"message": "method is not allowed: " + header.Method,
+		},
+	}
+}
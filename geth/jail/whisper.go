@@ -0,0 +1,290 @@
+package jail
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	whisper "github.com/ethereum/go-ethereum/whisper/whisperv6"
+	"github.com/robertkrimen/otto"
+)
+
+// whisperPollInterval defines how often subscribed whisper filters are checked for new messages.
+const whisperPollInterval = 250 * time.Millisecond
+
+var (
+	// ErrNoWhisperService is returned when whisper is required but the jail provider doesn't expose it.
+	ErrNoWhisperService = errors.New("whisper service is not available")
+	// ErrWhisperSubscriptionNotFound is returned when unsubscribing with an unknown subscription ID.
+	ErrWhisperSubscriptionNotFound = errors.New("whisper subscription not found")
+)
+
+// WhisperServiceProvider is an interface that provides a way to obtain the
+// running whisper service. If the RPCClientProvider passed to the jail
+// implements it, cells can subscribe to whisper messages with shh.subscribe().
+type WhisperServiceProvider interface {
+	WhisperService() (*whisper.Whisper, error)
+}
+
+// WhisperFilter describes a whisper filter installed by a cell with shh.subscribe().
+type WhisperFilter struct {
+	ID     string              // subscription ID, the same as the filter ID in whisper
+	Topics []whisper.TopicType // topics the filter matches, any topic if empty
+}
+
+// whisperSubscriptions keeps whisper filters installed by a single cell,
+// along with the goroutines delivering their messages.
+type whisperSubscriptions struct {
+	mu   sync.Mutex
+	shh  *whisper.Whisper
+	subs map[string]*whisperSubscription
+}
+
+// whisperSubscription is a filter installed by a cell.
+type whisperSubscription struct {
+	quit   chan struct{}
+	topics []whisper.TopicType
+}
+
+// registerWhisperSubscriptions creates an object called "shh", allowing to subscribe
+// to whisper messages: shh.subscribe(criteria, callback) returns a subscription ID,
+// which is passed to shh.unsubscribe(id). Criteria are the same as for shh_newMessageFilter
+// RPC method, and messages passed to callback are formatted as shh_getFilterMessages ones.
+// Subscriptions are removed once the cell is stopped.
+func registerWhisperSubscriptions(jail *Jail, cell *Cell) error {
+	subs := &whisperSubscriptions{subs: make(map[string]*whisperSubscription)}
+	cell.whisperSubs = subs
+	cell.onStop(subs.unsubscribeAll)
+
+	shh := map[string]interface{}{
+		"subscribe":   createWhisperSubscribeHandler(jail, cell, subs),
+		"unsubscribe": createWhisperUnsubscribeHandler(subs),
+	}
+
+	return cell.jsvm.Set("shh", shh)
+}
+
+// createWhisperSubscribeHandler returns shh.subscribe() handler.
+func createWhisperSubscribeHandler(jail *Jail, cell *Cell, subs *whisperSubscriptions) func(call otto.FunctionCall) otto.Value {
+	return func(call otto.FunctionCall) otto.Value {
+		provider, ok := jail.rpcClientProvider.(WhisperServiceProvider)
+		if !ok {
+			throwJSError(ErrNoWhisperService)
+		}
+
+		shh, err := provider.WhisperService()
+		if err != nil {
+			throwJSError(err)
+		}
+
+		callback := call.Argument(1)
+		if !callback.IsFunction() {
+			throwJSError(errors.New("callback must be a function"))
+		}
+
+		// As it's a sync call, it's called already from a thread-safe context,
+		// thus using otto.Otto directly.
+		criteria, err := cell.jsvm.UnsafeVM().Call("JSON.stringify", nil, call.Argument(0))
+		if err != nil {
+			throwJSError(err)
+		}
+
+		filter, err := newWhisperFilter(shh, criteria.String())
+		if err != nil {
+			throwJSError(err)
+		}
+
+		id, err := subs.subscribe(shh, filter, func(message map[string]interface{}) {
+			cell.CallAsync(callback, message) // nolint: errcheck
+		})
+		if err != nil {
+			throwJSError(err)
+		}
+
+		value, err := call.Otto.ToValue(id)
+		if err != nil {
+			throwJSError(err)
+		}
+
+		return value
+	}
+}
+
+// createWhisperUnsubscribeHandler returns shh.unsubscribe() handler.
+func createWhisperUnsubscribeHandler(subs *whisperSubscriptions) func(call otto.FunctionCall) otto.Value {
+	return func(call otto.FunctionCall) otto.Value {
+		if err := subs.unsubscribe(call.Argument(0).String()); err != nil {
+			throwJSError(err)
+		}
+
+		return otto.UndefinedValue()
+	}
+}
+
+// newWhisperFilter creates a filter out of JSON encoded criteria, the same way
+// shh_newMessageFilter does it.
+func newWhisperFilter(shh *whisper.Whisper, rawCriteria string) (*whisper.Filter, error) {
+	var criteria whisper.Criteria
+	if err := json.Unmarshal([]byte(rawCriteria), &criteria); err != nil {
+		return nil, err
+	}
+
+	symKeyGiven := len(criteria.SymKeyID) > 0
+	asymKeyGiven := len(criteria.PrivateKeyID) > 0
+	if symKeyGiven == asymKeyGiven {
+		return nil, whisper.ErrSymAsym
+	}
+
+	filter := &whisper.Filter{
+		PoW:      criteria.MinPow,
+		AllowP2P: criteria.AllowP2P,
+		Messages: make(map[gethcommon.Hash]*whisper.ReceivedMessage),
+	}
+
+	if len(criteria.Sig) > 0 {
+		filter.Src = crypto.ToECDSAPub(criteria.Sig)
+		if !whisper.ValidatePublicKey(filter.Src) {
+			return nil, whisper.ErrInvalidSigningPubKey
+		}
+	}
+
+	var err error
+	if symKeyGiven {
+		if filter.KeySym, err = shh.GetSymKey(criteria.SymKeyID); err != nil {
+			return nil, err
+		}
+		filter.SymKeyHash = crypto.Keccak256Hash(filter.KeySym)
+	} else if filter.KeyAsym, err = shh.GetPrivateKey(criteria.PrivateKeyID); err != nil {
+		return nil, err
+	}
+
+	for _, topic := range criteria.Topics {
+		filter.Topics = append(filter.Topics, topic[:])
+	}
+
+	return filter, nil
+}
+
+// subscribe installs the filter, and passes its messages to deliver until unsubscribed.
+func (s *whisperSubscriptions) subscribe(shh *whisper.Whisper, filter *whisper.Filter, deliver func(map[string]interface{})) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.subs == nil {
+		return "", errors.New("cell is stopped")
+	}
+
+	id, err := shh.Subscribe(filter)
+	if err != nil {
+		return "", err
+	}
+
+	s.shh = shh
+	quit := make(chan struct{})
+	sub := &whisperSubscription{quit: quit}
+	for _, topic := range filter.Topics {
+		sub.topics = append(sub.topics, whisper.BytesToTopic(topic))
+	}
+	s.subs[id] = sub
+
+	go pollWhisperFilter(shh, id, quit, deliver)
+
+	return id, nil
+}
+
+// unsubscribe removes the filter and stops delivery of its messages.
+func (s *whisperSubscriptions) unsubscribe(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sub, ok := s.subs[id]
+	if !ok {
+		return ErrWhisperSubscriptionNotFound
+	}
+
+	close(sub.quit)
+	delete(s.subs, id)
+
+	return s.shh.Unsubscribe(id)
+}
+
+// unsubscribeAll removes all filters, no more subscriptions can be made afterwards.
+func (s *whisperSubscriptions) unsubscribeAll() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for id, sub := range s.subs {
+		close(sub.quit)
+		s.shh.Unsubscribe(id) // nolint: errcheck
+	}
+	s.subs = nil
+}
+
+// filters returns filters currently installed, sorted by ID.
+func (s *whisperSubscriptions) filters() []WhisperFilter {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	filters := make([]WhisperFilter, 0, len(s.subs))
+	for id, sub := range s.subs {
+		filters = append(filters, WhisperFilter{
+			ID:     id,
+			Topics: append([]whisper.TopicType(nil), sub.topics...),
+		})
+	}
+	sort.Slice(filters, func(i, j int) bool {
+		return filters[i].ID < filters[j].ID
+	})
+
+	return filters
+}
+
+// WhisperFilters returns whisper filters the cell has installed with shh.subscribe() and
+// not removed yet, e.g. to debug leaked subscriptions. Filters are removed once the cell
+// is stopped. Cells not created by a jail have no shh object, so no filters either.
+func (c *Cell) WhisperFilters() []WhisperFilter {
+	if c.whisperSubs == nil {
+		return []WhisperFilter{}
+	}
+
+	return c.whisperSubs.filters()
+}
+
+// pollWhisperFilter periodically retrieves messages matched by the filter, until quit is closed.
+func pollWhisperFilter(shh *whisper.Whisper, id string, quit <-chan struct{}, deliver func(map[string]interface{})) {
+	ticker := time.NewTicker(whisperPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-quit:
+			return
+		case <-ticker.C:
+		}
+
+		for _, received := range shh.Messages(id) {
+			message, err := whisperMessageToMap(received)
+			if err != nil {
+				continue
+			}
+			deliver(message)
+		}
+	}
+}
+
+// whisperMessageToMap formats received message the same way whisper RPC API does it,
+// so that it can be passed to JS as an object.
+func whisperMessageToMap(received *whisper.ReceivedMessage) (map[string]interface{}, error) {
+	data, err := json.Marshal(whisper.ToWhisperMessage(received))
+	if err != nil {
+		return nil, err
+	}
+
+	var message map[string]interface{}
+	err = json.Unmarshal(data, &message)
+	return message, err
+}
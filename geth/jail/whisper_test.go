@@ -0,0 +1,170 @@
+package jail
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	whisper "github.com/ethereum/go-ethereum/whisper/whisperv6"
+	"github.com/robertkrimen/otto"
+	"github.com/stretchr/testify/suite"
+)
+
+type testWhisperProvider struct {
+	testRPCClientProvider
+	shh *whisper.Whisper
+}
+
+func (p testWhisperProvider) WhisperService() (*whisper.Whisper, error) {
+	return p.shh, nil
+}
+
+func TestWhisperTestSuite(t *testing.T) {
+	suite.Run(t, new(WhisperTestSuite))
+}
+
+type WhisperTestSuite struct {
+	suite.Suite
+	shh      *whisper.Whisper
+	symKeyID string
+	jail     *Jail
+	cell     *Cell
+	messages chan string
+}
+
+func (s *WhisperTestSuite) SetupTest() {
+	s.shh = whisper.New(nil)
+	s.NoError(s.shh.Start(nil))
+
+	symKeyID, err := s.shh.GenerateSymKey()
+	s.NoError(err)
+	s.symKeyID = symKeyID
+
+	s.jail = New(testWhisperProvider{shh: s.shh})
+	cell, _, err := s.jail.createAndInitCell("cell1")
+	s.NoError(err)
+	s.cell = cell
+
+	s.messages = make(chan string, 10)
+	err = cell.Set("__capture", func(call otto.FunctionCall) otto.Value {
+		s.messages <- call.Argument(0).String()
+		return otto.UndefinedValue()
+	})
+	s.NoError(err)
+}
+
+func (s *WhisperTestSuite) TearDownTest() {
+	s.jail.Stop()
+	s.NoError(s.shh.Stop())
+}
+
+// post sends a message encrypted with the test symmetric key.
+func (s *WhisperTestSuite) post(topic whisper.TopicType, payload string) {
+	symKey, err := s.shh.GetSymKey(s.symKeyID)
+	s.NoError(err)
+
+	params := &whisper.MessageParams{
+		KeySym:   symKey,
+		Topic:    topic,
+		Payload:  []byte(payload),
+		TTL:      whisper.DefaultTTL,
+		PoW:      s.shh.MinPow(),
+		WorkTime: 5,
+	}
+	message, err := whisper.NewSentMessage(params)
+	s.NoError(err)
+	envelope, err := message.Wrap(params)
+	s.NoError(err)
+	s.NoError(s.shh.Send(envelope))
+}
+
+// subscribe subscribes the cell to the topic, returning subscription ID.
+func (s *WhisperTestSuite) subscribe(topic whisper.TopicType) string {
+	value, err := s.cell.Run(`shh.subscribe({
+		symKeyID: '` + s.symKeyID + `',
+		topics: ['` + topic.String() + `']
+	}, function(message) {
+		__capture(message.topic + ':' + message.payload);
+	})`)
+	s.NoError(err)
+
+	return value.Value().String()
+}
+
+func (s *WhisperTestSuite) TestSubscribe() {
+	topic := whisper.BytesToTopic([]byte("test"))
+	id := s.subscribe(topic)
+	s.NotNil(s.shh.GetFilter(id))
+
+	// messages on other topics are not delivered
+	s.post(whisper.BytesToTopic([]byte("other")), "other")
+	s.post(topic, "hello")
+
+	select {
+	case message := <-s.messages:
+		s.Equal(topic.String()+":"+hexutil.Encode([]byte("hello")), message)
+	case <-time.After(5 * time.Second):
+		s.Fail("message not delivered")
+	}
+
+	_, err := s.cell.Run(`shh.unsubscribe('` + id + `')`)
+	s.NoError(err)
+	s.Nil(s.shh.GetFilter(id))
+
+	_, err = s.cell.Run(`shh.unsubscribe('` + id + `')`)
+	s.EqualError(err, ErrWhisperSubscriptionNotFound.Error())
+}
+
+func (s *WhisperTestSuite) TestSubscriptionsRemovedOnStop() {
+	id := s.subscribe(whisper.BytesToTopic([]byte("test")))
+	s.NotNil(s.shh.GetFilter(id))
+
+	s.NoError(s.cell.Stop())
+	s.Nil(s.shh.GetFilter(id))
+}
+
+func (s *WhisperTestSuite) TestWhisperFilters() {
+	s.Empty(s.cell.WhisperFilters())
+
+	topic1 := whisper.BytesToTopic([]byte("test1"))
+	topic2 := whisper.BytesToTopic([]byte("test2"))
+	id1 := s.subscribe(topic1)
+	id2 := s.subscribe(topic2)
+
+	expected := []WhisperFilter{
+		{ID: id1, Topics: []whisper.TopicType{topic1}},
+		{ID: id2, Topics: []whisper.TopicType{topic2}},
+	}
+	if id2 < id1 {
+		expected[0], expected[1] = expected[1], expected[0]
+	}
+	s.Equal(expected, s.cell.WhisperFilters())
+
+	_, err := s.cell.Run(`shh.unsubscribe('` + id1 + `')`)
+	s.NoError(err)
+	s.Equal([]WhisperFilter{{ID: id2, Topics: []whisper.TopicType{topic2}}}, s.cell.WhisperFilters())
+
+	s.NoError(s.cell.Stop())
+	s.Empty(s.cell.WhisperFilters())
+	s.Nil(s.shh.GetFilter(id2))
+}
+
+func (s *WhisperTestSuite) TestSubscribeInvalidCriteria() {
+	// neither symmetric nor asymmetric key
+	_, err := s.cell.Run(`shh.subscribe({topics: []}, function() {})`)
+	s.EqualError(err, whisper.ErrSymAsym.Error())
+
+	_, err = s.cell.Run(`shh.subscribe({symKeyID: 'unknown'}, function() {})`)
+	s.Error(err)
+}
+
+func (s *WhisperTestSuite) TestSubscribeWithoutWhisper() {
+	jail := New(nil)
+	defer jail.Stop()
+
+	cell, _, err := jail.createAndInitCell("cell1")
+	s.NoError(err)
+
+	_, err = cell.Run(`shh.subscribe({symKeyID: '` + s.symKeyID + `'}, function() {})`)
+	s.EqualError(err, ErrNoWhisperService.Error())
+}
@@ -0,0 +1,103 @@
+package mailservice
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/node"
+	whisper "github.com/ethereum/go-ethereum/whisper/whisperv6"
+)
+
+// historyPollInterval defines how often the filter of a history request is checked for new messages.
+const historyPollInterval = 250 * time.Millisecond
+
+// HistoryHandler receives messages delivered by a MailServer in response to RequestHistory.
+type HistoryHandler func(message *whisper.ReceivedMessage)
+
+// HistoryRequest is a request for historic messages sent with RequestHistory.
+// It can be stopped once all the expected messages are delivered.
+type HistoryRequest struct {
+	shh      *whisper.Whisper
+	filterID string
+
+	stopOnce sync.Once
+	quit     chan struct{}
+	done     chan struct{}
+}
+
+// RequestHistory requests historic messages sent within the time range of r to r.Topic,
+// from a MailServer peer, and passes the ones matching the filter to handler as they arrive.
+// Filter must have a key to decrypt the messages, it's installed for r.Topic with
+// peer-to-peer messages allowed, as MailServer delivers messages this way. Handler is
+// called from a single goroutine, until the returned request is stopped; it must not
+// stop the request itself.
+func (s *MailService) RequestHistory(r MessagesRequest, filter *whisper.Filter, handler HistoryHandler) (*HistoryRequest, error) {
+	shh, err := s.provider.WhisperService()
+	if err != nil {
+		return nil, err
+	}
+
+	node, err := s.provider.Node()
+	if err != nil {
+		return nil, err
+	}
+
+	return requestHistory(shh, shh, node, r, filter, handler)
+}
+
+func requestHistory(requester historicMessagesRequester, shh *whisper.Whisper, node *node.Node,
+	r MessagesRequest, filter *whisper.Filter, handler HistoryHandler) (*HistoryRequest, error) {
+	setMessagesRequestDefaults(&r)
+
+	filter.AllowP2P = true
+	filter.Topics = [][]byte{r.Topic[:]}
+
+	filterID, err := shh.Subscribe(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &HistoryRequest{
+		shh:      shh,
+		filterID: filterID,
+		quit:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	go req.deliver(filter, handler)
+
+	if err := sendMessagesRequest(requester, shh, node, r); err != nil {
+		req.Stop()
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// deliver passes messages received by the filter to handler, until the request is stopped.
+func (r *HistoryRequest) deliver(filter *whisper.Filter, handler HistoryHandler) {
+	defer close(r.done)
+
+	ticker := time.NewTicker(historyPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.quit:
+			return
+		case <-ticker.C:
+			for _, message := range filter.Retrieve() {
+				handler(message)
+			}
+		}
+	}
+}
+
+// Stop removes the filter of the request and waits until its messages are no longer delivered.
+// Messages MailServer sends afterwards are ignored.
+func (r *HistoryRequest) Stop() {
+	r.stopOnce.Do(func() {
+		close(r.quit)
+		r.shh.Unsubscribe(r.filterID) // nolint: errcheck
+	})
+	<-r.done
+}
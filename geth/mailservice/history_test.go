@@ -0,0 +1,117 @@
+package mailservice
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/p2p/discover"
+	whisper "github.com/ethereum/go-ethereum/whisper/whisperv6"
+	"github.com/stretchr/testify/require"
+)
+
+// stubMailServer records requests for historic messages, and responds
+// by injecting given envelopes into whisper.
+type stubMailServer struct {
+	shh       *whisper.Whisper
+	response  []*whisper.Envelope
+	peerIDs   [][]byte
+	envelopes []*whisper.Envelope
+}
+
+func (s *stubMailServer) RequestHistoricMessages(peerID []byte, envelope *whisper.Envelope) error {
+	s.peerIDs = append(s.peerIDs, peerID)
+	s.envelopes = append(s.envelopes, envelope)
+
+	for _, e := range s.response {
+		if err := s.shh.Send(e); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func TestRequestHistory(t *testing.T) {
+	shh := whisper.New(nil)
+	require.NoError(t, shh.Start(nil))
+	defer shh.Stop() // nolint: errcheck
+
+	// Node is ephemeral (only in memory).
+	nodeA, err := node.New(&node.Config{NoUSB: true})
+	require.NoError(t, err)
+	require.NoError(t, nodeA.Start())
+	defer nodeA.Stop() // nolint: errcheck
+
+	const mailServerPeer = "enode://b7e65e1bedc2499ee6cbd806945af5e7df0e59e4070c96821570bd581473eade24a489f5ec95d060c0db118c879403ab88d827d3766978f28708989d35474f87@[::]:51920"
+	mailServerNode, err := discover.ParseNode(mailServerPeer)
+	require.NoError(t, err)
+
+	authKeyID, err := shh.AddSymKeyFromPassword("mailserver-password")
+	require.NoError(t, err)
+	authKey, err := shh.GetSymKey(authKeyID)
+	require.NoError(t, err)
+
+	messagesKeyID, err := shh.GenerateSymKey()
+	require.NoError(t, err)
+	messagesKey, err := shh.GetSymKey(messagesKeyID)
+	require.NoError(t, err)
+
+	topic := whisper.TopicType{0x01, 0x02, 0x03, 0x04}
+	params := whisper.MessageParams{
+		TTL:      10,
+		PoW:      shh.MinPow(),
+		Payload:  []byte("hello"),
+		KeySym:   messagesKey,
+		Topic:    topic,
+		WorkTime: 1,
+	}
+	message, err := whisper.NewSentMessage(&params)
+	require.NoError(t, err)
+	envelope, err := message.Wrap(&params)
+	require.NoError(t, err)
+
+	mailServer := &stubMailServer{shh: shh, response: []*whisper.Envelope{envelope}}
+	received := make(chan *whisper.ReceivedMessage, 1)
+
+	req, err := requestHistory(mailServer, shh, nodeA, MessagesRequest{
+		MailServerPeer: mailServerPeer,
+		From:           100,
+		To:             200,
+		Topic:          topic,
+		SymKeyID:       authKeyID,
+	}, &whisper.Filter{KeySym: messagesKey}, func(message *whisper.ReceivedMessage) {
+		received <- message
+	})
+	require.NoError(t, err)
+	defer req.Stop()
+
+	// request is sent to the MailServer, with the given time range and topic
+	require.Len(t, mailServer.envelopes, 1)
+	require.Equal(t, mailServerNode.ID[:], mailServer.peerIDs[0])
+
+	request, err := mailServer.envelopes[0].OpenSymmetric(authKey)
+	require.NoError(t, err)
+	require.True(t, request.ValidateAndParse())
+	require.Equal(t, uint32(100), binary.BigEndian.Uint32(request.Payload))
+	require.Equal(t, uint32(200), binary.BigEndian.Uint32(request.Payload[4:]))
+	require.Equal(t, topic[:], request.Payload[8:])
+
+	// historic messages are passed to handler
+	select {
+	case message := <-received:
+		require.Equal(t, []byte("hello"), message.Payload)
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "historic message is not delivered")
+	}
+}
+
+func TestRequestHistoryFailure(t *testing.T) {
+	shh := whisper.New(nil)
+
+	_, err := requestHistory(&stubMailServer{shh: shh}, shh, nil, MessagesRequest{
+		MailServerPeer: "invalid-address",
+	}, &whisper.Filter{KeySym: make([]byte, 32)}, func(*whisper.ReceivedMessage) {})
+	require.EqualError(t, err, "invalid mailServerPeer value: invalid URL scheme, want \"enode\"")
+}
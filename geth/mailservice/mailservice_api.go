@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/ethereum/go-ethereum/node"
 	"github.com/ethereum/go-ethereum/p2p/discover"
 	whisper "github.com/ethereum/go-ethereum/whisper/whisperv6"
 	"github.com/status-im/status-go/geth/log"
@@ -80,26 +81,39 @@ func (api *PublicAPI) RequestMessages(_ context.Context, r MessagesRequest) (boo
 		return false, err
 	}
 
+	if err := sendMessagesRequest(shh, shh, node, r); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// historicMessagesRequester sends requests for historic messages to MailServer peers.
+// It's implemented by *whisper.Whisper.
+type historicMessagesRequester interface {
+	RequestHistoricMessages(peerID []byte, envelope *whisper.Envelope) error
+}
+
+// sendMessagesRequest sends a request for historic messages to a MailServer peer with
+// requester. Request is authenticated with a symmetric key kept by shh, and signed with
+// the key of the node.
+func sendMessagesRequest(requester historicMessagesRequester, shh *whisper.Whisper, node *node.Node, r MessagesRequest) error {
 	mailServerNode, err := discover.ParseNode(r.MailServerPeer)
 	if err != nil {
-		return false, fmt.Errorf("%v: %v", ErrInvalidMailServerPeer, err)
+		return fmt.Errorf("%v: %v", ErrInvalidMailServerPeer, err)
 	}
 
 	symKey, err := shh.GetSymKey(r.SymKeyID)
 	if err != nil {
-		return false, fmt.Errorf("%v: %v", ErrInvalidSymKeyID, err)
+		return fmt.Errorf("%v: %v", ErrInvalidSymKeyID, err)
 	}
 
 	envelope, err := makeEnvelop(makePayload(r), symKey, node.Server().PrivateKey, shh.MinPow())
 	if err != nil {
-		return false, err
-	}
-
-	if err := shh.RequestHistoricMessages(mailServerNode.ID[:], envelope); err != nil {
-		return false, err
+		return err
 	}
 
-	return true, nil
+	return requester.RequestHistoricMessages(mailServerNode.ID[:], envelope)
 }
 
 // makeEnvelop makes an envelop for a historic messages request.
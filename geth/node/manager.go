@@ -15,6 +15,7 @@ import (
 	"github.com/ethereum/go-ethereum/node"
 	"github.com/ethereum/go-ethereum/p2p/discover"
 	whisper "github.com/ethereum/go-ethereum/whisper/whisperv6"
+	"github.com/status-im/status-go/geth/common"
 	"github.com/status-im/status-go/geth/log"
 	"github.com/status-im/status-go/geth/mailservice"
 	"github.com/status-im/status-go/geth/params"
@@ -26,7 +27,7 @@ var (
 	ErrNodeExists                  = errors.New("node is already running")
 	ErrNoRunningNode               = errors.New("there is no running node")
 	ErrInvalidNodeManager          = errors.New("node manager is not properly initialized")
-	ErrInvalidWhisperService       = errors.New("whisper service is unavailable")
+	ErrInvalidWhisperService       = common.ErrWhisperServiceUnavailable
 	ErrInvalidLightEthereumService = errors.New("LES service is unavailable")
 	ErrInvalidAccountManager       = errors.New("could not retrieve account manager")
 	ErrAccountKeyStoreMissing      = errors.New("account key store is not set")
@@ -279,6 +280,17 @@ func (m *NodeManager) NodeConfig() (*params.NodeConfig, error) {
 	return m.config, nil
 }
 
+// ChainID returns chain ID of the network running node is connected to
+func (m *NodeManager) ChainID() (uint64, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if err := m.isNodeAvailable(); err != nil {
+		return 0, err
+	}
+	return m.config.NetworkID, nil
+}
+
 // LightEthereumService exposes reference to LES service running on top of the node
 func (m *NodeManager) LightEthereumService() (*les.LightEthereum, error) {
 	m.mu.RLock()
@@ -299,7 +311,8 @@ func (m *NodeManager) LightEthereumService() (*les.LightEthereum, error) {
 	return m.lesService, nil
 }
 
-// WhisperService exposes reference to Whisper service running on top of the node
+// WhisperService exposes reference to Whisper service running on top of the node.
+// ErrInvalidWhisperService (common.ErrWhisperServiceUnavailable) is returned if it's not running.
 func (m *NodeManager) WhisperService() (*whisper.Whisper, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
@@ -319,6 +332,12 @@ func (m *NodeManager) WhisperService() (*whisper.Whisper, error) {
 	return m.whisperService, nil
 }
 
+// IsWhisperEnabled returns true if Whisper service is running on the node
+func (m *NodeManager) IsWhisperEnabled() bool {
+	_, err := m.WhisperService()
+	return err == nil
+}
+
 // AccountManager exposes reference to node's accounts manager
 func (m *NodeManager) AccountManager() (*accounts.Manager, error) {
 	m.mu.RLock()
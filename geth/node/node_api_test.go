@@ -1,6 +1,8 @@
 package node
 
 import (
+	"io/ioutil"
+	"os"
 	"testing"
 
 	whisper "github.com/ethereum/go-ethereum/whisper/whisperv6"
@@ -10,7 +12,11 @@ import (
 )
 
 func TestWhisperLightModeEnabledSetsEmptyBloomFilter(t *testing.T) {
-	config, err := MakeTestNodeConfig(GetNetworkID())
+	dataDir, err := ioutil.TempDir("", "node-api")
+	require.NoError(t, err)
+	defer os.RemoveAll(dataDir) //nolint: errcheck
+
+	config, err := MakeTestNodeConfigWithDataDir(GetNetworkID(), dataDir)
 	require.NoError(t, err)
 	config.WhisperConfig.LightClient = true
 
@@ -33,7 +39,11 @@ func TestWhisperLightModeEnabledSetsEmptyBloomFilter(t *testing.T) {
 }
 
 func TestWhisperLightModeEnabledSetsNilBloomFilter(t *testing.T) {
-	config, err := MakeTestNodeConfig(GetNetworkID())
+	dataDir, err := ioutil.TempDir("", "node-api")
+	require.NoError(t, err)
+	defer os.RemoveAll(dataDir) //nolint: errcheck
+
+	config, err := MakeTestNodeConfigWithDataDir(GetNetworkID(), dataDir)
 	require.NoError(t, err)
 	config.WhisperConfig.LightClient = false
 
@@ -77,6 +77,17 @@ func (m *Manager) TransactionQueue() *queue.TxQueue {
 	return m.txQueue
 }
 
+// LocalNonce returns the nonce the next transaction sent from the account is going to use,
+// unless the node reports a higher one. False is returned if no transaction of the account
+// was completed by the manager yet.
+func (m *Manager) LocalNonce(address gethcommon.Address) (uint64, bool) {
+	val, ok := m.localNonce.Load(address)
+	if !ok {
+		return 0, false
+	}
+	return val.(uint64), true
+}
+
 // QueueTransaction puts a transaction into the queue.
 func (m *Manager) QueueTransaction(tx *common.QueuedTx) error {
 	to := "<nil>"
@@ -348,6 +348,8 @@ func (s *TxQueueTestSuite) TestLocalNonce() {
 	for i := 0; i < txCount+2; i++ {
 		s.setupStatusBackend(account, password, nil)
 	}
+	_, ok := s.manager.LocalNonce(common.FromAddress(TestConfig.Account1.Address))
+	s.False(ok)
 	nonce := hexutil.Uint64(0)
 	for i := 0; i < txCount; i++ {
 		tx := common.CreateTransaction(context.Background(), common.SendTxArgs{
@@ -362,8 +364,9 @@ func (s *TxQueueTestSuite) TestLocalNonce() {
 		s.NoError(err)
 		s.NoError(rst.Error)
 		s.Equal(rst.Hash, hash)
-		resultNonce, _ := s.manager.localNonce.Load(tx.Args.From)
-		s.Equal(uint64(i)+1, resultNonce.(uint64))
+		resultNonce, ok := s.manager.LocalNonce(tx.Args.From)
+		s.True(ok)
+		s.Equal(uint64(i)+1, resultNonce)
 	}
 	nonce = hexutil.Uint64(5)
 	tx := common.CreateTransaction(context.Background(), common.SendTxArgs{
@@ -106,6 +106,7 @@ func (s *ManagerTestSuite) TestReferencesWithoutStartedNode() {
 		s.Nil(obj)
 		s.Equal(tc.expectedErr, err)
 	}
+	s.False(s.NodeManager.IsWhisperEnabled())
 }
 
 func (s *ManagerTestSuite) TestReferencesWithStartedNode() {
@@ -177,6 +178,7 @@ func (s *ManagerTestSuite) TestReferencesWithStartedNode() {
 		s.NotNil(obj)
 		s.IsType(tc.expectedType, obj)
 	}
+	s.True(s.NodeManager.IsWhisperEnabled())
 }
 
 func (s *ManagerTestSuite) TestNodeStartStop() {
@@ -237,8 +237,12 @@ func WaitClosed(c <-chan struct{}, d time.Duration) error {
 // MakeTestNodeConfig defines a function to return a giving params.NodeConfig
 // where specific network addresses are assigned based on provided network id.
 func MakeTestNodeConfig(networkID int) (*params.NodeConfig, error) {
-	testDir := filepath.Join(TestDataDir, TestNetworkNames[networkID])
+	return MakeTestNodeConfigWithDataDir(networkID, filepath.Join(TestDataDir, TestNetworkNames[networkID]))
+}
 
+// MakeTestNodeConfigWithDataDir works like MakeTestNodeConfig, but the node keeps
+// its data in a given directory, e.g. a temporary one removed by the test.
+func MakeTestNodeConfigWithDataDir(networkID int, testDir string) (*params.NodeConfig, error) {
 	if runtime.GOOS == "windows" {
 		testDir = filepath.ToSlash(testDir)
 	}